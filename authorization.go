@@ -0,0 +1,352 @@
+package privy
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrInvalidAuthorizationKey is returned when an authorization key cannot be parsed.
+var ErrInvalidAuthorizationKey = errors.New("privy: invalid authorization key")
+
+// authorizationKeyPrefix is the prefix Privy adds to authorization keys exported from the dashboard.
+const authorizationKeyPrefix = "wallet-auth:"
+
+// AuthorizationSigner signs canonical authorization payloads.
+//
+// Implementations must return an ASN.1 DER-encoded ECDSA P-256 signature over
+// SHA-256(payload). This matches what cloud KMS and PKCS#11 HSMs produce for
+// ECDSA_SHA_256 keys, so the authorization key never has to leave the device.
+type AuthorizationSigner interface {
+	Sign(ctx context.Context, payload []byte) ([]byte, error)
+}
+
+// AuthorizationMultiSigner is an AuthorizationSigner that produces several
+// signatures for the same payload, such as a KeyQuorumSigner.
+// The client sends all of them in the privy-authorization-signature header.
+type AuthorizationMultiSigner interface {
+	AuthorizationSigner
+	SignAll(ctx context.Context, payload []byte) ([][]byte, error)
+}
+
+// AuthorizationSignerFunc adapts an ordinary function to the AuthorizationSigner interface.
+type AuthorizationSignerFunc func(ctx context.Context, payload []byte) ([]byte, error)
+
+// Sign calls f(ctx, payload).
+func (f AuthorizationSignerFunc) Sign(ctx context.Context, payload []byte) ([]byte, error) {
+	return f(ctx, payload)
+}
+
+// ecdsaSigner signs authorization payloads with an in-memory P-256 private key.
+type ecdsaSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+// Sign returns the ASN.1 DER-encoded ECDSA signature of SHA-256(payload).
+func (s *ecdsaSigner) Sign(_ context.Context, payload []byte) ([]byte, error) {
+	hash := sha256.Sum256(payload)
+	return ecdsa.SignASN1(rand.Reader, s.key, hash[:])
+}
+
+// NewECDSASigner returns an in-memory signer for a P-256 private key.
+func NewECDSASigner(key *ecdsa.PrivateKey) (AuthorizationSigner, error) {
+	if key == nil || key.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("%w: expected a P-256 ECDSA key", ErrInvalidAuthorizationKey)
+	}
+	return &ecdsaSigner{key: key}, nil
+}
+
+// NewPKCS8Signer returns an in-memory signer for a base64-encoded PKCS#8 P-256 key,
+// the format shown in the Privy dashboard. The "wallet-auth:" prefix is optional.
+func NewPKCS8Signer(privateKey string) (AuthorizationSigner, error) {
+	key, err := parseAuthorizationKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &ecdsaSigner{key: key}, nil
+}
+
+// NewPEMSigner returns an in-memory signer for a PEM-encoded P-256 key.
+// Both "PRIVATE KEY" (PKCS#8) and "EC PRIVATE KEY" (SEC 1) blocks are accepted.
+func NewPEMSigner(pemData []byte) (AuthorizationSigner, error) {
+	key, err := parsePEMKey(pemData)
+	if err != nil {
+		return nil, err
+	}
+	return &ecdsaSigner{key: key}, nil
+}
+
+// fileSigner loads the authorization key from disk on every signature.
+type fileSigner struct {
+	path string
+}
+
+// NewFileSigner returns a signer that reads the authorization key from path
+// each time it signs, so the key is not kept in process memory between
+// requests and rotated files are picked up without a restart.
+// The file may contain a PEM block or a base64 PKCS#8 key.
+func NewFileSigner(path string) AuthorizationSigner {
+	return &fileSigner{path: path}
+}
+
+// Sign reads the key file and signs payload with it.
+func (s *fileSigner) Sign(ctx context.Context, payload []byte) ([]byte, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("privy: read authorization key: %w", err)
+	}
+
+	var key *ecdsa.PrivateKey
+	if bytes.Contains(data, []byte("-----BEGIN")) {
+		key, err = parsePEMKey(data)
+	} else {
+		key, err = parseAuthorizationKey(string(data))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (&ecdsaSigner{key: key}).Sign(ctx, payload)
+}
+
+// KeyQuorumSigner collects signatures from several authorization keys so that
+// requests satisfy a key quorum's threshold.
+type KeyQuorumSigner struct {
+	quorum    *KeyQuorum
+	signers   []AuthorizationSigner
+	threshold int
+}
+
+// NewKeyQuorumSigner creates a signer for the given key quorum.
+// The quorum's AuthorizationThreshold determines how many signatures are required;
+// when it is zero every signer must succeed.
+func NewKeyQuorumSigner(quorum *KeyQuorum, signers ...AuthorizationSigner) (*KeyQuorumSigner, error) {
+	if quorum == nil {
+		return nil, errors.New("privy: key quorum is required")
+	}
+	if len(signers) == 0 {
+		return nil, errors.New("privy: key quorum signer needs at least one signer")
+	}
+	threshold := quorum.AuthorizationThreshold
+	if threshold == 0 {
+		threshold = len(signers)
+	}
+	if threshold > len(signers) {
+		return nil, fmt.Errorf("privy: key quorum %s requires %d signatures, got %d signers", quorum.ID, threshold, len(signers))
+	}
+	return &KeyQuorumSigner{quorum: quorum, signers: signers, threshold: threshold}, nil
+}
+
+// Quorum returns the key quorum this signer was created for.
+func (s *KeyQuorumSigner) Quorum() *KeyQuorum {
+	return s.quorum
+}
+
+// SignAll asks every member signer for a signature and returns those that succeeded.
+// It fails if fewer signatures than the quorum threshold were collected.
+func (s *KeyQuorumSigner) SignAll(ctx context.Context, payload []byte) ([][]byte, error) {
+	sigs := make([][]byte, 0, len(s.signers))
+	var errs []error
+	for _, signer := range s.signers {
+		sig, err := signer.Sign(ctx, payload)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sigs = append(sigs, sig)
+	}
+	if len(sigs) < s.threshold {
+		return nil, fmt.Errorf("privy: key quorum %s collected %d of %d signatures: %w",
+			s.quorum.ID, len(sigs), s.threshold, errors.Join(errs...))
+	}
+	return sigs, nil
+}
+
+// Sign returns the first signature collected by SignAll.
+// The client calls SignAll directly so that every signature is sent.
+func (s *KeyQuorumSigner) Sign(ctx context.Context, payload []byte) ([]byte, error) {
+	sigs, err := s.SignAll(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	return sigs[0], nil
+}
+
+// WithAuthorizationKey configures the client to sign requests with an authorization key.
+// The key is the base64-encoded PKCS#8 P-256 private key shown in the Privy dashboard,
+// with or without its "wallet-auth:" prefix.
+//
+// When set, requests that act on a wallet (RPC calls, raw_sign, export, updates)
+// get a privy-authorization-signature header computed automatically, unless
+// an explicit signature is passed to the method.
+func WithAuthorizationKey(privateKey string) ClientOption {
+	return func(c *Client) {
+		signer, err := NewPKCS8Signer(privateKey)
+		if err != nil {
+			c.authorizationErr = err
+			return
+		}
+		c.authorizationSigner = signer
+	}
+}
+
+// WithAuthorizationSigner configures the client to sign requests with a custom
+// AuthorizationSigner, e.g. one backed by a KMS or HSM, or a KeyQuorumSigner.
+func WithAuthorizationSigner(signer AuthorizationSigner) ClientOption {
+	return func(c *Client) {
+		c.authorizationSigner = signer
+	}
+}
+
+// parseAuthorizationKey decodes a base64 PKCS#8 P-256 private key.
+func parseAuthorizationKey(s string) (*ecdsa.PrivateKey, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), authorizationKeyPrefix)
+	der, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAuthorizationKey, err)
+	}
+	return parsePKCS8P256(der)
+}
+
+// parsePEMKey decodes a PEM-encoded PKCS#8 or SEC 1 P-256 private key.
+func parsePEMKey(data []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block found", ErrInvalidAuthorizationKey)
+	}
+	switch block.Type {
+	case "PRIVATE KEY":
+		return parsePKCS8P256(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAuthorizationKey, err)
+		}
+		if key.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("%w: expected a P-256 ECDSA key", ErrInvalidAuthorizationKey)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported PEM block %q", ErrInvalidAuthorizationKey, block.Type)
+	}
+}
+
+// parsePKCS8P256 parses a DER-encoded PKCS#8 key and checks that it is on P-256.
+func parsePKCS8P256(der []byte) (*ecdsa.PrivateKey, error) {
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAuthorizationKey, err)
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok || key.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("%w: expected a P-256 ECDSA key", ErrInvalidAuthorizationKey)
+	}
+	return key, nil
+}
+
+// authorizationPayload is the structure Privy expects to be signed.
+type authorizationPayload struct {
+	Version int               `json:"version"`
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+	Headers map[string]string `json:"headers"`
+}
+
+// AuthorizationPayload returns the canonical JSON payload that must be signed
+// to authorize a request. It is exposed for callers that sign requests
+// out-of-band and pass the resulting signature explicitly.
+func (c *Client) AuthorizationPayload(method, url string, body any) ([]byte, error) {
+	if c == nil {
+		return nil, ErrNilClient
+	}
+	var jsonBody []byte
+	if body != nil {
+		var err error
+		if jsonBody, err = json.Marshal(body); err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+	}
+	return c.authorizationPayload(method, url, jsonBody)
+}
+
+// authorizationPayload builds the canonical payload for an already-marshaled body.
+func (c *Client) authorizationPayload(method, url string, jsonBody []byte) ([]byte, error) {
+	payload := authorizationPayload{
+		Version: 1,
+		Method:  method,
+		URL:     url,
+		Body:    jsonBody,
+		Headers: map[string]string{"privy-app-id": c.appID},
+	}
+	return canonicalJSON(payload)
+}
+
+// authorizationSignature computes the privy-authorization-signature header value
+// for a request, or returns "" if no authorization key is configured.
+func (c *Client) authorizationSignature(ctx context.Context, method, url string, jsonBody []byte) (string, error) {
+	if c.authorizationErr != nil {
+		return "", c.authorizationErr
+	}
+	if c.authorizationSigner == nil {
+		return "", nil
+	}
+	payload, err := c.authorizationPayload(method, url, jsonBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to build authorization payload: %w", err)
+	}
+
+	var sigs [][]byte
+	if multi, ok := c.authorizationSigner.(AuthorizationMultiSigner); ok {
+		sigs, err = multi.SignAll(ctx, payload)
+	} else {
+		var sig []byte
+		sig, err = c.authorizationSigner.Sign(ctx, payload)
+		sigs = [][]byte{sig}
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to sign authorization payload: %w", err)
+	}
+
+	encoded := make([]string, len(sigs))
+	for i, sig := range sigs {
+		encoded[i] = base64.StdEncoding.EncodeToString(sig)
+	}
+	return strings.Join(encoded, ","), nil
+}
+
+// canonicalJSON serializes v following RFC 8785: object keys sorted,
+// no insignificant whitespace and no HTML escaping.
+func canonicalJSON(v any) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Round-trip through a generic value so nested objects (including
+	// json.RawMessage fields) get their keys sorted as well.
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(generic); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
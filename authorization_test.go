@@ -0,0 +1,302 @@
+package privy
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func generateAuthorizationKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return key, "wallet-auth:" + base64.StdEncoding.EncodeToString(der)
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	input := map[string]any{
+		"b": 1,
+		"a": map[string]any{"z": "<&>", "y": []any{2, 1}},
+	}
+	got, err := canonicalJSON(input)
+	if err != nil {
+		t.Fatalf("canonicalJSON failed: %v", err)
+	}
+	expected := `{"a":{"y":[2,1],"z":"<&>"},"b":1}`
+	if string(got) != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestCanonicalJSON_SortsRawMessage(t *testing.T) {
+	payload := authorizationPayload{
+		Version: 1,
+		Method:  "POST",
+		URL:     "https://api.privy.io/v1/wallets/w1/rpc",
+		Body:    json.RawMessage(`{"params":{"hash":"0x01"},"method":"raw_sign"}`),
+		Headers: map[string]string{"privy-app-id": "app"},
+	}
+	got, err := canonicalJSON(payload)
+	if err != nil {
+		t.Fatalf("canonicalJSON failed: %v", err)
+	}
+	expected := `{"body":{"method":"raw_sign","params":{"hash":"0x01"}},"headers":{"privy-app-id":"app"},"method":"POST","url":"https://api.privy.io/v1/wallets/w1/rpc","version":1}`
+	if string(got) != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestWithAuthorizationKey_SignsRequests(t *testing.T) {
+	key, encoded := generateAuthorizationKey(t)
+
+	var client *Client
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sigHeader := r.Header.Get("privy-authorization-signature")
+		if sigHeader == "" {
+			t.Error("expected privy-authorization-signature header")
+		}
+		sig, err := base64.StdEncoding.DecodeString(sigHeader)
+		if err != nil {
+			t.Fatalf("signature is not base64: %v", err)
+		}
+
+		var decoded any
+		json.Unmarshal(body, &decoded)
+		payload, err := client.AuthorizationPayload(r.Method, "http://"+r.Host+r.URL.Path, decoded)
+		if err != nil {
+			t.Fatalf("failed to build payload: %v", err)
+		}
+		hash := sha256.Sum256(payload)
+		if !ecdsa.VerifyASN1(&key.PublicKey, hash[:], sig) {
+			t.Error("signature does not verify against the authorization key")
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"method": "raw_sign",
+			"data":   map[string]any{"signature": "0xabc", "encoding": "hex"},
+		})
+	}))
+	defer server.Close()
+
+	client = NewClient("app-id", "app-secret",
+		WithBaseURL(server.URL+"/v1"),
+		WithAuthorizationKey(encoded),
+	)
+
+	if _, err := client.RawSign(context.Background(), "wallet-1", "0x1234"); err != nil {
+		t.Fatalf("RawSign failed: %v", err)
+	}
+	if _, err := client.Wallets().Ethereum().SignMessage(context.Background(), "wallet-1", "hello", "utf-8", ""); err != nil {
+		t.Fatalf("SignMessage failed: %v", err)
+	}
+}
+
+func TestWithAuthorizationKey_ExplicitSignatureWins(t *testing.T) {
+	_, encoded := generateAuthorizationKey(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("privy-authorization-signature"); got != "explicit-sig" {
+			t.Errorf("expected explicit signature, got %q", got)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"method": "personal_sign"})
+	}))
+	defer server.Close()
+
+	client := NewClient("app-id", "app-secret",
+		WithBaseURL(server.URL+"/v1"),
+		WithAuthorizationKey(encoded),
+	)
+
+	_, err := client.Wallets().Ethereum().SignMessage(context.Background(), "wallet-1", "hello", "utf-8", "explicit-sig")
+	if err != nil {
+		t.Fatalf("SignMessage failed: %v", err)
+	}
+}
+
+func TestWithAuthorizationKey_UnsignedEndpoints(t *testing.T) {
+	_, encoded := generateAuthorizationKey(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("privy-authorization-signature"); got != "" {
+			t.Errorf("expected no signature on read endpoint, got %q", got)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "wallet-1"})
+	}))
+	defer server.Close()
+
+	client := NewClient("app-id", "app-secret",
+		WithBaseURL(server.URL+"/v1"),
+		WithAuthorizationKey(encoded),
+	)
+
+	if _, err := client.Wallets().Get(context.Background(), "wallet-1"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+}
+
+func TestWithAuthorizationKey_InvalidKey(t *testing.T) {
+	client := NewClient("app-id", "app-secret",
+		WithBaseURL("http://127.0.0.1:0/v1"),
+		WithAuthorizationKey("wallet-auth:not-base64!"),
+	)
+
+	_, err := client.RawSign(context.Background(), "wallet-1", "0x1234")
+	if !errors.Is(err, ErrInvalidAuthorizationKey) {
+		t.Errorf("expected ErrInvalidAuthorizationKey, got %v", err)
+	}
+}
+
+func TestParseAuthorizationKey_RejectsNonP256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+
+	_, err = parseAuthorizationKey(base64.StdEncoding.EncodeToString(der))
+	if !errors.Is(err, ErrInvalidAuthorizationKey) {
+		t.Errorf("expected ErrInvalidAuthorizationKey, got %v", err)
+	}
+}
+
+func verifyAuthorizationSignature(t *testing.T, key *ecdsa.PrivateKey, payload, sig []byte) {
+	t.Helper()
+	hash := sha256.Sum256(payload)
+	if !ecdsa.VerifyASN1(&key.PublicKey, hash[:], sig) {
+		t.Error("signature does not verify")
+	}
+}
+
+func TestNewPEMSigner(t *testing.T) {
+	key, _ := generateAuthorizationKey(t)
+
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(key)
+	sec1, _ := x509.MarshalECPrivateKey(key)
+
+	blocks := map[string]*pem.Block{
+		"PKCS8": {Type: "PRIVATE KEY", Bytes: pkcs8},
+		"SEC1":  {Type: "EC PRIVATE KEY", Bytes: sec1},
+	}
+	for name, block := range blocks {
+		t.Run(name, func(t *testing.T) {
+			signer, err := NewPEMSigner(pem.EncodeToMemory(block))
+			if err != nil {
+				t.Fatalf("NewPEMSigner failed: %v", err)
+			}
+			payload := []byte(`{"version":1}`)
+			sig, err := signer.Sign(context.Background(), payload)
+			if err != nil {
+				t.Fatalf("Sign failed: %v", err)
+			}
+			verifyAuthorizationSignature(t, key, payload, sig)
+		})
+	}
+
+	if _, err := NewPEMSigner([]byte("not pem")); !errors.Is(err, ErrInvalidAuthorizationKey) {
+		t.Errorf("expected ErrInvalidAuthorizationKey, got %v", err)
+	}
+}
+
+func TestNewFileSigner(t *testing.T) {
+	key, encoded := generateAuthorizationKey(t)
+	path := filepath.Join(t.TempDir(), "auth.key")
+	if err := os.WriteFile(path, []byte(encoded+"\n"), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+
+	signer := NewFileSigner(path)
+	payload := []byte(`{"version":1}`)
+	sig, err := signer.Sign(context.Background(), payload)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	verifyAuthorizationSignature(t, key, payload, sig)
+
+	// Rotated keys are picked up on the next signature
+	rotated, rotatedEncoded := generateAuthorizationKey(t)
+	os.WriteFile(path, []byte(rotatedEncoded), 0o600)
+	sig, err = signer.Sign(context.Background(), payload)
+	if err != nil {
+		t.Fatalf("Sign after rotation failed: %v", err)
+	}
+	verifyAuthorizationSignature(t, rotated, payload, sig)
+
+	if _, err := NewFileSigner(filepath.Join(t.TempDir(), "missing")).Sign(context.Background(), payload); err == nil {
+		t.Error("expected error for missing key file")
+	}
+}
+
+func TestKeyQuorumSigner(t *testing.T) {
+	key1, _ := generateAuthorizationKey(t)
+	key2, _ := generateAuthorizationKey(t)
+	signer1, _ := NewECDSASigner(key1)
+	signer2, _ := NewECDSASigner(key2)
+	failing := AuthorizationSignerFunc(func(ctx context.Context, payload []byte) ([]byte, error) {
+		return nil, errors.New("hsm unavailable")
+	})
+
+	quorum := &KeyQuorum{ID: "kq-1", AuthorizationThreshold: 2}
+
+	if _, err := NewKeyQuorumSigner(quorum, signer1); err == nil {
+		t.Error("expected error when fewer signers than threshold")
+	}
+
+	qs, err := NewKeyQuorumSigner(quorum, signer1, failing, signer2)
+	if err != nil {
+		t.Fatalf("NewKeyQuorumSigner failed: %v", err)
+	}
+
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("privy-authorization-signature")
+		json.NewEncoder(w).Encode(map[string]any{"method": "raw_sign"})
+	}))
+	defer server.Close()
+
+	client := NewClient("app-id", "app-secret",
+		WithBaseURL(server.URL+"/v1"),
+		WithAuthorizationSigner(qs),
+	)
+	if _, err := client.RawSign(context.Background(), "wallet-1", "0x1234"); err != nil {
+		t.Fatalf("RawSign failed: %v", err)
+	}
+
+	parts := strings.Split(header, ",")
+	if len(parts) != 2 {
+		t.Fatalf("expected 2 comma-separated signatures, got %q", header)
+	}
+
+	payload, _ := client.AuthorizationPayload("POST", server.URL+"/v1/wallets/wallet-1/raw_sign",
+		&RawSignHashRequest{Params: RawSignHashParams{Hash: "0x1234"}})
+	for i, key := range []*ecdsa.PrivateKey{key1, key2} {
+		sig, _ := base64.StdEncoding.DecodeString(parts[i])
+		verifyAuthorizationSignature(t, key, payload, sig)
+	}
+
+	// Below threshold fails the request
+	strict, _ := NewKeyQuorumSigner(&KeyQuorum{ID: "kq-2"}, signer1, failing)
+	if _, err := strict.SignAll(context.Background(), payload); err == nil {
+		t.Error("expected error when threshold is not met")
+	}
+}
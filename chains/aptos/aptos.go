@@ -5,19 +5,28 @@ package aptos
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/api"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/aptos-labs/aptos-go-sdk/crypto"
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
 )
 
+var _ chains.Chain = (*Helper)(nil)
+
 // Helper provides convenience methods for Aptos operations using Privy wallets.
 type Helper struct {
-	client      *privy.Client
-	aptosClient *aptos.Client
+	client       *privy.Client
+	aptosClient  *aptos.Client
+	pollInterval time.Duration
 }
 
 // Option configures the Helper.
@@ -44,10 +53,15 @@ func WithAptosClient(c *aptos.Client) Option {
 	return func(h *Helper) { h.aptosClient = c }
 }
 
+// WithPollInterval sets how often WaitForConfirmation polls the node.
+func WithPollInterval(d time.Duration) Option {
+	return func(h *Helper) { h.pollInterval = d }
+}
+
 // NewHelper creates a new Aptos helper.
 // Options are applied in order: testnet defaults, client-level chain options, then direct options.
 func NewHelper(client *privy.Client, opts ...Option) *Helper {
-	h := &Helper{client: client}
+	h := &Helper{client: client, pollInterval: chains.DefaultPollInterval}
 	if client.Testnet() {
 		WithTestnet()(h)
 	}
@@ -69,10 +83,13 @@ func NewHelper(client *privy.Client, opts ...Option) *Helper {
 }
 
 // Transfer sends APT from a Privy wallet to a destination address.
-// Amount is in octas (1 APT = 100_000_000 octas).
+// amount is in octas (1 APT = 100_000_000 octas) as a decimal string.
 // The walletID is the Privy wallet ID. The public key and address are fetched
 // from Privy automatically.
-func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount uint64) (string, error) {
+func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "aptos.transfer", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
 	if h.aptosClient == nil {
 		return "", fmt.Errorf("aptos client not initialized")
 	}
@@ -82,34 +99,101 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 	if err != nil {
 		return "", fmt.Errorf("aptos: get wallet: %w", err)
 	}
-	if wallet.PublicKey == "" {
-		return "", fmt.Errorf("aptos: wallet %s has no public key", walletID)
+	pubKey, err := walletPublicKey(wallet)
+	if err != nil {
+		return "", err
+	}
+
+	// Build raw transaction
+	_, buildSpan := h.client.StartSpan(ctx, "aptos.build_transaction")
+	rawTxn, err := h.buildTransfer(wallet.Address, destination, amount)
+	buildSpan.End(err)
+	if err != nil {
+		return "", err
+	}
+
+	// Get signing message — includes sha3_256("APTOS::RawTransaction") prefix + BCS bytes
+	signingMessage, err := rawTxn.SigningMessage()
+	if err != nil {
+		return "", fmt.Errorf("aptos: failed to get signing message: %w", err)
+	}
+
+	// Sign via Privy raw_sign — pass the signing message bytes directly as "hash".
+	// For Ed25519 wallets, Privy signs the provided bytes directly (Ed25519 does
+	// its own internal SHA-512 hashing as part of the signing algorithm).
+	hashHex := "0x" + hex.EncodeToString(signingMessage)
+	signCtx, signSpan := h.client.StartSpan(ctx, "aptos.sign")
+	signResp, err := h.client.RawSign(signCtx, walletID, hashHex)
+	signSpan.End(err)
+	if err != nil {
+		return "", fmt.Errorf("aptos: failed to sign: %w", err)
+	}
+
+	// Decode the Ed25519 signature
+	sigBytes, err := decodeHexSignature(signResp.Data.Signature)
+	if err != nil {
+		return "", fmt.Errorf("aptos: failed to decode signature: %w", err)
+	}
+
+	sig := &crypto.Ed25519Signature{}
+	copy(sig.Inner[:], sigBytes)
+
+	// Build authenticator
+	auth := &crypto.AccountAuthenticator{
+		Variant: crypto.AccountAuthenticatorEd25519,
+		Auth: &crypto.Ed25519Authenticator{
+			PubKey: pubKey,
+			Sig:    sig,
+		},
+	}
+
+	// Create signed transaction
+	signedTxn, err := rawTxn.SignedTransactionWithAuthenticator(auth)
+	if err != nil {
+		return "", fmt.Errorf("aptos: failed to create signed transaction: %w", err)
+	}
+
+	// Submit to the network
+	_, submitSpan := h.client.StartSpan(ctx, "aptos.broadcast")
+	submitResult, err := h.aptosClient.SubmitTransaction(signedTxn)
+	submitSpan.End(err)
+	if err != nil {
+		return "", fmt.Errorf("aptos: failed to submit transaction: %w", err)
+	}
+
+	return submitResult.Hash, nil
+}
+
+// buildTransfer builds an unsigned aptos_account::transfer transaction.
+func (h *Helper) buildTransfer(from, destination, amount string) (*aptos.RawTransaction, error) {
+	octas, err := strconv.ParseUint(amount, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("aptos: invalid amount %q: %w", amount, err)
 	}
 
 	// Parse sender address
-	sender, err := parseAddress(wallet.Address)
+	sender, err := parseAddress(from)
 	if err != nil {
-		return "", fmt.Errorf("aptos: invalid sender address: %w", err)
+		return nil, fmt.Errorf("aptos: invalid sender address: %w", err)
 	}
 
 	// Parse recipient address
 	recipient, err := parseAddress(destination)
 	if err != nil {
-		return "", fmt.Errorf("aptos: invalid recipient address: %w", err)
+		return nil, fmt.Errorf("aptos: invalid recipient address: %w", err)
 	}
 
 	// Serialize arguments for the transfer entry function
 	recipientBytes, err := bcs.Serialize(&recipient)
 	if err != nil {
-		return "", fmt.Errorf("aptos: failed to serialize recipient: %w", err)
+		return nil, fmt.Errorf("aptos: failed to serialize recipient: %w", err)
 	}
 
-	amountBytes, err := bcs.SerializeU64(amount)
+	amountBytes, err := bcs.SerializeU64(octas)
 	if err != nil {
-		return "", fmt.Errorf("aptos: failed to serialize amount: %w", err)
+		return nil, fmt.Errorf("aptos: failed to serialize amount: %w", err)
 	}
 
-	// Build raw transaction
 	rawTxn, err := h.aptosClient.BuildTransaction(sender,
 		aptos.TransactionPayload{
 			Payload: &aptos.EntryFunction{
@@ -124,38 +208,20 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 		},
 	)
 	if err != nil {
-		return "", fmt.Errorf("aptos: failed to build transaction: %w", err)
-	}
-
-	// Get signing message — includes sha3_256("APTOS::RawTransaction") prefix + BCS bytes
-	signingMessage, err := rawTxn.SigningMessage()
-	if err != nil {
-		return "", fmt.Errorf("aptos: failed to get signing message: %w", err)
-	}
-
-	// Sign via Privy raw_sign — pass the signing message bytes directly as "hash".
-	// For Ed25519 wallets, Privy signs the provided bytes directly (Ed25519 does
-	// its own internal SHA-512 hashing as part of the signing algorithm).
-	hashHex := "0x" + hex.EncodeToString(signingMessage)
-	signResp, err := h.client.RawSign(ctx, walletID, hashHex)
-	if err != nil {
-		return "", fmt.Errorf("aptos: failed to sign: %w", err)
+		return nil, fmt.Errorf("aptos: failed to build transaction: %w", err)
 	}
+	return rawTxn, nil
+}
 
-	// Decode the Ed25519 signature
-	sigBytes, err := decodeHexSignature(signResp.Data.Signature)
-	if err != nil {
-		return "", fmt.Errorf("aptos: failed to decode signature: %w", err)
+// walletPublicKey decodes the wallet's Ed25519 public key.
+// Privy returns it as hex, possibly with a 1-byte scheme prefix (0x00 = Ed25519).
+func walletPublicKey(wallet *privy.Wallet) (*crypto.Ed25519PublicKey, error) {
+	if wallet.PublicKey == "" {
+		return nil, fmt.Errorf("aptos: wallet %s has no public key", wallet.ID)
 	}
-
-	sig := &crypto.Ed25519Signature{}
-	copy(sig.Inner[:], sigBytes)
-
-	// Decode the public key from the wallet.
-	// Privy returns it as hex, possibly with a 1-byte scheme prefix (0x00 = Ed25519).
 	pubKeyBytes, err := decodeHexSignature(wallet.PublicKey)
 	if err != nil {
-		return "", fmt.Errorf("aptos: failed to decode public key: %w", err)
+		return nil, fmt.Errorf("aptos: failed to decode public key: %w", err)
 	}
 	// Strip the Ed25519 scheme prefix byte if present (33 bytes → 32 bytes)
 	if len(pubKeyBytes) == 33 && pubKeyBytes[0] == 0x00 {
@@ -164,33 +230,142 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 
 	pubKey := &crypto.Ed25519PublicKey{}
 	if err := pubKey.FromBytes(pubKeyBytes); err != nil {
-		return "", fmt.Errorf("aptos: failed to parse public key (%d bytes): %w", len(pubKeyBytes), err)
+		return nil, fmt.Errorf("aptos: failed to parse public key (%d bytes): %w", len(pubKeyBytes), err)
 	}
+	return pubKey, nil
+}
 
-	// Build authenticator
-	auth := &crypto.AccountAuthenticator{
-		Variant: crypto.AccountAuthenticatorEd25519,
-		Auth: &crypto.Ed25519Authenticator{
-			PubKey: pubKey,
-			Sig:    sig,
-		},
+// Balance returns the APT balance of address in octas.
+func (h *Helper) Balance(ctx context.Context, address string) (_ string, err error) {
+	_, span := h.client.StartSpan(ctx, "aptos.balance")
+	defer func() { span.End(err) }()
+
+	if h.aptosClient == nil {
+		return "", fmt.Errorf("aptos client not initialized")
+	}
+	account, err := parseAddress(address)
+	if err != nil {
+		return "", fmt.Errorf("aptos: invalid address: %w", err)
 	}
+	balance, err := h.aptosClient.AccountAPTBalance(account)
+	if err != nil {
+		return "", fmt.Errorf("aptos: get balance: %w", err)
+	}
+	return strconv.FormatUint(balance, 10), nil
+}
 
-	// Create signed transaction
-	signedTxn, err := rawTxn.SignedTransactionWithAuthenticator(auth)
+// ValidateAddress checks that address is a 0x-prefixed hex account address of
+// up to 32 bytes.
+func (h *Helper) ValidateAddress(address string) error {
+	if !strings.HasPrefix(address, "0x") {
+		return fmt.Errorf("%w: %q is not 0x-prefixed", chains.ErrInvalidAddress, address)
+	}
+	if _, err := parseAddress(address); err != nil {
+		return fmt.Errorf("%w: %v", chains.ErrInvalidAddress, err)
+	}
+	return nil
+}
+
+// EstimateFee simulates the transfer and returns the fee in octas: gas used
+// times the estimated gas unit price.
+func (h *Helper) EstimateFee(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "aptos.estimate_fee", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	if h.aptosClient == nil {
+		return "", fmt.Errorf("aptos client not initialized")
+	}
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
 	if err != nil {
-		return "", fmt.Errorf("aptos: failed to create signed transaction: %w", err)
+		return "", fmt.Errorf("aptos: get wallet: %w", err)
+	}
+	pubKey, err := walletPublicKey(wallet)
+	if err != nil {
+		return "", err
+	}
+	rawTxn, err := h.buildTransfer(wallet.Address, destination, amount)
+	if err != nil {
+		return "", err
 	}
 
-	// Submit to the network
-	submitResult, err := h.aptosClient.SubmitTransaction(signedTxn)
+	sender := &simulationSigner{address: rawTxn.Sender, pubKey: pubKey}
+	results, err := h.aptosClient.SimulateTransaction(rawTxn, sender, aptos.EstimateGasUnitPrice(true), aptos.EstimateMaxGasAmount(true))
 	if err != nil {
-		return "", fmt.Errorf("aptos: failed to submit transaction: %w", err)
+		return "", fmt.Errorf("aptos: simulate transaction: %w", err)
+	}
+	if len(results) == 0 {
+		return "", fmt.Errorf("aptos: simulate transaction: empty response")
+	}
+	if !results[0].Success {
+		return "", fmt.Errorf("aptos: simulated transaction failed: %s", results[0].VmStatus)
 	}
+	return strconv.FormatUint(results[0].GasUsed*results[0].GasUnitPrice, 10), nil
+}
 
-	return submitResult.Hash, nil
+// WaitForConfirmation polls the node until the transaction is committed.
+// A committed transaction that aborted is reported as
+// chains.ErrTransactionFailed.
+func (h *Helper) WaitForConfirmation(ctx context.Context, txHash string) (err error) {
+	ctx, span := h.client.StartSpan(ctx, "aptos.wait_for_confirmation")
+	defer func() { span.End(err) }()
+
+	if h.aptosClient == nil {
+		return fmt.Errorf("aptos client not initialized")
+	}
+	return chains.Poll(ctx, h.pollInterval, func(ctx context.Context) (bool, error) {
+		txn, err := h.aptosClient.TransactionByHash(txHash)
+		var httpErr *aptos.HttpError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("aptos: get transaction: %w", err)
+		}
+		if txn.Type == api.TransactionVariantPending {
+			return false, nil
+		}
+		if success := txn.Success(); success != nil && !*success {
+			status := ""
+			if user, err := txn.UserTransaction(); err == nil {
+				status = user.VmStatus
+			}
+			return true, fmt.Errorf("aptos: %w: %s", chains.ErrTransactionFailed, status)
+		}
+		return true, nil
+	})
+}
+
+// simulationSigner lets the SDK build the zero-signature authenticator used
+// for simulation from the wallet's public key. It cannot sign.
+type simulationSigner struct {
+	address aptos.AccountAddress
+	pubKey  *crypto.Ed25519PublicKey
+}
+
+func (s *simulationSigner) Sign(msg []byte) (*crypto.AccountAuthenticator, error) {
+	return nil, fmt.Errorf("aptos: simulation signer cannot sign")
+}
+
+func (s *simulationSigner) SignMessage(msg []byte) (crypto.Signature, error) {
+	return nil, fmt.Errorf("aptos: simulation signer cannot sign")
 }
 
+func (s *simulationSigner) SimulationAuthenticator() *crypto.AccountAuthenticator {
+	return &crypto.AccountAuthenticator{
+		Variant: crypto.AccountAuthenticatorEd25519,
+		Auth: &crypto.Ed25519Authenticator{
+			PubKey: s.pubKey,
+			Sig:    &crypto.Ed25519Signature{},
+		},
+	}
+}
+
+func (s *simulationSigner) AuthKey() *crypto.AuthenticationKey { return s.pubKey.AuthKey() }
+
+func (s *simulationSigner) PubKey() crypto.PublicKey { return s.pubKey }
+
+func (s *simulationSigner) AccountAddress() aptos.AccountAddress { return s.address }
+
 func parseAddress(addr string) (aptos.AccountAddress, error) {
 	var address aptos.AccountAddress
 	err := address.ParseStringRelaxed(addr)
@@ -1,9 +1,23 @@
 package aptos
 
 import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
 )
 
 func TestNewHelper(t *testing.T) {
@@ -46,3 +60,137 @@ func TestParseAddress(t *testing.T) {
 		t.Errorf("Expected valid address parsing, got error: %v", err)
 	}
 }
+
+func TestValidateAddress(t *testing.T) {
+	h := NewHelper(privy.NewClient("app-id", "app-secret"))
+
+	valid := []string{"0x1", "0x" + strings.Repeat("ab", 32)}
+	for _, addr := range valid {
+		if err := h.ValidateAddress(addr); err != nil {
+			t.Errorf("ValidateAddress(%q) = %v", addr, err)
+		}
+	}
+	invalid := []string{"", "1", strings.Repeat("ab", 32), "0x" + strings.Repeat("ab", 33), "0xzz"}
+	for _, addr := range invalid {
+		if err := h.ValidateAddress(addr); !errors.Is(err, chains.ErrInvalidAddress) {
+			t.Errorf("ValidateAddress(%q) = %v, want ErrInvalidAddress", addr, err)
+		}
+	}
+}
+
+func TestBalance(t *testing.T) {
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/view" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`["150000000"]`))
+	}))
+	defer node.Close()
+
+	h := NewHelper(privy.NewClient("app-id", "app-secret"), WithNodeURL(node.URL))
+	balance, err := h.Balance(context.Background(), "0x1")
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if balance != "150000000" {
+		t.Errorf("expected 150000000, got %s", balance)
+	}
+}
+
+// pendingTransaction is the node's response to a submitted transaction.
+var pendingTransaction = `{"type":"pending_transaction","hash":"0xabc","sender":"0x1","sequence_number":"0","max_gas_amount":"1000","gas_unit_price":"100","expiration_timestamp_secs":"1","payload":{"type":"entry_function_payload","function":"0x1::aptos_account::transfer","type_arguments":[],"arguments":[]},"signature":{"type":"ed25519_signature","public_key":"0x` + strings.Repeat("11", 32) + `","signature":"0x` + strings.Repeat("22", 64) + `"}}`
+
+func TestTransfer_SignatureVerifies(t *testing.T) {
+	var submitted []byte
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "" || r.URL.Path == "/":
+			w.Write([]byte(`{"chain_id":4,"epoch":"1","ledger_version":"1","ledger_timestamp":"1","block_height":"1"}`))
+		case strings.HasPrefix(r.URL.Path, "/accounts/"):
+			w.Write([]byte(`{"sequence_number":"7","authentication_key":"0x00"}`))
+		case r.URL.Path == "/estimate_gas_price":
+			w.Write([]byte(`{"gas_estimate":100}`))
+		case r.URL.Path == "/transactions" && r.Method == "POST":
+			submitted, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(pendingTransaction))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer node.Close()
+
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, err := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeAptos})
+	if err != nil {
+		t.Fatalf("Create wallet failed: %v", err)
+	}
+
+	h := NewHelper(client, WithNodeURL(node.URL))
+	recipient := "0x" + strings.Repeat("ab", 32)
+	if _, err := h.Transfer(context.Background(), wallet.ID, recipient, "150000000"); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+
+	var signed aptos.SignedTransaction
+	if err := bcs.Deserialize(&signed, submitted); err != nil {
+		t.Fatalf("decode submitted transaction: %v", err)
+	}
+	txn := signed.Transaction
+	if txn.Sender.String() != wallet.Address || txn.SequenceNumber != 7 || txn.ChainId != 4 {
+		t.Errorf("Unexpected transaction sender %s, sequence %d, chain %d", txn.Sender, txn.SequenceNumber, txn.ChainId)
+	}
+	entry, ok := txn.Payload.Payload.(*aptos.EntryFunction)
+	if !ok || entry.Function != "transfer" || len(entry.Args) != 2 {
+		t.Fatalf("Expected aptos_account::transfer, got %+v", txn.Payload.Payload)
+	}
+	if amount, _ := bcs.SerializeU64(150000000); hex.EncodeToString(entry.Args[1]) != hex.EncodeToString(amount) {
+		t.Errorf("Unexpected amount argument %x", entry.Args[1])
+	}
+
+	// The Ed25519 authenticator signs the transaction's signing message
+	// with the wallet key.
+	sender, ok := signed.Authenticator.Auth.(*aptos.Ed25519TransactionAuthenticator)
+	if !ok {
+		t.Fatalf("Expected an Ed25519 authenticator, got %T", signed.Authenticator.Auth)
+	}
+	auth := sender.Sender.Auth.(*crypto.Ed25519Authenticator)
+	pub, _ := hex.DecodeString(strings.TrimPrefix(wallet.PublicKey, "0x"))
+	if hex.EncodeToString(auth.PubKey.Bytes()) != hex.EncodeToString(pub) {
+		t.Errorf("Authenticator public key %x is not the wallet key %x", auth.PubKey.Bytes(), pub)
+	}
+	msg, err := txn.SigningMessage()
+	if err != nil {
+		t.Fatalf("signing message: %v", err)
+	}
+	if !ed25519.Verify(pub, msg, auth.Sig.Bytes()) {
+		t.Error("transaction signature does not verify against the wallet key")
+	}
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	calls := 0
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Transaction not found","error_code":"transaction_not_found"}`))
+		case 2:
+			w.Write([]byte(pendingTransaction))
+		default:
+			w.Write([]byte(`{"type":"block_metadata_transaction","version":"1","hash":"0xabc","state_change_hash":"0x0","event_root_hash":"0x0","gas_used":"0","success":true,"vm_status":"Executed successfully","accumulator_root_hash":"0x0","changes":[],"id":"0x0","epoch":"1","round":"1","events":[],"previous_block_votes_bitvec":[],"proposer":"0x1","failed_proposer_indices":[],"timestamp":"1"}`))
+		}
+	}))
+	defer node.Close()
+
+	h := NewHelper(privy.NewClient("app-id", "app-secret"), WithNodeURL(node.URL), WithPollInterval(time.Millisecond))
+	if err := h.WaitForConfirmation(context.Background(), "0xabc"); err != nil {
+		t.Fatalf("WaitForConfirmation failed: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 polls, got %d", calls)
+	}
+}
@@ -98,7 +98,7 @@ func TestIntegration_Aptos_Transfer(t *testing.T) {
 
 	// Transfer 10000 octas (0.0001 APT)
 	t.Log("Transferring 10000 octas...")
-	txHash, err := h.Transfer(ctx, wallet.ID, destWallet.Address, 10000)
+	txHash, err := h.Transfer(ctx, wallet.ID, destWallet.Address, "10000")
 	if err != nil {
 		t.Fatalf("Transfer failed: %v", err)
 	}
@@ -10,13 +10,16 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
@@ -25,14 +28,17 @@ import (
 	"github.com/btcsuite/btcd/wire"
 )
 
+var _ chains.Chain = (*Helper)(nil)
+
 // Helper provides high-level Bitcoin transaction methods using Privy wallets.
 type Helper struct {
-	client      *privy.Client
-	explorerURL string
-	network     string
-	chainParams *chaincfg.Params
-	feeRate     int64 // satoshis per vByte
-	httpClient  *http.Client
+	client       *privy.Client
+	explorerURL  string
+	network      string
+	chainParams  *chaincfg.Params
+	feeRate      int64 // satoshis per vByte
+	httpClient   *http.Client
+	pollInterval time.Duration
 }
 
 // Option configures the Helper.
@@ -81,16 +87,24 @@ func WithHTTPClient(c *http.Client) Option {
 	}
 }
 
+// WithPollInterval sets how often WaitForConfirmation polls the explorer.
+func WithPollInterval(d time.Duration) Option {
+	return func(h *Helper) {
+		h.pollInterval = d
+	}
+}
+
 // NewHelper creates a new Bitcoin helper.
 // Options are applied in order: testnet defaults, client-level chain options, then direct options.
 func NewHelper(client *privy.Client, opts ...Option) *Helper {
 	h := &Helper{
-		client:      client,
-		explorerURL: "https://blockstream.info/api",
-		network:     "mainnet",
-		chainParams: &chaincfg.MainNetParams,
-		feeRate:     10,
-		httpClient:  http.DefaultClient,
+		client:       client,
+		explorerURL:  "https://blockstream.info/api",
+		network:      "mainnet",
+		chainParams:  &chaincfg.MainNetParams,
+		feeRate:      10,
+		httpClient:   http.DefaultClient,
+		pollInterval: chains.DefaultPollInterval,
 	}
 	if client.Testnet() {
 		WithTestnet()(h)
@@ -116,7 +130,10 @@ type UTXO struct {
 // Transfer sends BTC from a Privy wallet to a destination address.
 // amount is in satoshis as a decimal string.
 // Returns the transaction ID (hash).
-func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (string, error) {
+func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "bitcoin.transfer", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
 	// Parse amount
 	amountSats, err := strconv.ParseInt(amount, 10, 64)
 	if err != nil {
@@ -179,7 +196,9 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 
 		// Sign via Privy raw_sign
 		hashHex := "0x" + hex.EncodeToString(sigHash)
-		signResp, err := h.client.RawSign(ctx, walletID, hashHex)
+		signCtx, signSpan := h.client.StartSpan(ctx, "bitcoin.sign", privy.Attr("bitcoin.input", i))
+		signResp, err := h.client.RawSign(signCtx, walletID, hashHex)
+		signSpan.End(err)
 		if err != nil {
 			return "", fmt.Errorf("bitcoin: sign input %d: %w", i, err)
 		}
@@ -217,35 +236,126 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 	return txID, nil
 }
 
-// fetchUTXOs retrieves unspent transaction outputs from the block explorer API.
-func (h *Helper) fetchUTXOs(ctx context.Context, address string) ([]UTXO, error) {
-	url := fmt.Sprintf("%s/address/%s/utxo", h.explorerURL, address)
+// Balance returns the balance of address in satoshis, including unconfirmed
+// transactions in the mempool.
+func (h *Helper) Balance(ctx context.Context, address string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "bitcoin.balance")
+	defer func() { span.End(err) }()
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	type stats struct {
+		Funded int64 `json:"funded_txo_sum"`
+		Spent  int64 `json:"spent_txo_sum"`
+	}
+	var info struct {
+		ChainStats   stats `json:"chain_stats"`
+		MempoolStats stats `json:"mempool_stats"`
+	}
+	if err := h.getJSON(ctx, "/address/"+address, &info); err != nil {
+		return "", fmt.Errorf("bitcoin: get address: %w", err)
+	}
+	balance := info.ChainStats.Funded - info.ChainStats.Spent + info.MempoolStats.Funded - info.MempoolStats.Spent
+	return strconv.FormatInt(balance, 10), nil
+}
+
+// ValidateAddress checks that address decodes for the configured network.
+func (h *Helper) ValidateAddress(address string) error {
+	addr, err := btcutil.DecodeAddress(address, h.chainParams)
+	if err != nil {
+		return fmt.Errorf("%w: %v", chains.ErrInvalidAddress, err)
+	}
+	if !addr.IsForNet(h.chainParams) {
+		return fmt.Errorf("%w: %s is not a %s address", chains.ErrInvalidAddress, address, h.network)
+	}
+	return nil
+}
+
+// EstimateFee returns the fee in satoshis that Transfer would pay, based on
+// the wallet's current UTXOs and the configured fee rate.
+func (h *Helper) EstimateFee(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "bitcoin.estimate_fee", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	amountSats, err := strconv.ParseInt(amount, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("bitcoin: invalid amount %q: %w", amount, err)
+	}
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("bitcoin: get wallet: %w", err)
+	}
+	utxos, err := h.fetchUTXOs(ctx, wallet.Address)
+	if err != nil {
+		return "", fmt.Errorf("bitcoin: fetch utxos: %w", err)
+	}
+	_, _, fee, err := h.selectUTXOs(utxos, amountSats)
 	if err != nil {
+		return "", fmt.Errorf("bitcoin: select utxos: %w", err)
+	}
+	return strconv.FormatInt(fee, 10), nil
+}
+
+// WaitForConfirmation polls the explorer until the transaction is included
+// in a block.
+func (h *Helper) WaitForConfirmation(ctx context.Context, txHash string) (err error) {
+	ctx, span := h.client.StartSpan(ctx, "bitcoin.wait_for_confirmation")
+	defer func() { span.End(err) }()
+
+	return chains.Poll(ctx, h.pollInterval, func(ctx context.Context) (bool, error) {
+		var status struct {
+			Confirmed bool `json:"confirmed"`
+		}
+		err := h.getJSON(ctx, "/tx/"+txHash+"/status", &status)
+		if errors.Is(err, errNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("bitcoin: get tx status: %w", err)
+		}
+		return status.Confirmed, nil
+	})
+}
+
+// fetchUTXOs retrieves unspent transaction outputs from the block explorer API.
+func (h *Helper) fetchUTXOs(ctx context.Context, address string) (_ []UTXO, err error) {
+	ctx, span := h.client.StartSpan(ctx, "bitcoin.fetch_utxos")
+	defer func() { span.End(err) }()
+
+	var utxos []UTXO
+	if err := h.getJSON(ctx, "/address/"+address+"/utxo", &utxos); err != nil {
 		return nil, err
 	}
+	return utxos, nil
+}
+
+// errNotFound is returned by getJSON when the explorer responds with 404.
+var errNotFound = errors.New("not found")
+
+// getJSON fetches path from the block explorer API and decodes the JSON body.
+func (h *Helper) getJSON(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", h.explorerURL+path, nil)
+	if err != nil {
+		return err
+	}
 
 	resp, err := h.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("explorer API returned %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("explorer API returned %d: %s", resp.StatusCode, string(body))
 	}
 
-	var utxos []UTXO
-	if err := json.Unmarshal(body, &utxos); err != nil {
-		return nil, err
-	}
-	return utxos, nil
+	return json.Unmarshal(body, out)
 }
 
 // selectUTXOs selects UTXOs to cover the amount plus estimated fee.
@@ -327,7 +437,10 @@ func (h *Helper) calcWitnessSigHash(tx *wire.MsgTx, fetcher txscript.PrevOutputF
 }
 
 // broadcastTx broadcasts a raw transaction hex via the block explorer API.
-func (h *Helper) broadcastTx(ctx context.Context, txHex string) (string, error) {
+func (h *Helper) broadcastTx(ctx context.Context, txHex string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "bitcoin.broadcast")
+	defer func() { span.End(err) }()
+
 	url := h.explorerURL + "/tx"
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(txHex))
@@ -1,13 +1,23 @@
 package bitcoin
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
 )
 
 func TestNewHelper(t *testing.T) {
@@ -192,3 +202,158 @@ func TestTransfer_WithMockServer(t *testing.T) {
 		t.Errorf("Expected txID txid123456, got %s", txID)
 	}
 }
+
+func TestTransfer_ScriptVerifies(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, err := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeBitcoinSegwit})
+	if err != nil {
+		t.Fatalf("Create wallet failed: %v", err)
+	}
+
+	utxos := []UTXO{
+		{TxID: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", Vout: 0, Value: 30000},
+		{TxID: "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", Vout: 1, Value: 40000},
+	}
+	var broadcast string
+	explorerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/address/"+wallet.Address+"/utxo":
+			json.NewEncoder(w).Encode(utxos)
+		case r.Method == "POST" && r.URL.Path == "/tx":
+			body, _ := io.ReadAll(r.Body)
+			broadcast = string(body)
+			w.Write([]byte("txid"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer explorerServer.Close()
+
+	h := NewHelper(client, WithExplorerURL(explorerServer.URL))
+	if _, err := h.Transfer(context.Background(), wallet.ID, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "50000"); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+
+	raw, err := hex.DecodeString(broadcast)
+	if err != nil {
+		t.Fatalf("broadcast transaction is not hex: %v", err)
+	}
+	var tx wire.MsgTx
+	if err := tx.Deserialize(bytes.NewReader(raw)); err != nil {
+		t.Fatalf("deserialize transaction: %v", err)
+	}
+
+	pubKey, _ := hex.DecodeString(wallet.PublicKey[2:])
+	pkScript, _ := payToWitnessPubKeyHashScript(btcutil.Hash160(pubKey))
+	prevOuts := txscript.NewMultiPrevOutFetcher(nil)
+	values := map[string]int64{}
+	for _, u := range utxos {
+		values[u.TxID] = u.Value
+	}
+	for _, in := range tx.TxIn {
+		prevOuts.AddPrevOut(in.PreviousOutPoint, wire.NewTxOut(values[in.PreviousOutPoint.Hash.String()], pkScript))
+	}
+	sigHashes := txscript.NewTxSigHashes(&tx, prevOuts)
+	for i, in := range tx.TxIn {
+		prev := prevOuts.FetchPrevOutput(in.PreviousOutPoint)
+		vm, err := txscript.NewEngine(prev.PkScript, &tx, i, txscript.StandardVerifyFlags, nil, sigHashes, prev.Value, prevOuts)
+		if err != nil {
+			t.Fatalf("input %d: new engine: %v", i, err)
+		}
+		if err := vm.Execute(); err != nil {
+			t.Errorf("input %d does not verify: %v", i, err)
+		}
+	}
+}
+
+func TestBalance(t *testing.T) {
+	explorerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/address/bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"chain_stats":   map[string]any{"funded_txo_sum": 150000, "spent_txo_sum": 50000},
+			"mempool_stats": map[string]any{"funded_txo_sum": 0, "spent_txo_sum": 20000},
+		})
+	}))
+	defer explorerServer.Close()
+
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithExplorerURL(explorerServer.URL))
+	balance, err := h.Balance(context.Background(), "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4")
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if balance != "80000" {
+		t.Errorf("Expected 80000, got %s", balance)
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	client := privy.NewClient("test-app-id", "test-app-secret")
+	mainnet := NewHelper(client)
+	testnet := NewHelper(client, WithTestnet())
+
+	if err := mainnet.ValidateAddress("bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"); err != nil {
+		t.Errorf("Expected valid mainnet address, got %v", err)
+	}
+	if err := mainnet.ValidateAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"); err != nil {
+		t.Errorf("Expected valid legacy address, got %v", err)
+	}
+	if err := testnet.ValidateAddress("bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"); !errors.Is(err, chains.ErrInvalidAddress) {
+		t.Errorf("Expected mainnet address to be invalid on testnet, got %v", err)
+	}
+	if err := mainnet.ValidateAddress("not-an-address"); !errors.Is(err, chains.ErrInvalidAddress) {
+		t.Errorf("Expected ErrInvalidAddress, got %v", err)
+	}
+}
+
+func TestEstimateFee(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeBitcoinSegwit})
+
+	explorerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]UTXO{
+			{TxID: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", Vout: 0, Value: 30000},
+			{TxID: "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", Vout: 1, Value: 40000},
+		})
+	}))
+	defer explorerServer.Close()
+
+	h := NewHelper(client, WithExplorerURL(explorerServer.URL), WithFeeRate(2))
+	fee, err := h.EstimateFee(context.Background(), wallet.ID, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "50000")
+	if err != nil {
+		t.Fatalf("EstimateFee failed: %v", err)
+	}
+	// Two inputs and two outputs: (11 + 2*68 + 2*31) vB at 2 sat/vB.
+	if fee != "418" {
+		t.Errorf("Expected fee 418, got %s", fee)
+	}
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	polls := 0
+	explorerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		switch polls {
+		case 1:
+			http.Error(w, "Transaction not found", http.StatusNotFound)
+		case 2:
+			json.NewEncoder(w).Encode(map[string]any{"confirmed": false})
+		default:
+			json.NewEncoder(w).Encode(map[string]any{"confirmed": true, "block_height": 800000})
+		}
+	}))
+	defer explorerServer.Close()
+
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"),
+		WithExplorerURL(explorerServer.URL), WithPollInterval(time.Millisecond))
+	if err := h.WaitForConfirmation(context.Background(), "txid"); err != nil {
+		t.Fatalf("WaitForConfirmation failed: %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("Expected 3 polls, got %d", polls)
+	}
+}
@@ -0,0 +1,147 @@
+// Package chains defines the interface shared by the chain helpers in the
+// chains/* modules, and a registry that picks the helper for a wallet's chain
+// type.
+//
+// Every helper (bitcoin.Helper, near.Helper, aptos.Helper and so on)
+// implements Chain, so code that pays out on several chains can register the
+// helpers it needs once and then work on any wallet generically:
+//
+//	reg := chains.NewRegistry()
+//	reg.Register(privy.ChainTypeEthereum, ethereum.NewHelper(client))
+//	reg.Register(privy.ChainTypeSolana, solana.NewHelper(client))
+//
+//	chain, err := reg.ForWallet(wallet)
+//	if err != nil {
+//		return err
+//	}
+//	txHash, err := chain.Transfer(ctx, wallet.ID, destination, amount)
+//
+// Amounts, balances and fees are decimal strings in the chain's smallest
+// unit (wei, lamports, satoshis, stroops, yoctoNEAR, ...).
+package chains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+var (
+	// ErrUnsupportedChain is returned by the Registry when no helper is
+	// registered for a chain type.
+	ErrUnsupportedChain = errors.New("chains: unsupported chain type")
+
+	// ErrInvalidAddress is wrapped by ValidateAddress errors.
+	ErrInvalidAddress = errors.New("chains: invalid address")
+
+	// ErrTransactionFailed is wrapped by WaitForConfirmation when the
+	// transaction was included on chain but did not execute successfully.
+	ErrTransactionFailed = errors.New("chains: transaction failed")
+)
+
+// DefaultPollInterval is how often WaitForConfirmation checks the chain
+// unless the helper is configured otherwise.
+const DefaultPollInterval = 2 * time.Second
+
+// Transferer sends the chain's native asset from a Privy wallet.
+type Transferer interface {
+	// Transfer sends amount from the wallet to destination and returns the
+	// transaction hash.
+	Transfer(ctx context.Context, walletID string, destination string, amount string) (string, error)
+}
+
+// Chain is the chain-agnostic interface implemented by every helper.
+type Chain interface {
+	Transferer
+
+	// Balance returns the native balance of address.
+	Balance(ctx context.Context, address string) (string, error)
+
+	// ValidateAddress reports whether address is a well-formed address on
+	// this chain. Errors wrap ErrInvalidAddress.
+	ValidateAddress(address string) error
+
+	// EstimateFee returns the expected network fee for transferring amount
+	// from the wallet to destination.
+	EstimateFee(ctx context.Context, walletID string, destination string, amount string) (string, error)
+
+	// WaitForConfirmation blocks until the transaction is confirmed or ctx
+	// is done. Errors wrap ErrTransactionFailed if the transaction was
+	// included but failed.
+	WaitForConfirmation(ctx context.Context, txHash string) error
+}
+
+// Registry maps chain types to helpers. It is safe for concurrent use.
+type Registry struct {
+	mu     sync.RWMutex
+	chains map[privy.ChainType]Chain
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{chains: make(map[privy.ChainType]Chain)}
+}
+
+// Register sets the helper for a chain type, replacing any previous one.
+func (r *Registry) Register(chainType privy.ChainType, chain Chain) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.chains[chainType] = chain
+}
+
+// Get returns the helper registered for chainType.
+func (r *Registry) Get(chainType privy.ChainType) (Chain, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	chain, ok := r.chains[chainType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedChain, chainType)
+	}
+	return chain, nil
+}
+
+// ForWallet returns the helper for the wallet's chain type.
+func (r *Registry) ForWallet(wallet *privy.Wallet) (Chain, error) {
+	if wallet == nil {
+		return nil, errors.New("chains: nil wallet")
+	}
+	return r.Get(wallet.ChainType)
+}
+
+// ChainTypes returns the registered chain types in sorted order.
+func (r *Registry) ChainTypes() []privy.ChainType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]privy.ChainType, 0, len(r.chains))
+	for chainType := range r.chains {
+		types = append(types, chainType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// Poll calls check every interval until it reports done, returns an error,
+// or ctx is done. Helpers use it to implement WaitForConfirmation.
+func Poll(ctx context.Context, interval time.Duration, check func(ctx context.Context) (done bool, err error)) error {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
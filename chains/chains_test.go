@@ -0,0 +1,75 @@
+package chains
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+type fakeChain struct{ name string }
+
+func (f *fakeChain) Transfer(ctx context.Context, walletID, destination, amount string) (string, error) {
+	return f.name + "-tx", nil
+}
+func (f *fakeChain) Balance(ctx context.Context, address string) (string, error) { return "0", nil }
+func (f *fakeChain) ValidateAddress(address string) error                        { return nil }
+func (f *fakeChain) EstimateFee(ctx context.Context, walletID, destination, amount string) (string, error) {
+	return "0", nil
+}
+func (f *fakeChain) WaitForConfirmation(ctx context.Context, txHash string) error { return nil }
+
+func TestRegistry(t *testing.T) {
+	reg := NewRegistry()
+	eth, sol := &fakeChain{"eth"}, &fakeChain{"sol"}
+	reg.Register(privy.ChainTypeSolana, sol)
+	reg.Register(privy.ChainTypeEthereum, eth)
+
+	chain, err := reg.ForWallet(&privy.Wallet{ID: "w1", ChainType: privy.ChainTypeEthereum})
+	if err != nil {
+		t.Fatalf("ForWallet failed: %v", err)
+	}
+	if chain != eth {
+		t.Errorf("expected the ethereum helper, got %v", chain)
+	}
+
+	if _, err := reg.Get(privy.ChainTypeTon); !errors.Is(err, ErrUnsupportedChain) {
+		t.Errorf("expected ErrUnsupportedChain, got %v", err)
+	}
+	if _, err := reg.ForWallet(nil); err == nil {
+		t.Error("expected error for nil wallet")
+	}
+
+	want := []privy.ChainType{privy.ChainTypeEthereum, privy.ChainTypeSolana}
+	if got := reg.ChainTypes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ChainTypes() = %v, want %v", got, want)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	calls := 0
+	err := Poll(context.Background(), time.Millisecond, func(ctx context.Context) (bool, error) {
+		calls++
+		return calls == 3, nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("expected 3 calls and no error, got %d and %v", calls, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = Poll(ctx, time.Millisecond, func(ctx context.Context) (bool, error) { return false, nil })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+
+	err = Poll(context.Background(), time.Millisecond, func(ctx context.Context) (bool, error) {
+		return false, ErrTransactionFailed
+	})
+	if !errors.Is(err, ErrTransactionFailed) {
+		t.Errorf("expected ErrTransactionFailed, got %v", err)
+	}
+}
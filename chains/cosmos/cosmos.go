@@ -12,12 +12,19 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"math/big"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 
@@ -27,15 +34,23 @@ import (
 	txv1beta1 "cosmossdk.io/api/cosmos/tx/v1beta1"
 )
 
+var _ chains.Chain = (*Helper)(nil)
+
 // Helper provides high-level Cosmos transaction methods using Privy wallets.
 type Helper struct {
-	client     *privy.Client
-	rpcURL     string
-	chainID    string
-	denom      string
-	gasLimit   uint64
-	feeAmount  string
-	httpClient *http.Client
+	client        *privy.Client
+	rpcURL        string
+	chainID       string
+	denom         string
+	bech32Prefix  string
+	gasLimit      uint64
+	feeAmount     string
+	gasPrice      string
+	gasAdjustment float64
+	httpClient    *http.Client
+	pollInterval  time.Duration
+
+	waitForInclusion bool
 }
 
 // Option configures the Helper.
@@ -62,17 +77,47 @@ func WithDenom(denom string) Option {
 	}
 }
 
-// WithGasLimit sets the gas limit for transactions.
+// WithBech32Prefix sets the human-readable address prefix (e.g. "osmo").
+// ValidateAddress rejects addresses with a different prefix.
+func WithBech32Prefix(prefix string) Option {
+	return func(h *Helper) {
+		h.bech32Prefix = prefix
+	}
+}
+
+// WithGasLimit sets the gas limit for transactions. It is ignored when gas
+// simulation is enabled (see WithGasSimulation).
 func WithGasLimit(gasLimit uint64) Option {
 	return func(h *Helper) {
 		h.gasLimit = gasLimit
 	}
 }
 
-// WithFeeAmount sets the fee amount in the native denomination.
+// WithFeeAmount sets a fixed fee amount in the native denomination. It
+// replaces any gas price set with WithGasPrice or WithChain.
 func WithFeeAmount(feeAmount string) Option {
 	return func(h *Helper) {
 		h.feeAmount = feeAmount
+		h.gasPrice = ""
+	}
+}
+
+// WithGasPrice sets the price of one unit of gas in the native denomination
+// as a decimal string (e.g. "0.025"). The fee is then the gas limit times
+// the price, rounded up, instead of the fixed fee amount.
+func WithGasPrice(price string) Option {
+	return func(h *Helper) {
+		h.gasPrice = price
+	}
+}
+
+// WithGasSimulation makes the helper simulate every transaction through the
+// node before signing it and set the gas limit to the gas used times
+// adjustment (e.g. 1.3 for a 30% margin). Combine it with WithGasPrice so
+// the fee follows the gas limit. An adjustment of 0 disables simulation.
+func WithGasSimulation(adjustment float64) Option {
+	return func(h *Helper) {
+		h.gasAdjustment = adjustment
 	}
 }
 
@@ -91,17 +136,36 @@ func WithHTTPClient(c *http.Client) Option {
 	}
 }
 
+// WithPollInterval sets how often WaitForConfirmation polls the node. It is
+// the first interval of WaitForTx's backoff.
+func WithPollInterval(d time.Duration) Option {
+	return func(h *Helper) {
+		h.pollInterval = d
+	}
+}
+
+// WithWaitForInclusion makes Transfer and the other transaction methods
+// block until the transaction is included in a block (see WaitForTx). If it
+// fails there, they return its hash together with the *TxError.
+func WithWaitForInclusion() Option {
+	return func(h *Helper) {
+		h.waitForInclusion = true
+	}
+}
+
 // NewHelper creates a new Cosmos helper.
 // Options are applied in order: testnet defaults, client-level chain options, then direct options.
 func NewHelper(client *privy.Client, opts ...Option) *Helper {
 	h := &Helper{
-		client:     client,
-		rpcURL:     "https://rest.cosmos.directory/cosmoshub",
-		chainID:    "cosmoshub-4",
-		denom:      "uatom",
-		gasLimit:   200000,
-		feeAmount:  "5000",
-		httpClient: http.DefaultClient,
+		client:       client,
+		rpcURL:       "https://rest.cosmos.directory/cosmoshub",
+		chainID:      "cosmoshub-4",
+		denom:        "uatom",
+		bech32Prefix: "cosmos",
+		gasLimit:     200000,
+		feeAmount:    "5000",
+		httpClient:   http.DefaultClient,
+		pollInterval: chains.DefaultPollInterval,
 	}
 	if client.Testnet() {
 		WithTestnet()(h)
@@ -125,36 +189,39 @@ type accountInfo struct {
 	} `json:"account"`
 }
 
+// txResponse is the result of a broadcast or transaction lookup.
+type txResponse struct {
+	TxHash    string  `json:"txhash"`
+	Height    string  `json:"height"`
+	Codespace string  `json:"codespace"`
+	Code      uint32  `json:"code"`
+	RawLog    string  `json:"raw_log"`
+	GasWanted string  `json:"gas_wanted"`
+	GasUsed   string  `json:"gas_used"`
+	Events    []Event `json:"events"`
+}
+
 // broadcastResult from the REST API.
 type broadcastResult struct {
-	TxResponse struct {
-		TxHash string `json:"txhash"`
-		Code   int    `json:"code"`
-		RawLog string `json:"raw_log"`
-	} `json:"tx_response"`
+	TxResponse txResponse `json:"tx_response"`
 }
 
+// errNotFound is returned by get when the API responds with 404.
+var errNotFound = errors.New("not found")
+
 // Transfer sends native tokens from a Privy wallet to a destination address.
 // amount is in the smallest denomination (e.g. uatom) as a decimal string.
 // Returns the transaction hash.
-func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (string, error) {
+func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "cosmos.transfer", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
 	// Get wallet info from Privy
 	wallet, err := h.client.Wallets().Get(ctx, walletID)
 	if err != nil {
 		return "", fmt.Errorf("cosmos: get wallet: %w", err)
 	}
 
-	// Query account info (sequence, account_number)
-	acctInfo, err := h.queryAccount(ctx, wallet.Address)
-	if err != nil {
-		return "", fmt.Errorf("cosmos: query account: %w", err)
-	}
-
-	// Parse account number and sequence
-	var accountNumber, sequence uint64
-	fmt.Sscanf(acctInfo.Account.AccountNumber, "%d", &accountNumber)
-	fmt.Sscanf(acctInfo.Account.Sequence, "%d", &sequence)
-
 	// Build MsgSend
 	msg := &bankv1beta1.MsgSend{
 		FromAddress: wallet.Address,
@@ -163,32 +230,94 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 			{Denom: h.denom, Amount: amount},
 		},
 	}
+	return h.signAndBroadcast(ctx, wallet, msg)
+}
+
+// unsignedTx is a transaction ready to be signed.
+type unsignedTx struct {
+	bodyBytes     []byte
+	authInfoBytes []byte
+	accountNumber uint64
+	gasLimit      uint64
+	fee           string
+}
 
-	// Wrap in Any
-	msgAny, err := anypb.New(msg)
+// buildTx builds a transaction carrying msgs from wallet. With gas
+// simulation enabled, it simulates the transaction first and sets the gas
+// limit and fee from the gas used.
+func (h *Helper) buildTx(ctx context.Context, wallet *privy.Wallet, msgs ...proto.Message) (*unsignedTx, error) {
+	// Query account info (sequence, account_number)
+	acctInfo, err := h.queryAccount(ctx, wallet.Address)
 	if err != nil {
-		return "", fmt.Errorf("cosmos: wrap message: %w", err)
+		return nil, fmt.Errorf("cosmos: query account: %w", err)
+	}
+
+	// Parse account number and sequence
+	var accountNumber, sequence uint64
+	fmt.Sscanf(acctInfo.Account.AccountNumber, "%d", &accountNumber)
+	fmt.Sscanf(acctInfo.Account.Sequence, "%d", &sequence)
+
+	// Wrap messages in Any; messages without Go types (e.g. IBC) come
+	// pre-wrapped.
+	msgAnys := make([]*anypb.Any, len(msgs))
+	for i, msg := range msgs {
+		if a, ok := msg.(*anypb.Any); ok {
+			msgAnys[i] = a
+			continue
+		}
+		msgAnys[i], err = anypb.New(msg)
+		if err != nil {
+			return nil, fmt.Errorf("cosmos: wrap message: %w", err)
+		}
 	}
 
 	// Build TxBody
 	txBody := &txv1beta1.TxBody{
-		Messages: []*anypb.Any{msgAny},
+		Messages: msgAnys,
 	}
 	bodyBytes, err := proto.Marshal(txBody)
 	if err != nil {
-		return "", fmt.Errorf("cosmos: marshal tx body: %w", err)
+		return nil, fmt.Errorf("cosmos: marshal tx body: %w", err)
 	}
 
 	// Decode public key for AuthInfo
 	pubKeyBytes, err := decodeHex(wallet.PublicKey)
 	if err != nil {
-		return "", fmt.Errorf("cosmos: decode public key: %w", err)
+		return nil, fmt.Errorf("cosmos: decode public key: %w", err)
+	}
+
+	tx := &unsignedTx{bodyBytes: bodyBytes, accountNumber: accountNumber, gasLimit: h.gasLimit}
+	if tx.fee, err = h.fee(tx.gasLimit); err != nil {
+		return nil, fmt.Errorf("cosmos: %w", err)
+	}
+	if tx.authInfoBytes, err = h.authInfo(pubKeyBytes, sequence, tx.gasLimit, tx.fee); err != nil {
+		return nil, fmt.Errorf("cosmos: marshal auth info: %w", err)
+	}
+	if h.gasAdjustment <= 0 {
+		return tx, nil
+	}
+
+	// Simulate, then rebuild AuthInfo with the measured gas
+	gasUsed, err := h.simulate(ctx, tx.bodyBytes, tx.authInfoBytes)
+	if err != nil {
+		return nil, fmt.Errorf("cosmos: simulate: %w", err)
+	}
+	tx.gasLimit = uint64(math.Ceil(float64(gasUsed) * h.gasAdjustment))
+	if tx.fee, err = h.fee(tx.gasLimit); err != nil {
+		return nil, fmt.Errorf("cosmos: %w", err)
+	}
+	if tx.authInfoBytes, err = h.authInfo(pubKeyBytes, sequence, tx.gasLimit, tx.fee); err != nil {
+		return nil, fmt.Errorf("cosmos: marshal auth info: %w", err)
 	}
+	return tx, nil
+}
 
-	// Build AuthInfo
+// authInfo marshals the AuthInfo of a transaction signed by a single
+// secp256k1 key in SIGN_MODE_DIRECT.
+func (h *Helper) authInfo(pubKey []byte, sequence uint64, gasLimit uint64, fee string) ([]byte, error) {
 	pubKeyAny := &anypb.Any{
 		TypeUrl: "/cosmos.crypto.secp256k1.PubKey",
-		Value:   pubKeyBytes,
+		Value:   pubKey,
 	}
 
 	authInfo := &txv1beta1.AuthInfo{
@@ -207,22 +336,47 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 		},
 		Fee: &txv1beta1.Fee{
 			Amount: []*basev1beta1.Coin{
-				{Denom: h.denom, Amount: h.feeAmount},
+				{Denom: h.denom, Amount: fee},
 			},
-			GasLimit: h.gasLimit,
+			GasLimit: gasLimit,
 		},
 	}
-	authInfoBytes, err := proto.Marshal(authInfo)
+	return proto.Marshal(authInfo)
+}
+
+// fee returns the fee for gasLimit: gasLimit times the gas price rounded
+// up, or the fixed fee amount when no gas price is set.
+func (h *Helper) fee(gasLimit uint64) (string, error) {
+	if h.gasPrice == "" {
+		return h.feeAmount, nil
+	}
+	price, ok := new(big.Rat).SetString(h.gasPrice)
+	if !ok || price.Sign() < 0 {
+		return "", fmt.Errorf("invalid gas price %q", h.gasPrice)
+	}
+	total := price.Mul(price, new(big.Rat).SetUint64(gasLimit))
+	fee, rem := new(big.Int).QuoRem(total.Num(), total.Denom(), new(big.Int))
+	if rem.Sign() > 0 {
+		fee.Add(fee, big.NewInt(1))
+	}
+	return fee.String(), nil
+}
+
+// signAndBroadcast builds a transaction carrying msgs, signs it with wallet
+// in SIGN_MODE_DIRECT and broadcasts it. Returns the transaction hash; with
+// WithWaitForInclusion, also once the transaction fails in a block.
+func (h *Helper) signAndBroadcast(ctx context.Context, wallet *privy.Wallet, msgs ...proto.Message) (string, error) {
+	tx, err := h.buildTx(ctx, wallet, msgs...)
 	if err != nil {
-		return "", fmt.Errorf("cosmos: marshal auth info: %w", err)
+		return "", err
 	}
 
 	// Build SignDoc
 	signDoc := &txv1beta1.SignDoc{
-		BodyBytes:     bodyBytes,
-		AuthInfoBytes: authInfoBytes,
+		BodyBytes:     tx.bodyBytes,
+		AuthInfoBytes: tx.authInfoBytes,
 		ChainId:       h.chainID,
-		AccountNumber: accountNumber,
+		AccountNumber: tx.accountNumber,
 	}
 	signDocBytes, err := proto.Marshal(signDoc)
 	if err != nil {
@@ -234,7 +388,9 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 
 	// Sign via Privy raw_sign
 	hashHex := "0x" + hex.EncodeToString(hash[:])
-	signResp, err := h.client.RawSign(ctx, walletID, hashHex)
+	signCtx, signSpan := h.client.StartSpan(ctx, "cosmos.sign")
+	signResp, err := h.client.RawSign(signCtx, wallet.ID, hashHex)
+	signSpan.End(err)
 	if err != nil {
 		return "", fmt.Errorf("cosmos: sign transaction: %w", err)
 	}
@@ -251,8 +407,8 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 
 	// Build TxRaw
 	txRaw := &txv1beta1.TxRaw{
-		BodyBytes:     bodyBytes,
-		AuthInfoBytes: authInfoBytes,
+		BodyBytes:     tx.bodyBytes,
+		AuthInfoBytes: tx.authInfoBytes,
 		Signatures:    [][]byte{sigBytes},
 	}
 	txBytes, err := proto.Marshal(txRaw)
@@ -262,89 +418,219 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 
 	// Broadcast
 	txHash, err := h.broadcastTx(ctx, txBytes)
+	var txErr *TxError
+	if errors.As(err, &txErr) {
+		return "", err
+	}
 	if err != nil {
 		return "", fmt.Errorf("cosmos: broadcast: %w", err)
 	}
 
+	if h.waitForInclusion {
+		if _, err := h.WaitForTx(ctx, txHash); err != nil {
+			return txHash, err
+		}
+	}
 	return txHash, nil
 }
 
-// Delegate delegates tokens to a validator.
-func (h *Helper) Delegate(ctx context.Context, walletID string, validatorAddr string, amount string) (string, error) {
-	return "", fmt.Errorf("cosmos: Delegate not yet implemented")
+// queryAccount queries account info from the Cosmos REST API.
+func (h *Helper) queryAccount(ctx context.Context, address string) (_ *accountInfo, err error) {
+	ctx, span := h.client.StartSpan(ctx, "cosmos.query_account")
+	defer func() { span.End(err) }()
+
+	var info accountInfo
+	if err := h.get(ctx, "/cosmos/auth/v1beta1/accounts/"+address, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
 }
 
-// Undelegate undelegates tokens from a validator.
-func (h *Helper) Undelegate(ctx context.Context, walletID string, validatorAddr string, amount string) (string, error) {
-	return "", fmt.Errorf("cosmos: Undelegate not yet implemented")
+// Balance returns the balance of address in the helper's denom.
+func (h *Helper) Balance(ctx context.Context, address string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "cosmos.balance")
+	defer func() { span.End(err) }()
+
+	var result struct {
+		Balance struct {
+			Amount string `json:"amount"`
+		} `json:"balance"`
+	}
+	path := fmt.Sprintf("/cosmos/bank/v1beta1/balances/%s/by_denom?denom=%s", address, url.QueryEscape(h.denom))
+	if err := h.get(ctx, path, &result); err != nil {
+		return "", fmt.Errorf("cosmos: get balance: %w", err)
+	}
+	if result.Balance.Amount == "" {
+		return "0", nil
+	}
+	return result.Balance.Amount, nil
 }
 
-// queryAccount queries account info from the Cosmos REST API.
-func (h *Helper) queryAccount(ctx context.Context, address string) (*accountInfo, error) {
-	url := fmt.Sprintf("%s/cosmos/auth/v1beta1/accounts/%s", h.rpcURL, address)
+// ValidateAddress checks that address is a bech32 account address with the
+// helper's prefix.
+func (h *Helper) ValidateAddress(address string) error {
+	hrp, data, err := bech32Decode(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", chains.ErrInvalidAddress, err)
+	}
+	if hrp != h.bech32Prefix {
+		return fmt.Errorf("%w: expected prefix %q, got %q", chains.ErrInvalidAddress, h.bech32Prefix, hrp)
+	}
+	if len(data) != 20 && len(data) != 32 {
+		return fmt.Errorf("%w: unexpected address length %d", chains.ErrInvalidAddress, len(data))
+	}
+	return nil
+}
+
+// EstimateFee returns the fee Transfer attaches to the transaction, in the
+// helper's denom. With gas simulation enabled it simulates the transfer;
+// otherwise it is the fee for the configured gas limit.
+func (h *Helper) EstimateFee(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	if h.gasAdjustment <= 0 {
+		fee, err := h.fee(h.gasLimit)
+		if err != nil {
+			return "", fmt.Errorf("cosmos: %w", err)
+		}
+		return fee, nil
+	}
+
+	ctx, span := h.client.StartSpan(ctx, "cosmos.estimate_fee", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("cosmos: get wallet: %w", err)
+	}
+	tx, err := h.buildTx(ctx, wallet, &bankv1beta1.MsgSend{
+		FromAddress: wallet.Address,
+		ToAddress:   destination,
+		Amount:      []*basev1beta1.Coin{{Denom: h.denom, Amount: amount}},
+	})
+	if err != nil {
+		return "", err
+	}
+	return tx.fee, nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+// WaitForConfirmation polls the node until the transaction is included in a
+// block. A transaction with a non-zero result code is reported as a
+// *TxError, which wraps chains.ErrTransactionFailed. Use WaitForTx for the
+// gas used and events.
+func (h *Helper) WaitForConfirmation(ctx context.Context, txHash string) error {
+	_, err := h.WaitForTx(ctx, txHash)
+	return err
+}
+
+// get fetches path from the REST API and decodes the JSON response into out.
+func (h *Helper) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", h.rpcURL+path, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	resp, err := h.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	var info accountInfo
-	if err := json.Unmarshal(body, &info); err != nil {
-		return nil, err
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
 	}
-	return &info, nil
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, string(body))
+	}
+	return json.Unmarshal(body, out)
 }
 
 // broadcastTx broadcasts a signed transaction via the Cosmos REST API.
-func (h *Helper) broadcastTx(ctx context.Context, txBytes []byte) (string, error) {
+func (h *Helper) broadcastTx(ctx context.Context, txBytes []byte) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "cosmos.broadcast")
+	defer func() { span.End(err) }()
+
 	reqBody := map[string]any{
 		"tx_bytes": base64.StdEncoding.EncodeToString(txBytes),
 		"mode":     "BROADCAST_MODE_SYNC",
 	}
-	body, err := json.Marshal(reqBody)
-	if err != nil {
+	var result broadcastResult
+	if err := h.post(ctx, "/cosmos/tx/v1beta1/txs", reqBody, &result); err != nil {
 		return "", err
 	}
 
-	url := h.rpcURL + "/cosmos/tx/v1beta1/txs"
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
-	if err != nil {
+	if err := result.TxResponse.err(); err != nil {
 		return "", err
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := h.httpClient.Do(req)
+	return result.TxResponse.TxHash, nil
+}
+
+// simulate runs an unsigned transaction through the node and returns the
+// gas it used.
+func (h *Helper) simulate(ctx context.Context, bodyBytes, authInfoBytes []byte) (_ uint64, err error) {
+	ctx, span := h.client.StartSpan(ctx, "cosmos.simulate")
+	defer func() { span.End(err) }()
+
+	// Signatures are not checked in simulation, but one must be present
+	// per signer.
+	txBytes, err := proto.Marshal(&txv1beta1.TxRaw{
+		BodyBytes:     bodyBytes,
+		AuthInfoBytes: authInfoBytes,
+		Signatures:    [][]byte{{}},
+	})
 	if err != nil {
-		return "", err
+		return 0, err
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	reqBody := map[string]any{
+		"tx_bytes": base64.StdEncoding.EncodeToString(txBytes),
+	}
+	var result struct {
+		GasInfo struct {
+			GasUsed string `json:"gas_used"`
+		} `json:"gas_info"`
+	}
+	if err := h.post(ctx, "/cosmos/tx/v1beta1/simulate", reqBody, &result); err != nil {
+		return 0, err
+	}
+	gasUsed, err := strconv.ParseUint(result.GasInfo.GasUsed, 10, 64)
 	if err != nil {
-		return "", err
+		return 0, fmt.Errorf("invalid gas used %q", result.GasInfo.GasUsed)
 	}
+	return gasUsed, nil
+}
 
-	var result broadcastResult
-	if err := json.Unmarshal(respBody, &result); err != nil {
-		return "", err
+// post sends in as JSON to path on the REST API and decodes the JSON
+// response into out.
+func (h *Helper) post(ctx context.Context, path string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
 	}
 
-	if result.TxResponse.Code != 0 {
-		return "", fmt.Errorf("broadcast failed (code %d): %s", result.TxResponse.Code, result.TxResponse.RawLog)
+	req, err := http.NewRequestWithContext(ctx, "POST", h.rpcURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
 	}
+	req.Header.Set("Content-Type", "application/json")
 
-	return result.TxResponse.TxHash, nil
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return json.Unmarshal(respBody, out)
 }
 
 func decodeHex(s string) ([]byte, error) {
@@ -3,11 +3,16 @@ package cosmos
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
 )
 
 func TestNewHelper(t *testing.T) {
@@ -133,22 +138,267 @@ func TestTransfer_WithMockServer(t *testing.T) {
 	}
 }
 
-func TestDelegate_NotImplemented(t *testing.T) {
-	client := privy.NewClient("test-app-id", "test-app-secret")
-	h := NewHelper(client)
+func TestBalance(t *testing.T) {
+	cosmosServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cosmos/bank/v1beta1/balances/cosmos1holder/by_denom" || r.URL.Query().Get("denom") != "uatom" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"balance": map[string]any{"denom": "uatom", "amount": "2500000"},
+		})
+	}))
+	defer cosmosServer.Close()
+
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(cosmosServer.URL))
+	balance, err := h.Balance(context.Background(), "cosmos1holder")
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if balance != "2500000" {
+		t.Errorf("Expected 2500000, got %s", balance)
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"))
+
+	if err := h.ValidateAddress("cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu"); err != nil {
+		t.Errorf("Expected valid address, got %v", err)
+	}
+	invalid := []string{
+		"",
+		"cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xv", // bad checksum
+		"osmo1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5helwsw",   // wrong prefix
+		"cosmos1sender123",
+	}
+	for _, addr := range invalid {
+		if err := h.ValidateAddress(addr); !errors.Is(err, chains.ErrInvalidAddress) {
+			t.Errorf("ValidateAddress(%q) = %v, want ErrInvalidAddress", addr, err)
+		}
+	}
+
+	osmo := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithBech32Prefix("osmo"))
+	if err := osmo.ValidateAddress("osmo1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5helwsw"); err != nil {
+		t.Errorf("Expected valid osmo address, got %v", err)
+	}
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	polls := 0
+	cosmosServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/tx/v1beta1/txs/PENDING":
+			polls++
+			if polls < 3 {
+				http.NotFound(w, r)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{
+				"tx_response": map[string]any{"txhash": "PENDING", "height": "100", "code": 0},
+			})
+		case "/cosmos/tx/v1beta1/txs/FAILED":
+			json.NewEncoder(w).Encode(map[string]any{
+				"tx_response": map[string]any{"txhash": "FAILED", "height": "100", "code": 5, "raw_log": "insufficient funds"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer cosmosServer.Close()
+
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"),
+		WithRPCURL(cosmosServer.URL),
+		WithPollInterval(time.Millisecond),
+	)
+
+	if err := h.WaitForConfirmation(context.Background(), "PENDING"); err != nil {
+		t.Fatalf("WaitForConfirmation failed: %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("Expected 3 polls, got %d", polls)
+	}
 
-	_, err := h.Delegate(context.Background(), "wallet-id", "cosmosvaloper1...", "1000000")
-	if err == nil {
-		t.Error("Expected error for unimplemented Delegate")
+	err := h.WaitForConfirmation(context.Background(), "FAILED")
+	if !errors.Is(err, chains.ErrTransactionFailed) {
+		t.Errorf("Expected ErrTransactionFailed, got %v", err)
 	}
 }
 
-func TestUndelegate_NotImplemented(t *testing.T) {
+func TestWithChain(t *testing.T) {
 	client := privy.NewClient("test-app-id", "test-app-secret")
-	h := NewHelper(client)
 
-	_, err := h.Undelegate(context.Background(), "wallet-id", "cosmosvaloper1...", "1000000")
-	if err == nil {
-		t.Error("Expected error for unimplemented Undelegate")
+	h := NewHelper(client, WithChain(Osmosis))
+	if h.chainID != "osmosis-1" || h.denom != "uosmo" || h.bech32Prefix != "osmo" || h.gasPrice != "0.025" {
+		t.Errorf("Unexpected Osmosis config: %+v", h)
+	}
+	if err := h.ValidateAddress("osmo1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5helwsw"); err != nil {
+		t.Errorf("Expected valid osmo address, got %v", err)
+	}
+
+	// A fixed fee set afterwards replaces the preset's gas price.
+	h = NewHelper(client, WithChain(Celestia), WithFeeAmount("2000"))
+	if h.gasPrice != "" {
+		t.Errorf("Expected WithFeeAmount to clear the gas price, got %q", h.gasPrice)
+	}
+}
+
+func TestFee(t *testing.T) {
+	tests := []struct {
+		gasPrice string
+		gasLimit uint64
+		want     string
+	}{
+		{"", 200000, "5000"}, // fixed fee amount
+		{"0.025", 200000, "5000"},
+		{"0.025", 123457, "3087"}, // rounded up
+		{"12500000000", 200000, "2500000000000000"},
+	}
+	for _, tt := range tests {
+		h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithGasPrice(tt.gasPrice))
+		fee, err := h.fee(tt.gasLimit)
+		if err != nil {
+			t.Fatalf("fee(%d) at %q failed: %v", tt.gasLimit, tt.gasPrice, err)
+		}
+		if fee != tt.want {
+			t.Errorf("fee(%d) at %q = %s, want %s", tt.gasLimit, tt.gasPrice, fee, tt.want)
+		}
+	}
+
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithGasPrice("cheap"))
+	if _, err := h.fee(200000); err == nil {
+		t.Error("Expected error for invalid gas price")
+	}
+}
+
+func TestGasSimulation(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeCosmos})
+
+	node := newCosmosNode(t)
+	node.gasUsed = "80000"
+	h := NewHelper(client, WithRPCURL(node.URL), WithGasPrice("0.025"), WithGasSimulation(1.5))
+
+	fee, err := h.EstimateFee(context.Background(), wallet.ID, "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", "1000")
+	if err != nil {
+		t.Fatalf("EstimateFee failed: %v", err)
+	}
+	if fee != "3000" {
+		t.Errorf("Expected fee 3000, got %s", fee)
+	}
+
+	if _, err := h.Transfer(context.Background(), wallet.ID, "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", "1000"); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+	if node.simulated != 2 {
+		t.Errorf("Expected 2 simulations, got %d", node.simulated)
+	}
+	authInfo := node.lastAuthInfo(t)
+	if authInfo.Fee.GasLimit != 120000 {
+		t.Errorf("Expected gas limit 120000, got %d", authInfo.Fee.GasLimit)
+	}
+	if amount := authInfo.Fee.Amount; len(amount) != 1 || amount[0].Denom != "uatom" || amount[0].Amount != "3000" {
+		t.Errorf("Expected fee 3000uatom, got %v", amount)
+	}
+}
+
+func TestWaitForTx(t *testing.T) {
+	node := newCosmosNode(t)
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"),
+		WithRPCURL(node.URL),
+		WithPollInterval(time.Millisecond),
+	)
+
+	node.responses["/cosmos/tx/v1beta1/txs/OK"] = map[string]any{
+		"tx_response": map[string]any{
+			"txhash": "OK", "height": "1234", "code": 0, "gas_wanted": "200000", "gas_used": "81234",
+			"events": []any{map[string]any{
+				"type": "transfer",
+				"attributes": []any{
+					map[string]any{"key": "recipient", "value": "cosmos1recipient", "index": true},
+					map[string]any{"key": "amount", "value": "1000uatom", "index": true},
+				},
+			}},
+		},
+	}
+	result, err := h.WaitForTx(context.Background(), "OK")
+	if err != nil {
+		t.Fatalf("WaitForTx failed: %v", err)
+	}
+	if result.Height != 1234 || result.GasWanted != 200000 || result.GasUsed != 81234 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if len(result.Events) != 1 || result.Events[0].Type != "transfer" || len(result.Events[0].Attributes) != 2 ||
+		result.Events[0].Attributes[1] != (EventAttribute{Key: "amount", Value: "1000uatom", Index: true}) {
+		t.Errorf("Unexpected events %+v", result.Events)
+	}
+
+	node.responses["/cosmos/tx/v1beta1/txs/OUTOFGAS"] = map[string]any{
+		"tx_response": map[string]any{
+			"txhash": "OUTOFGAS", "height": "1235", "codespace": "sdk", "code": 11,
+			"raw_log": "out of gas in location: WriteFlat", "gas_wanted": "100000", "gas_used": "100312",
+		},
+	}
+	result, err = h.WaitForTx(context.Background(), "OUTOFGAS")
+	var txErr *TxError
+	if !errors.As(err, &txErr) || !errors.Is(err, chains.ErrTransactionFailed) {
+		t.Fatalf("Expected TxError, got %v", err)
+	}
+	if txErr.Codespace != "sdk" || txErr.Code != 11 || !strings.Contains(err.Error(), "out of gas") {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if result == nil || result.GasUsed != 100312 {
+		t.Errorf("Expected the result with the error, got %+v", result)
+	}
+}
+
+func TestTransfer_WaitForInclusion(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeCosmos})
+
+	node := newCosmosNode(t)
+	h := NewHelper(client, WithRPCURL(node.URL), WithPollInterval(time.Millisecond), WithWaitForInclusion())
+
+	node.responses["/cosmos/tx/v1beta1/txs/STAKE123"] = map[string]any{
+		"tx_response": map[string]any{"txhash": "STAKE123", "height": "99", "codespace": "sdk", "code": 5, "raw_log": "spendable balance is smaller"},
+	}
+	txHash, err := h.Transfer(context.Background(), wallet.ID, "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", "1000")
+	if !errors.Is(err, chains.ErrTransactionFailed) || !strings.Contains(err.Error(), "insufficient funds") {
+		t.Errorf("Expected insufficient funds, got %v", err)
+	}
+	if txHash != "STAKE123" {
+		t.Errorf("Expected the hash with the error, got %q", txHash)
+	}
+}
+
+func TestBroadcast_Rejected(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeCosmos})
+
+	cosmosServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/auth/v1beta1/accounts/" + wallet.Address:
+			json.NewEncoder(w).Encode(map[string]any{"account": map[string]any{"account_number": "1", "sequence": "3"}})
+		case "/cosmos/tx/v1beta1/txs":
+			json.NewEncoder(w).Encode(map[string]any{
+				"tx_response": map[string]any{"txhash": "BAD", "codespace": "sdk", "code": 32, "raw_log": "account sequence mismatch, expected 4, got 3"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer cosmosServer.Close()
+
+	h := NewHelper(client, WithRPCURL(cosmosServer.URL))
+	_, err := h.Transfer(context.Background(), wallet.ID, "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", "1000")
+	var txErr *TxError
+	if !errors.As(err, &txErr) || txErr.Code != 32 {
+		t.Fatalf("Expected TxError with code 32, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "incorrect account sequence") {
+		t.Errorf("Expected decoded code in %q", err)
 	}
 }
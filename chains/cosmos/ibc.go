@@ -0,0 +1,142 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// defaultIBCTimeout is how long an IBC transfer stays valid when no timeout
+// is given.
+const defaultIBCTimeout = 10 * time.Minute
+
+// IBCOption configures an IBC transfer.
+type IBCOption func(*ibcConfig)
+
+type ibcConfig struct {
+	sourcePort       string
+	denom            string
+	memo             string
+	timeoutRevision  uint64
+	timeoutHeight    uint64
+	timeoutTimestamp time.Time
+}
+
+// WithIBCDenom sends denom instead of the helper's native denom, e.g. an
+// "ibc/..." voucher received from another chain.
+func WithIBCDenom(denom string) IBCOption {
+	return func(c *ibcConfig) {
+		c.denom = denom
+	}
+}
+
+// WithIBCMemo attaches a memo to the transfer packet, e.g. for exchange
+// deposits or packet-forward middleware.
+func WithIBCMemo(memo string) IBCOption {
+	return func(c *ibcConfig) {
+		c.memo = memo
+	}
+}
+
+// WithSourcePort sets the IBC port to send from. The default is "transfer".
+func WithSourcePort(port string) IBCOption {
+	return func(c *ibcConfig) {
+		c.sourcePort = port
+	}
+}
+
+// WithTimeoutHeight makes the transfer time out once the destination chain
+// reaches the given revision number and height.
+func WithTimeoutHeight(revisionNumber, revisionHeight uint64) IBCOption {
+	return func(c *ibcConfig) {
+		c.timeoutRevision = revisionNumber
+		c.timeoutHeight = revisionHeight
+	}
+}
+
+// WithTimeoutTimestamp makes the transfer time out once the destination
+// chain's block time passes t.
+func WithTimeoutTimestamp(t time.Time) IBCOption {
+	return func(c *ibcConfig) {
+		c.timeoutTimestamp = t
+	}
+}
+
+// TransferIBC sends amount of the helper's denom from a Privy wallet to
+// receiver on another chain over the IBC channel sourceChannel (e.g.
+// "channel-141" from Cosmos Hub to Osmosis). Without a timeout option the
+// transfer times out after ten minutes; if it does, the tokens are
+// refunded. Returns the transaction hash on the source chain.
+func (h *Helper) TransferIBC(ctx context.Context, walletID string, sourceChannel string, receiver string, amount string, opts ...IBCOption) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "cosmos.transfer_ibc", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	cfg := ibcConfig{sourcePort: "transfer", denom: h.denom}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.timeoutHeight == 0 && cfg.timeoutTimestamp.IsZero() {
+		cfg.timeoutTimestamp = time.Now().Add(defaultIBCTimeout)
+	}
+
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("cosmos: get wallet: %w", err)
+	}
+
+	msg := &anypb.Any{
+		TypeUrl: "/ibc.applications.transfer.v1.MsgTransfer",
+		Value:   msgTransfer(cfg, sourceChannel, wallet.Address, receiver, amount),
+	}
+	return h.signAndBroadcast(ctx, wallet, msg)
+}
+
+// msgTransfer encodes an ibc.applications.transfer.v1.MsgTransfer.
+func msgTransfer(cfg ibcConfig, channel, sender, receiver, amount string) []byte {
+	var token []byte
+	token = appendString(token, 1, cfg.denom)
+	token = appendString(token, 2, amount)
+
+	var height []byte
+	height = appendUint(height, 1, cfg.timeoutRevision)
+	height = appendUint(height, 2, cfg.timeoutHeight)
+
+	var b []byte
+	b = appendString(b, 1, cfg.sourcePort)
+	b = appendString(b, 2, channel)
+	b = protowire.AppendTag(b, 3, protowire.BytesType)
+	b = protowire.AppendBytes(b, token)
+	b = appendString(b, 4, sender)
+	b = appendString(b, 5, receiver)
+	// timeout_height is not nullable, so it is always present.
+	b = protowire.AppendTag(b, 6, protowire.BytesType)
+	b = protowire.AppendBytes(b, height)
+	if !cfg.timeoutTimestamp.IsZero() {
+		b = appendUint(b, 7, uint64(cfg.timeoutTimestamp.UnixNano()))
+	}
+	b = appendString(b, 8, cfg.memo)
+	return b
+}
+
+// appendString appends a string field, omitting it when empty as proto3
+// does.
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+// appendUint appends a varint field, omitting it when zero as proto3 does.
+func appendUint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
@@ -0,0 +1,119 @@
+package cosmos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// protoFields decodes the top-level fields of a protobuf message: bytes
+// fields as []byte and varints as uint64.
+func protoFields(t *testing.T, b []byte) map[protowire.Number]any {
+	t.Helper()
+	fields := map[protowire.Number]any{}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			t.Fatalf("invalid tag: %v", protowire.ParseError(n))
+		}
+		b = b[n:]
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				t.Fatalf("invalid field %d: %v", num, protowire.ParseError(n))
+			}
+			fields[num], b = v, b[n:]
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				t.Fatalf("invalid field %d: %v", num, protowire.ParseError(n))
+			}
+			fields[num], b = v, b[n:]
+		default:
+			t.Fatalf("unexpected wire type %d for field %d", typ, num)
+		}
+	}
+	return fields
+}
+
+func TestTransferIBC(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeCosmos})
+
+	node := newCosmosNode(t)
+	h := NewHelper(client, WithRPCURL(node.URL))
+	receiver := "osmo1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5helwsw"
+
+	before := time.Now()
+	txHash, err := h.TransferIBC(context.Background(), wallet.ID, "channel-141", receiver, "1000000", WithIBCMemo("deposit 7"))
+	if err != nil {
+		t.Fatalf("TransferIBC failed: %v", err)
+	}
+	if txHash != "STAKE123" {
+		t.Errorf("Expected txHash STAKE123, got %s", txHash)
+	}
+
+	msgs := node.lastMessages(t)
+	if len(msgs) != 1 {
+		t.Fatalf("Expected one message, got %d", len(msgs))
+	}
+	msg, ok := msgs[0].(*anypb.Any)
+	if !ok || msg.TypeUrl != "/ibc.applications.transfer.v1.MsgTransfer" {
+		t.Fatalf("Expected MsgTransfer, got %v", msgs[0])
+	}
+	fields := protoFields(t, msg.Value)
+	for num, want := range map[protowire.Number]string{1: "transfer", 2: "channel-141", 4: wallet.Address, 5: receiver, 8: "deposit 7"} {
+		if got, _ := fields[num].([]byte); string(got) != want {
+			t.Errorf("Field %d = %q, want %q", num, got, want)
+		}
+	}
+	token := protoFields(t, fields[3].([]byte))
+	if string(token[1].([]byte)) != "uatom" || string(token[2].([]byte)) != "1000000" {
+		t.Errorf("Unexpected token %v", token)
+	}
+	if height := protoFields(t, fields[6].([]byte)); len(height) != 0 {
+		t.Errorf("Expected zero timeout height, got %v", height)
+	}
+	timeout := time.Unix(0, int64(fields[7].(uint64)))
+	if timeout.Before(before.Add(defaultIBCTimeout)) || timeout.After(time.Now().Add(defaultIBCTimeout)) {
+		t.Errorf("Expected default timeout, got %v", timeout)
+	}
+}
+
+func TestTransferIBC_TimeoutHeight(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeCosmos})
+
+	node := newCosmosNode(t)
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	_, err := h.TransferIBC(context.Background(), wallet.ID, "channel-0", "osmo1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5helwsw", "5",
+		WithTimeoutHeight(1, 25_000_000), WithIBCDenom("ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"))
+	if err != nil {
+		t.Fatalf("TransferIBC failed: %v", err)
+	}
+
+	fields := protoFields(t, node.lastMessages(t)[0].(*anypb.Any).Value)
+	height := protoFields(t, fields[6].([]byte))
+	if height[1] != uint64(1) || height[2] != uint64(25_000_000) {
+		t.Errorf("Unexpected timeout height %v", height)
+	}
+	if _, ok := fields[7]; ok {
+		t.Error("Expected no timeout timestamp with a timeout height")
+	}
+	if _, ok := fields[8]; ok {
+		t.Error("Expected no memo")
+	}
+	token := protoFields(t, fields[3].([]byte))
+	if string(token[1].([]byte)) != "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2" {
+		t.Errorf("Unexpected denom %s", token[1])
+	}
+}
@@ -0,0 +1,65 @@
+package cosmos
+
+// ChainConfig describes a Cosmos SDK chain: where to reach it, its native
+// denomination, its address prefix and its gas price.
+type ChainConfig struct {
+	ChainID      string
+	RPCURL       string // REST API endpoint
+	Denom        string
+	Bech32Prefix string
+	GasPrice     string // in Denom per unit of gas
+}
+
+// Presets for popular Cosmos SDK chains. Gas prices are the chains'
+// minimum prices at the time of writing; use WithGasPrice after WithChain
+// to override them.
+var (
+	CosmosHub = ChainConfig{
+		ChainID:      "cosmoshub-4",
+		RPCURL:       "https://rest.cosmos.directory/cosmoshub",
+		Denom:        "uatom",
+		Bech32Prefix: "cosmos",
+		GasPrice:     "0.005",
+	}
+	Osmosis = ChainConfig{
+		ChainID:      "osmosis-1",
+		RPCURL:       "https://rest.cosmos.directory/osmosis",
+		Denom:        "uosmo",
+		Bech32Prefix: "osmo",
+		GasPrice:     "0.025",
+	}
+	Celestia = ChainConfig{
+		ChainID:      "celestia",
+		RPCURL:       "https://rest.cosmos.directory/celestia",
+		Denom:        "utia",
+		Bech32Prefix: "celestia",
+		GasPrice:     "0.002",
+	}
+	DYDX = ChainConfig{
+		ChainID:      "dydx-mainnet-1",
+		RPCURL:       "https://rest.cosmos.directory/dydx",
+		Denom:        "adydx",
+		Bech32Prefix: "dydx",
+		GasPrice:     "12500000000",
+	}
+	Neutron = ChainConfig{
+		ChainID:      "neutron-1",
+		RPCURL:       "https://rest.cosmos.directory/neutron",
+		Denom:        "untrn",
+		Bech32Prefix: "neutron",
+		GasPrice:     "0.0053",
+	}
+)
+
+// WithChain configures the helper for the given chain, e.g.
+// WithChain(cosmos.Osmosis). Fees are then the gas limit times the chain's
+// gas price.
+func WithChain(c ChainConfig) Option {
+	return func(h *Helper) {
+		h.chainID = c.ChainID
+		h.rpcURL = c.RPCURL
+		h.denom = c.Denom
+		h.bech32Prefix = c.Bech32Prefix
+		h.gasPrice = c.GasPrice
+	}
+}
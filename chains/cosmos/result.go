@@ -0,0 +1,139 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/vadimzhukck/privy-sdk-go/chains"
+)
+
+// maxPollInterval caps the backoff between WaitForTx polls, unless the
+// configured poll interval is already longer.
+const maxPollInterval = 10 * time.Second
+
+// TxResult is the outcome of a transaction included in a block.
+type TxResult struct {
+	TxHash    string
+	Height    int64
+	GasWanted uint64
+	GasUsed   uint64
+	Events    []Event
+}
+
+// Event is an event emitted by a transaction, e.g. "transfer" or
+// "delegate".
+type Event struct {
+	Type       string           `json:"type"`
+	Attributes []EventAttribute `json:"attributes"`
+}
+
+// EventAttribute is a key/value pair of an Event.
+type EventAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Index bool   `json:"index"`
+}
+
+// TxError is a transaction rejected by the node or failed in execution. It
+// wraps chains.ErrTransactionFailed.
+type TxError struct {
+	TxHash    string
+	Codespace string // module that raised the error, e.g. "sdk" or "staking"
+	Code      uint32
+	Log       string
+}
+
+func (e *TxError) Error() string {
+	code := fmt.Sprintf("code %d", e.Code)
+	if e.Codespace != "" {
+		code = e.Codespace + " " + code
+	}
+	if d, ok := sdkErrors[e.Code]; ok && e.Codespace == "sdk" {
+		code += ": " + d
+	}
+	return fmt.Sprintf("cosmos: %v (%s): %s", chains.ErrTransactionFailed, code, e.Log)
+}
+
+// Unwrap returns chains.ErrTransactionFailed.
+func (e *TxError) Unwrap() error {
+	return chains.ErrTransactionFailed
+}
+
+// sdkErrors describes the common codes of the "sdk" codespace.
+var sdkErrors = map[uint32]string{
+	2:  "tx parse error",
+	3:  "invalid sequence",
+	4:  "unauthorized",
+	5:  "insufficient funds",
+	6:  "unknown request",
+	7:  "invalid address",
+	8:  "invalid pubkey",
+	9:  "unknown address",
+	10: "invalid coins",
+	11: "out of gas",
+	12: "memo too large",
+	13: "insufficient fee",
+	18: "invalid request",
+	19: "tx already in mempool",
+	20: "mempool is full",
+	21: "tx too large",
+	28: "invalid chain-id",
+	30: "tx timeout height",
+	32: "incorrect account sequence",
+	41: "invalid gas limit",
+}
+
+// err returns the TxError for a response with a non-zero code.
+func (r txResponse) err() error {
+	if r.Code == 0 {
+		return nil
+	}
+	return &TxError{TxHash: r.TxHash, Codespace: r.Codespace, Code: r.Code, Log: r.RawLog}
+}
+
+// WaitForTx polls the node until the transaction is included in a block and
+// returns its result. Polls back off from the poll interval (see
+// WithPollInterval) up to ten seconds. If the transaction failed, the
+// result is returned together with a *TxError.
+func (h *Helper) WaitForTx(ctx context.Context, txHash string) (_ *TxResult, err error) {
+	ctx, span := h.client.StartSpan(ctx, "cosmos.wait_for_tx")
+	defer func() { span.End(err) }()
+
+	interval := h.pollInterval
+	if interval <= 0 {
+		interval = chains.DefaultPollInterval
+	}
+	maxInterval := max(maxPollInterval, interval)
+	for {
+		var result struct {
+			TxResponse txResponse `json:"tx_response"`
+		}
+		err := h.get(ctx, "/cosmos/tx/v1beta1/txs/"+txHash, &result)
+		if err == nil {
+			resp := result.TxResponse
+			height, _ := strconv.ParseInt(resp.Height, 10, 64)
+			gasWanted, _ := strconv.ParseUint(resp.GasWanted, 10, 64)
+			gasUsed, _ := strconv.ParseUint(resp.GasUsed, 10, 64)
+			return &TxResult{
+				TxHash:    txHash,
+				Height:    height,
+				GasWanted: gasWanted,
+				GasUsed:   gasUsed,
+				Events:    resp.Events,
+			}, resp.err()
+		}
+		if !errors.Is(err, errNotFound) {
+			return nil, fmt.Errorf("cosmos: get transaction: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, maxInterval)
+	}
+}
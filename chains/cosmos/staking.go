@@ -0,0 +1,295 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
+	"google.golang.org/protobuf/proto"
+
+	basev1beta1 "cosmossdk.io/api/cosmos/base/v1beta1"
+	distributionv1beta1 "cosmossdk.io/api/cosmos/distribution/v1beta1"
+	stakingv1beta1 "cosmossdk.io/api/cosmos/staking/v1beta1"
+)
+
+// Coin is an amount of one denomination. Reward amounts are decimals with
+// a fractional part (e.g. "1234.560000000000000000"); all other amounts are
+// integers in the smallest unit.
+type Coin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+// Delegation is a delegator's stake with one validator.
+type Delegation struct {
+	ValidatorAddress string
+	Shares           string
+	Balance          Coin
+}
+
+// UnbondingEntry is an undelegation that has not completed yet. Balance is
+// released to the delegator at CompletionTime.
+type UnbondingEntry struct {
+	ValidatorAddress string
+	CreationHeight   int64
+	CompletionTime   time.Time
+	InitialBalance   string
+	Balance          string
+}
+
+// Reward is the pending reward from one validator.
+type Reward struct {
+	ValidatorAddress string
+	Amount           []Coin
+}
+
+// pagination is the page info returned by list endpoints.
+type pagination struct {
+	NextKey string `json:"next_key"`
+}
+
+// Delegate delegates amount of the helper's denom from the wallet to
+// validatorAddr. Returns the transaction hash.
+func (h *Helper) Delegate(ctx context.Context, walletID string, validatorAddr string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "cosmos.delegate", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	if err := h.validateValidator(validatorAddr); err != nil {
+		return "", fmt.Errorf("cosmos: validator: %w", err)
+	}
+	return h.sendMsgs(ctx, walletID, func(delegator string) []proto.Message {
+		return []proto.Message{&stakingv1beta1.MsgDelegate{
+			DelegatorAddress: delegator,
+			ValidatorAddress: validatorAddr,
+			Amount:           &basev1beta1.Coin{Denom: h.denom, Amount: amount},
+		}}
+	})
+}
+
+// Undelegate starts unbonding amount of the helper's denom from
+// validatorAddr. The tokens are returned to the wallet once the unbonding
+// period ends (see UnbondingDelegations). Returns the transaction hash.
+func (h *Helper) Undelegate(ctx context.Context, walletID string, validatorAddr string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "cosmos.undelegate", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	if err := h.validateValidator(validatorAddr); err != nil {
+		return "", fmt.Errorf("cosmos: validator: %w", err)
+	}
+	return h.sendMsgs(ctx, walletID, func(delegator string) []proto.Message {
+		return []proto.Message{&stakingv1beta1.MsgUndelegate{
+			DelegatorAddress: delegator,
+			ValidatorAddress: validatorAddr,
+			Amount:           &basev1beta1.Coin{Denom: h.denom, Amount: amount},
+		}}
+	})
+}
+
+// Redelegate moves amount of the helper's denom from srcValidator to
+// dstValidator without unbonding it. Returns the transaction hash.
+func (h *Helper) Redelegate(ctx context.Context, walletID string, srcValidator string, dstValidator string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "cosmos.redelegate", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	for _, v := range []string{srcValidator, dstValidator} {
+		if err := h.validateValidator(v); err != nil {
+			return "", fmt.Errorf("cosmos: validator: %w", err)
+		}
+	}
+	return h.sendMsgs(ctx, walletID, func(delegator string) []proto.Message {
+		return []proto.Message{&stakingv1beta1.MsgBeginRedelegate{
+			DelegatorAddress:    delegator,
+			ValidatorSrcAddress: srcValidator,
+			ValidatorDstAddress: dstValidator,
+			Amount:              &basev1beta1.Coin{Denom: h.denom, Amount: amount},
+		}}
+	})
+}
+
+// WithdrawDelegatorRewards withdraws the wallet's pending rewards from
+// validatorAddr. Returns the transaction hash.
+func (h *Helper) WithdrawDelegatorRewards(ctx context.Context, walletID string, validatorAddr string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "cosmos.withdraw_rewards", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	if err := h.validateValidator(validatorAddr); err != nil {
+		return "", fmt.Errorf("cosmos: validator: %w", err)
+	}
+	return h.sendMsgs(ctx, walletID, func(delegator string) []proto.Message {
+		return []proto.Message{&distributionv1beta1.MsgWithdrawDelegatorReward{
+			DelegatorAddress: delegator,
+			ValidatorAddress: validatorAddr,
+		}}
+	})
+}
+
+// WithdrawAllRewards withdraws the wallet's pending rewards from every
+// validator that owes it any, in a single transaction with one message per
+// validator. Withdrawing from many validators may need a higher gas limit
+// (see WithGasLimit). Returns the transaction hash.
+func (h *Helper) WithdrawAllRewards(ctx context.Context, walletID string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "cosmos.withdraw_all_rewards", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("cosmos: get wallet: %w", err)
+	}
+
+	rewards, err := h.PendingRewards(ctx, wallet.Address)
+	if err != nil {
+		return "", err
+	}
+	msgs := make([]proto.Message, 0, len(rewards))
+	for _, r := range rewards {
+		msgs = append(msgs, &distributionv1beta1.MsgWithdrawDelegatorReward{
+			DelegatorAddress: wallet.Address,
+			ValidatorAddress: r.ValidatorAddress,
+		})
+	}
+	if len(msgs) == 0 {
+		return "", fmt.Errorf("cosmos: no rewards to withdraw for %s", wallet.Address)
+	}
+	return h.signAndBroadcast(ctx, wallet, msgs...)
+}
+
+// sendMsgs fetches the wallet and broadcasts the messages built for its
+// address.
+func (h *Helper) sendMsgs(ctx context.Context, walletID string, build func(delegator string) []proto.Message) (string, error) {
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("cosmos: get wallet: %w", err)
+	}
+	return h.signAndBroadcast(ctx, wallet, build(wallet.Address)...)
+}
+
+// Delegations returns the stakes of delegator with every validator.
+func (h *Helper) Delegations(ctx context.Context, delegator string) (_ []Delegation, err error) {
+	ctx, span := h.client.StartSpan(ctx, "cosmos.delegations")
+	defer func() { span.End(err) }()
+
+	var delegations []Delegation
+	key := ""
+	for {
+		var result struct {
+			DelegationResponses []struct {
+				Delegation struct {
+					ValidatorAddress string `json:"validator_address"`
+					Shares           string `json:"shares"`
+				} `json:"delegation"`
+				Balance Coin `json:"balance"`
+			} `json:"delegation_responses"`
+			Pagination pagination `json:"pagination"`
+		}
+		path := "/cosmos/staking/v1beta1/delegations/" + delegator + pageQuery(key)
+		if err := h.get(ctx, path, &result); err != nil {
+			return nil, fmt.Errorf("cosmos: get delegations: %w", err)
+		}
+		for _, d := range result.DelegationResponses {
+			delegations = append(delegations, Delegation{
+				ValidatorAddress: d.Delegation.ValidatorAddress,
+				Shares:           d.Delegation.Shares,
+				Balance:          d.Balance,
+			})
+		}
+		if key = result.Pagination.NextKey; key == "" {
+			return delegations, nil
+		}
+	}
+}
+
+// UnbondingDelegations returns the pending undelegations of delegator,
+// one entry per Undelegate that has not completed yet.
+func (h *Helper) UnbondingDelegations(ctx context.Context, delegator string) (_ []UnbondingEntry, err error) {
+	ctx, span := h.client.StartSpan(ctx, "cosmos.unbonding_delegations")
+	defer func() { span.End(err) }()
+
+	var entries []UnbondingEntry
+	key := ""
+	for {
+		var result struct {
+			UnbondingResponses []struct {
+				ValidatorAddress string `json:"validator_address"`
+				Entries          []struct {
+					CreationHeight string    `json:"creation_height"`
+					CompletionTime time.Time `json:"completion_time"`
+					InitialBalance string    `json:"initial_balance"`
+					Balance        string    `json:"balance"`
+				} `json:"entries"`
+			} `json:"unbonding_responses"`
+			Pagination pagination `json:"pagination"`
+		}
+		path := "/cosmos/staking/v1beta1/delegators/" + delegator + "/unbonding_delegations" + pageQuery(key)
+		if err := h.get(ctx, path, &result); err != nil {
+			return nil, fmt.Errorf("cosmos: get unbonding delegations: %w", err)
+		}
+		for _, u := range result.UnbondingResponses {
+			for _, e := range u.Entries {
+				height, _ := strconv.ParseInt(e.CreationHeight, 10, 64)
+				entries = append(entries, UnbondingEntry{
+					ValidatorAddress: u.ValidatorAddress,
+					CreationHeight:   height,
+					CompletionTime:   e.CompletionTime,
+					InitialBalance:   e.InitialBalance,
+					Balance:          e.Balance,
+				})
+			}
+		}
+		if key = result.Pagination.NextKey; key == "" {
+			return entries, nil
+		}
+	}
+}
+
+// PendingRewards returns the rewards delegator can withdraw, per validator.
+func (h *Helper) PendingRewards(ctx context.Context, delegator string) (_ []Reward, err error) {
+	ctx, span := h.client.StartSpan(ctx, "cosmos.pending_rewards")
+	defer func() { span.End(err) }()
+
+	var result struct {
+		Rewards []struct {
+			ValidatorAddress string `json:"validator_address"`
+			Reward           []Coin `json:"reward"`
+		} `json:"rewards"`
+	}
+	if err := h.get(ctx, "/cosmos/distribution/v1beta1/delegators/"+delegator+"/rewards", &result); err != nil {
+		return nil, fmt.Errorf("cosmos: get rewards: %w", err)
+	}
+	rewards := make([]Reward, 0, len(result.Rewards))
+	for _, r := range result.Rewards {
+		if len(r.Reward) == 0 {
+			continue
+		}
+		rewards = append(rewards, Reward{ValidatorAddress: r.ValidatorAddress, Amount: r.Reward})
+	}
+	return rewards, nil
+}
+
+// validateValidator checks that address is a bech32 validator operator
+// address for the helper's chain (e.g. cosmosvaloper1...).
+func (h *Helper) validateValidator(address string) error {
+	hrp, data, err := bech32Decode(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", chains.ErrInvalidAddress, err)
+	}
+	if want := h.bech32Prefix + "valoper"; hrp != want {
+		return fmt.Errorf("%w: expected prefix %q, got %q", chains.ErrInvalidAddress, want, hrp)
+	}
+	if len(data) != 20 {
+		return fmt.Errorf("%w: unexpected validator address length %d", chains.ErrInvalidAddress, len(data))
+	}
+	return nil
+}
+
+// pageQuery returns the query string requesting the page at key, or ""
+// for the first page.
+func pageQuery(key string) string {
+	if key == "" {
+		return ""
+	}
+	return "?pagination.key=" + url.QueryEscape(key)
+}
@@ -0,0 +1,347 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
+	"google.golang.org/protobuf/proto"
+
+	distributionv1beta1 "cosmossdk.io/api/cosmos/distribution/v1beta1"
+	stakingv1beta1 "cosmossdk.io/api/cosmos/staking/v1beta1"
+	txv1beta1 "cosmossdk.io/api/cosmos/tx/v1beta1"
+)
+
+const (
+	testValidator  = "cosmosvaloper1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc56kct20"
+	testValidator2 = "cosmosvaloper1qgpsgpgxquyqjzstpsxsurcszyfpx9q4lppuuv"
+)
+
+// cosmosNode is a mock Cosmos REST API that knows every account, records
+// broadcast transactions, simulates every transaction as using gasUsed and
+// serves canned queries.
+type cosmosNode struct {
+	*httptest.Server
+	txs       []*txv1beta1.TxRaw
+	broadcast [][]proto.Message
+	simulated int
+	gasUsed   string
+	responses map[string]any // keyed by request URI
+}
+
+func newCosmosNode(t *testing.T) *cosmosNode {
+	t.Helper()
+	n := &cosmosNode{gasUsed: "100000", responses: map[string]any{}}
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/cosmos/auth/v1beta1/accounts/"):
+			json.NewEncoder(w).Encode(map[string]any{
+				"account": map[string]any{"account_number": "12345", "sequence": "7"},
+			})
+		case r.Method == "POST" && r.URL.Path == "/cosmos/tx/v1beta1/txs":
+			var req struct {
+				TxBytes string `json:"tx_bytes"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			txRaw, msgs := decodeTx(t, req.TxBytes)
+			n.txs = append(n.txs, txRaw)
+			n.broadcast = append(n.broadcast, msgs)
+			json.NewEncoder(w).Encode(map[string]any{
+				"tx_response": map[string]any{"txhash": "STAKE123", "code": 0},
+			})
+		case r.Method == "POST" && r.URL.Path == "/cosmos/tx/v1beta1/simulate":
+			n.simulated++
+			json.NewEncoder(w).Encode(map[string]any{
+				"gas_info": map[string]any{"gas_wanted": "0", "gas_used": n.gasUsed},
+			})
+		case n.responses[r.URL.RequestURI()] != nil:
+			json.NewEncoder(w).Encode(n.responses[r.URL.RequestURI()])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(n.Close)
+	return n
+}
+
+// decodeTx decodes a base64 TxRaw and unpacks its messages. Messages of
+// unregistered types (IBC) are left as Any.
+func decodeTx(t *testing.T, txBytes string) (*txv1beta1.TxRaw, []proto.Message) {
+	t.Helper()
+	raw, _ := base64.StdEncoding.DecodeString(txBytes)
+	var txRaw txv1beta1.TxRaw
+	if err := proto.Unmarshal(raw, &txRaw); err != nil {
+		t.Fatalf("invalid TxRaw: %v", err)
+	}
+	var body txv1beta1.TxBody
+	if err := proto.Unmarshal(txRaw.BodyBytes, &body); err != nil {
+		t.Fatalf("invalid TxBody: %v", err)
+	}
+	if len(txRaw.Signatures) != 1 || len(txRaw.Signatures[0]) != 64 {
+		t.Errorf("Expected one 64-byte signature, got %d", len(txRaw.Signatures))
+	}
+	msgs := make([]proto.Message, len(body.Messages))
+	for i, m := range body.Messages {
+		msg, err := m.UnmarshalNew()
+		if err != nil {
+			msgs[i] = m
+			continue
+		}
+		msgs[i] = msg
+	}
+	return &txRaw, msgs
+}
+
+// lastAuthInfo returns the AuthInfo of the last broadcast transaction.
+func (n *cosmosNode) lastAuthInfo(t *testing.T) *txv1beta1.AuthInfo {
+	t.Helper()
+	if len(n.txs) == 0 {
+		t.Fatal("no transaction was broadcast")
+	}
+	var authInfo txv1beta1.AuthInfo
+	if err := proto.Unmarshal(n.txs[len(n.txs)-1].AuthInfoBytes, &authInfo); err != nil {
+		t.Fatalf("invalid AuthInfo: %v", err)
+	}
+	return &authInfo
+}
+
+func (n *cosmosNode) lastMessages(t *testing.T) []proto.Message {
+	t.Helper()
+	if len(n.broadcast) == 0 {
+		t.Fatal("no transaction was broadcast")
+	}
+	return n.broadcast[len(n.broadcast)-1]
+}
+
+func TestStakingMessages(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeCosmos})
+
+	node := newCosmosNode(t)
+	h := NewHelper(client, WithRPCURL(node.URL))
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		send func() (string, error)
+		want proto.Message
+	}{
+		{
+			"delegate",
+			func() (string, error) { return h.Delegate(ctx, wallet.ID, testValidator, "1000000") },
+			&stakingv1beta1.MsgDelegate{DelegatorAddress: wallet.Address, ValidatorAddress: testValidator},
+		},
+		{
+			"undelegate",
+			func() (string, error) { return h.Undelegate(ctx, wallet.ID, testValidator, "1000000") },
+			&stakingv1beta1.MsgUndelegate{DelegatorAddress: wallet.Address, ValidatorAddress: testValidator},
+		},
+		{
+			"redelegate",
+			func() (string, error) { return h.Redelegate(ctx, wallet.ID, testValidator, testValidator2, "1000000") },
+			&stakingv1beta1.MsgBeginRedelegate{DelegatorAddress: wallet.Address, ValidatorSrcAddress: testValidator, ValidatorDstAddress: testValidator2},
+		},
+		{
+			"withdraw rewards",
+			func() (string, error) { return h.WithdrawDelegatorRewards(ctx, wallet.ID, testValidator) },
+			&distributionv1beta1.MsgWithdrawDelegatorReward{DelegatorAddress: wallet.Address, ValidatorAddress: testValidator},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txHash, err := tt.send()
+			if err != nil {
+				t.Fatalf("%s failed: %v", tt.name, err)
+			}
+			if txHash != "STAKE123" {
+				t.Errorf("Expected txHash STAKE123, got %s", txHash)
+			}
+			msgs := node.lastMessages(t)
+			if len(msgs) != 1 {
+				t.Fatalf("Expected one message, got %d", len(msgs))
+			}
+			got := proto.Clone(msgs[0])
+			// Compare the amount separately; the expected messages leave it unset.
+			switch m := got.(type) {
+			case *stakingv1beta1.MsgDelegate:
+				checkAmount(t, m.Amount.GetDenom(), m.Amount.GetAmount())
+				m.Amount = nil
+			case *stakingv1beta1.MsgUndelegate:
+				checkAmount(t, m.Amount.GetDenom(), m.Amount.GetAmount())
+				m.Amount = nil
+			case *stakingv1beta1.MsgBeginRedelegate:
+				checkAmount(t, m.Amount.GetDenom(), m.Amount.GetAmount())
+				m.Amount = nil
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Unexpected message %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func checkAmount(t *testing.T, denom, amount string) {
+	t.Helper()
+	if denom != "uatom" || amount != "1000000" {
+		t.Errorf("Expected 1000000uatom, got %s%s", amount, denom)
+	}
+}
+
+func TestStaking_InvalidValidator(t *testing.T) {
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"))
+
+	for _, validator := range []string{
+		"cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu",      // account, not validator
+		"osmovaloper1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5dwhd8f", // other chain
+		"cosmosvaloper1...",
+	} {
+		_, err := h.Delegate(context.Background(), "wallet-id", validator, "1000000")
+		if !errors.Is(err, chains.ErrInvalidAddress) {
+			t.Errorf("Delegate(%q) = %v, want ErrInvalidAddress", validator, err)
+		}
+	}
+	if _, err := h.Redelegate(context.Background(), "wallet-id", testValidator, "cosmosvaloper1...", "1"); !errors.Is(err, chains.ErrInvalidAddress) {
+		t.Errorf("Redelegate = %v, want ErrInvalidAddress", err)
+	}
+}
+
+func TestWithdrawAllRewards(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeCosmos})
+
+	node := newCosmosNode(t)
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	rewardsPath := "/cosmos/distribution/v1beta1/delegators/" + wallet.Address + "/rewards"
+	node.responses[rewardsPath] = map[string]any{"rewards": []any{}}
+	if _, err := h.WithdrawAllRewards(context.Background(), wallet.ID); err == nil {
+		t.Error("Expected error when there are no rewards")
+	}
+
+	node.responses[rewardsPath] = map[string]any{
+		"rewards": []any{
+			map[string]any{"validator_address": testValidator, "reward": []any{map[string]any{"denom": "uatom", "amount": "12.500000000000000000"}}},
+			map[string]any{"validator_address": testValidator2, "reward": []any{map[string]any{"denom": "uatom", "amount": "3.000000000000000000"}}},
+		},
+	}
+	if _, err := h.WithdrawAllRewards(context.Background(), wallet.ID); err != nil {
+		t.Fatalf("WithdrawAllRewards failed: %v", err)
+	}
+	msgs := node.lastMessages(t)
+	if len(msgs) != 2 {
+		t.Fatalf("Expected two messages, got %d", len(msgs))
+	}
+	for i, validator := range []string{testValidator, testValidator2} {
+		want := &distributionv1beta1.MsgWithdrawDelegatorReward{DelegatorAddress: wallet.Address, ValidatorAddress: validator}
+		if !proto.Equal(msgs[i], want) {
+			t.Errorf("Message %d = %v, want %v", i, msgs[i], want)
+		}
+	}
+}
+
+func TestDelegations(t *testing.T) {
+	node := newCosmosNode(t)
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(node.URL))
+
+	path := "/cosmos/staking/v1beta1/delegations/cosmos1holder"
+	node.responses[path] = map[string]any{
+		"delegation_responses": []any{map[string]any{
+			"delegation": map[string]any{"delegator_address": "cosmos1holder", "validator_address": testValidator, "shares": "1000000.000000000000000000"},
+			"balance":    map[string]any{"denom": "uatom", "amount": "1000000"},
+		}},
+		"pagination": map[string]any{"next_key": "a2V5Lw=="},
+	}
+	node.responses[path+"?pagination.key=a2V5Lw%3D%3D"] = map[string]any{
+		"delegation_responses": []any{map[string]any{
+			"delegation": map[string]any{"delegator_address": "cosmos1holder", "validator_address": testValidator2, "shares": "500.000000000000000000"},
+			"balance":    map[string]any{"denom": "uatom", "amount": "500"},
+		}},
+		"pagination": map[string]any{"next_key": nil},
+	}
+
+	delegations, err := h.Delegations(context.Background(), "cosmos1holder")
+	if err != nil {
+		t.Fatalf("Delegations failed: %v", err)
+	}
+	want := []Delegation{
+		{ValidatorAddress: testValidator, Shares: "1000000.000000000000000000", Balance: Coin{Denom: "uatom", Amount: "1000000"}},
+		{ValidatorAddress: testValidator2, Shares: "500.000000000000000000", Balance: Coin{Denom: "uatom", Amount: "500"}},
+	}
+	if len(delegations) != len(want) {
+		t.Fatalf("Expected %d delegations, got %d", len(want), len(delegations))
+	}
+	for i := range want {
+		if delegations[i] != want[i] {
+			t.Errorf("Delegation %d = %+v, want %+v", i, delegations[i], want[i])
+		}
+	}
+}
+
+func TestUnbondingDelegations(t *testing.T) {
+	node := newCosmosNode(t)
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(node.URL))
+
+	node.responses["/cosmos/staking/v1beta1/delegators/cosmos1holder/unbonding_delegations"] = map[string]any{
+		"unbonding_responses": []any{map[string]any{
+			"delegator_address": "cosmos1holder",
+			"validator_address": testValidator,
+			"entries": []any{
+				map[string]any{"creation_height": "100", "completion_time": "2026-11-06T12:00:00Z", "initial_balance": "300", "balance": "300"},
+				map[string]any{"creation_height": "250", "completion_time": "2026-11-07T08:30:00Z", "initial_balance": "200", "balance": "150"},
+			},
+		}},
+		"pagination": map[string]any{"next_key": nil},
+	}
+
+	entries, err := h.UnbondingDelegations(context.Background(), "cosmos1holder")
+	if err != nil {
+		t.Fatalf("UnbondingDelegations failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	want := UnbondingEntry{
+		ValidatorAddress: testValidator,
+		CreationHeight:   250,
+		CompletionTime:   time.Date(2026, 11, 7, 8, 30, 0, 0, time.UTC),
+		InitialBalance:   "200",
+		Balance:          "150",
+	}
+	if got := entries[1]; got.ValidatorAddress != want.ValidatorAddress || got.CreationHeight != want.CreationHeight ||
+		!got.CompletionTime.Equal(want.CompletionTime) || got.InitialBalance != want.InitialBalance || got.Balance != want.Balance {
+		t.Errorf("Entry = %+v, want %+v", got, want)
+	}
+}
+
+func TestPendingRewards(t *testing.T) {
+	node := newCosmosNode(t)
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(node.URL))
+
+	node.responses["/cosmos/distribution/v1beta1/delegators/cosmos1holder/rewards"] = map[string]any{
+		"rewards": []any{
+			map[string]any{"validator_address": testValidator, "reward": []any{map[string]any{"denom": "uatom", "amount": "12.500000000000000000"}}},
+			map[string]any{"validator_address": testValidator2, "reward": []any{}},
+		},
+		"total": []any{map[string]any{"denom": "uatom", "amount": "12.500000000000000000"}},
+	}
+
+	rewards, err := h.PendingRewards(context.Background(), "cosmos1holder")
+	if err != nil {
+		t.Fatalf("PendingRewards failed: %v", err)
+	}
+	if len(rewards) != 1 || rewards[0].ValidatorAddress != testValidator ||
+		len(rewards[0].Amount) != 1 || rewards[0].Amount[0] != (Coin{Denom: "uatom", Amount: "12.500000000000000000"}) {
+		t.Errorf("Unexpected rewards %+v", rewards)
+	}
+}
@@ -2,20 +2,42 @@
 // using Privy's /rpc endpoint.
 //
 // The Transfer method wraps the core SDK's SendTransaction to provide
-// a simple one-call native ETH transfer.
+// a simple one-call native ETH transfer. Balance, fee estimation and
+// confirmation tracking use an Ethereum JSON-RPC node.
 package ethereum
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
 
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
 )
 
+var _ chains.Chain = (*Helper)(nil)
+
+// defaultRPCURLs are the public JSON-RPC endpoints used when WithRPCURL is
+// not set. Other chain IDs have no default.
+var defaultRPCURLs = map[int64]string{
+	1:        "https://ethereum-rpc.publicnode.com",
+	11155111: "https://ethereum-sepolia-rpc.publicnode.com",
+}
+
 // Helper provides convenience methods for Ethereum operations using Privy wallets.
 type Helper struct {
-	client  *privy.Client
-	chainID int64
+	client       *privy.Client
+	chainID      int64
+	rpcURL       string
+	httpClient   *http.Client
+	pollInterval time.Duration
 }
 
 // Option configures the Helper.
@@ -26,17 +48,37 @@ func WithChainID(chainID int64) Option {
 	return func(h *Helper) { h.chainID = chainID }
 }
 
+// WithRPCURL sets the JSON-RPC endpoint used for balances, fee estimates and
+// receipts. It defaults to a public node for mainnet and Sepolia and is
+// required with WithChainID for other EVM chains.
+func WithRPCURL(url string) Option {
+	return func(h *Helper) { h.rpcURL = url }
+}
+
+// WithHTTPClient sets a custom HTTP client for RPC calls.
+func WithHTTPClient(c *http.Client) Option {
+	return func(h *Helper) { h.httpClient = c }
+}
+
+// WithPollInterval sets how often WaitForConfirmation polls for the receipt.
+func WithPollInterval(d time.Duration) Option {
+	return func(h *Helper) { h.pollInterval = d }
+}
+
 // WithTestnet configures the helper for Ethereum Sepolia testnet (chain ID 11155111).
 func WithTestnet() Option {
-	return WithChainID(11155111)
+	return func(h *Helper) { h.chainID = 11155111 }
 }
 
 // NewHelper creates a new Ethereum helper.
 // Options are applied in order: testnet defaults, client-level chain options, then direct options.
+// Without WithRPCURL, the RPC endpoint is derived from the resulting chain ID.
 func NewHelper(client *privy.Client, opts ...Option) *Helper {
 	h := &Helper{
-		client:  client,
-		chainID: 1,
+		client:       client,
+		chainID:      1,
+		httpClient:   http.DefaultClient,
+		pollInterval: chains.DefaultPollInterval,
 	}
 	if client.Testnet() {
 		WithTestnet()(h)
@@ -49,6 +91,9 @@ func NewHelper(client *privy.Client, opts ...Option) *Helper {
 	for _, opt := range opts {
 		opt(h)
 	}
+	if h.rpcURL == "" {
+		h.rpcURL = defaultRPCURLs[h.chainID]
+	}
 	return h
 }
 
@@ -95,3 +140,162 @@ func (h *Helper) SendTransaction(ctx context.Context, walletID string, tx *privy
 
 	return resp.Data.Hash, nil
 }
+
+// Balance returns the wei balance of address at the latest block.
+func (h *Helper) Balance(ctx context.Context, address string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "ethereum.balance")
+	defer func() { span.End(err) }()
+
+	var result string
+	if err := h.callRPC(ctx, "eth_getBalance", []any{address, "latest"}, &result); err != nil {
+		return "", fmt.Errorf("ethereum: get balance: %w", err)
+	}
+	balance, err := parseQuantity(result)
+	if err != nil {
+		return "", fmt.Errorf("ethereum: get balance: %w", err)
+	}
+	return balance.String(), nil
+}
+
+// ValidateAddress checks that address is 0x followed by 40 hex digits. The
+// EIP-55 checksum is not verified.
+func (h *Helper) ValidateAddress(address string) error {
+	if len(address) != 42 || !strings.HasPrefix(address, "0x") {
+		return fmt.Errorf("%w: %q is not a 0x-prefixed 20-byte address", chains.ErrInvalidAddress, address)
+	}
+	if _, err := hex.DecodeString(address[2:]); err != nil {
+		return fmt.Errorf("%w: %q is not hex", chains.ErrInvalidAddress, address)
+	}
+	return nil
+}
+
+// EstimateFee returns the fee in wei for a native transfer: the gas the node
+// estimates for the call multiplied by the current gas price.
+func (h *Helper) EstimateFee(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "ethereum.estimate_fee", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	value, err := parseQuantity(amount)
+	if err != nil {
+		return "", fmt.Errorf("ethereum: invalid amount %q", amount)
+	}
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("ethereum: get wallet: %w", err)
+	}
+
+	var gasHex, priceHex string
+	call := map[string]string{
+		"from":  wallet.Address,
+		"to":    destination,
+		"value": "0x" + value.Text(16),
+	}
+	if err := h.callRPC(ctx, "eth_estimateGas", []any{call}, &gasHex); err != nil {
+		return "", fmt.Errorf("ethereum: estimate gas: %w", err)
+	}
+	if err := h.callRPC(ctx, "eth_gasPrice", []any{}, &priceHex); err != nil {
+		return "", fmt.Errorf("ethereum: get gas price: %w", err)
+	}
+	gas, err := parseQuantity(gasHex)
+	if err != nil {
+		return "", fmt.Errorf("ethereum: estimate gas: %w", err)
+	}
+	price, err := parseQuantity(priceHex)
+	if err != nil {
+		return "", fmt.Errorf("ethereum: get gas price: %w", err)
+	}
+	return new(big.Int).Mul(gas, price).String(), nil
+}
+
+// WaitForConfirmation polls for the transaction receipt until it is mined.
+// A receipt with status 0 is reported as chains.ErrTransactionFailed.
+func (h *Helper) WaitForConfirmation(ctx context.Context, txHash string) (err error) {
+	ctx, span := h.client.StartSpan(ctx, "ethereum.wait_for_confirmation")
+	defer func() { span.End(err) }()
+
+	return chains.Poll(ctx, h.pollInterval, func(ctx context.Context) (bool, error) {
+		var receipt *struct {
+			Status string `json:"status"`
+		}
+		if err := h.callRPC(ctx, "eth_getTransactionReceipt", []any{txHash}, &receipt); err != nil {
+			return false, fmt.Errorf("ethereum: get receipt: %w", err)
+		}
+		if receipt == nil {
+			return false, nil
+		}
+		if receipt.Status == "0x0" {
+			return true, fmt.Errorf("ethereum: %w: %s reverted", chains.ErrTransactionFailed, txHash)
+		}
+		return true, nil
+	})
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type jsonRPCResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// callRPC makes a JSON-RPC call to the Ethereum node and decodes the result
+// into out.
+func (h *Helper) callRPC(ctx context.Context, method string, params []any, out any) (err error) {
+	ctx, span := h.client.StartSpan(ctx, "ethereum.rpc", privy.Attr("rpc.method", method))
+	defer func() { span.End(err) }()
+
+	if h.rpcURL == "" {
+		return fmt.Errorf("no RPC URL for chain ID %d: set WithRPCURL", h.chainID)
+	}
+	body, err := json.Marshal(&jsonRPCRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", h.rpcURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var rpcResp jsonRPCResponse
+	if err := json.Unmarshal(respBody, &rpcResp); err != nil {
+		return fmt.Errorf("failed to parse RPC response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("RPC error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+	return json.Unmarshal(rpcResp.Result, out)
+}
+
+// parseQuantity parses a 0x-prefixed hex or decimal quantity.
+func parseQuantity(s string) (*big.Int, error) {
+	v, ok := new(big.Int), false
+	if strings.HasPrefix(s, "0x") {
+		v, ok = v.SetString(s[2:], 16)
+	} else {
+		v, ok = v.SetString(s, 10)
+	}
+	if !ok || v.Sign() < 0 {
+		return nil, fmt.Errorf("invalid quantity %q", s)
+	}
+	return v, nil
+}
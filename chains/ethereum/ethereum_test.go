@@ -1,9 +1,18 @@
 package ethereum
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
 )
 
 func TestNewHelper(t *testing.T) {
@@ -55,3 +64,133 @@ func TestNewHelper_CommonChains(t *testing.T) {
 		})
 	}
 }
+
+func TestNewHelper_DefaultRPCURL(t *testing.T) {
+	client := privy.NewClient("test-app-id", "test-app-secret")
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"mainnet", nil, "https://ethereum-rpc.publicnode.com"},
+		{"testnet", []Option{WithTestnet()}, "https://ethereum-sepolia-rpc.publicnode.com"},
+		{"sepolia chain ID", []Option{WithChainID(11155111)}, "https://ethereum-sepolia-rpc.publicnode.com"},
+		{"other chain", []Option{WithChainID(137)}, ""},
+		{"other chain with URL", []Option{WithChainID(137), WithRPCURL("https://polygon.example")}, "https://polygon.example"},
+		{"URL before testnet", []Option{WithRPCURL("https://sepolia.example"), WithTestnet()}, "https://sepolia.example"},
+	}
+	for _, tt := range tests {
+		if h := NewHelper(client, tt.opts...); h.rpcURL != tt.want {
+			t.Errorf("%s: rpcURL = %q, want %q", tt.name, h.rpcURL, tt.want)
+		}
+	}
+
+	h := NewHelper(client, WithChainID(137))
+	if _, err := h.Balance(context.Background(), "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"); err == nil || !strings.Contains(err.Error(), "WithRPCURL") {
+		t.Errorf("Expected missing RPC URL error, got %v", err)
+	}
+}
+
+// newRPCServer returns a JSON-RPC node that answers from results, keyed by
+// method name.
+func newRPCServer(t *testing.T, results map[string]func(params []json.RawMessage) any) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		fn, ok := results[req.Method]
+		if !ok {
+			json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "error": map[string]any{"code": -32601, "message": "method not found"}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": fn(req.Params)})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestBalance(t *testing.T) {
+	node := newRPCServer(t, map[string]func([]json.RawMessage) any{
+		"eth_getBalance": func([]json.RawMessage) any { return "0xde0b6b3a7640000" },
+	})
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(node.URL))
+
+	balance, err := h.Balance(context.Background(), "0x742d35Cc6634C0532925a3b844Bc9e7595f2bD18")
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if balance != "1000000000000000000" {
+		t.Errorf("Expected 1000000000000000000, got %s", balance)
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"))
+
+	if err := h.ValidateAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f2bD18"); err != nil {
+		t.Errorf("Expected valid address, got %v", err)
+	}
+	for _, addr := range []string{"", "742d35Cc6634C0532925a3b844Bc9e7595f2bD18", "0x742d35", "0xZZ2d35Cc6634C0532925a3b844Bc9e7595f2bD18"} {
+		if err := h.ValidateAddress(addr); !errors.Is(err, chains.ErrInvalidAddress) {
+			t.Errorf("ValidateAddress(%q) = %v, want ErrInvalidAddress", addr, err)
+		}
+	}
+}
+
+func TestEstimateFee(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeEthereum})
+
+	var call map[string]string
+	node := newRPCServer(t, map[string]func([]json.RawMessage) any{
+		"eth_estimateGas": func(params []json.RawMessage) any {
+			json.Unmarshal(params[0], &call)
+			return "0x5208"
+		},
+		"eth_gasPrice": func([]json.RawMessage) any { return "0x3b9aca00" },
+	})
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	fee, err := h.EstimateFee(context.Background(), wallet.ID, "0x742d35Cc6634C0532925a3b844Bc9e7595f2bD18", "1000")
+	if err != nil {
+		t.Fatalf("EstimateFee failed: %v", err)
+	}
+	if fee != "21000000000000" {
+		t.Errorf("Expected 21000 gas at 1 gwei, got %s", fee)
+	}
+	if call["from"] != wallet.Address || call["value"] != "0x3e8" {
+		t.Errorf("unexpected estimateGas call %v", call)
+	}
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	polls := 0
+	status := "0x1"
+	node := newRPCServer(t, map[string]func([]json.RawMessage) any{
+		"eth_getTransactionReceipt": func([]json.RawMessage) any {
+			polls++
+			if polls < 3 {
+				return nil
+			}
+			return map[string]any{"status": status}
+		},
+	})
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(node.URL), WithPollInterval(time.Millisecond))
+
+	if err := h.WaitForConfirmation(context.Background(), "0xabc"); err != nil {
+		t.Fatalf("WaitForConfirmation failed: %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("Expected 3 polls, got %d", polls)
+	}
+
+	polls, status = 2, "0x0"
+	if err := h.WaitForConfirmation(context.Background(), "0xabc"); !errors.Is(err, chains.ErrTransactionFailed) {
+		t.Errorf("Expected ErrTransactionFailed, got %v", err)
+	}
+}
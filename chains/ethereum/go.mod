@@ -2,6 +2,23 @@ module github.com/vadimzhukck/privy-sdk-go/chains/ethereum
 
 go 1.21
 
-require github.com/vadimzhukck/privy-sdk-go v0.0.0
+require (
+	github.com/vadimzhukck/privy-sdk-go v0.0.0
+	github.com/vadimzhukck/privy-sdk-go/privytest v0.0.0
+)
 
-replace github.com/vadimzhukck/privy-sdk-go => ../..
+require (
+	github.com/bits-and-blooms/bitset v1.7.0 // indirect
+	github.com/consensys/bavard v0.1.13 // indirect
+	github.com/consensys/gnark-crypto v0.12.1 // indirect
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.0 // indirect
+	github.com/mmcloughlin/addchain v0.4.0 // indirect
+	golang.org/x/crypto v0.26.0 // indirect
+	golang.org/x/sys v0.23.0 // indirect
+	rsc.io/tmplfunc v0.0.3 // indirect
+)
+
+replace (
+	github.com/vadimzhukck/privy-sdk-go => ../..
+	github.com/vadimzhukck/privy-sdk-go/privytest => ../../privytest
+)
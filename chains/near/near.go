@@ -13,20 +13,33 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"net/http"
+	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
 )
 
+var _ chains.Chain = (*Helper)(nil)
+
 // Helper provides high-level NEAR transaction methods using Privy wallets.
 type Helper struct {
-	client     *privy.Client
-	rpcURL     string
-	httpClient *http.Client
+	client       *privy.Client
+	rpcURL       string
+	httpClient   *http.Client
+	pollInterval time.Duration
+
+	// senders maps hashes of transactions sent by Transfer to the signer
+	// account, which the tx status RPC needs.
+	mu      sync.Mutex
+	senders map[string]string
 }
 
 // Option configures the Helper.
@@ -51,13 +64,22 @@ func WithHTTPClient(c *http.Client) Option {
 	}
 }
 
+// WithPollInterval sets how often WaitForConfirmation polls the transaction status.
+func WithPollInterval(d time.Duration) Option {
+	return func(h *Helper) {
+		h.pollInterval = d
+	}
+}
+
 // NewHelper creates a new NEAR helper.
 // Options are applied in order: testnet defaults, client-level chain options, then direct options.
 func NewHelper(client *privy.Client, opts ...Option) *Helper {
 	h := &Helper{
-		client:     client,
-		rpcURL:     "https://rpc.mainnet.near.org",
-		httpClient: http.DefaultClient,
+		client:       client,
+		rpcURL:       "https://rpc.mainnet.near.org",
+		httpClient:   http.DefaultClient,
+		pollInterval: chains.DefaultPollInterval,
+		senders:      make(map[string]string),
 	}
 	if client.Testnet() {
 		WithTestnet()(h)
@@ -92,6 +114,13 @@ type jsonRPCResponse struct {
 type jsonRPCError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+	Cause   struct {
+		Name string `json:"name"`
+	} `json:"cause"`
+}
+
+func (e *jsonRPCError) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
 }
 
 type accessKeyResult struct {
@@ -114,7 +143,10 @@ type sendTxResult struct {
 // Transfer sends native NEAR from a Privy wallet to a destination account.
 // amount is in yoctoNEAR (1 NEAR = 10^24 yoctoNEAR) as a decimal string.
 // Returns the transaction hash.
-func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (string, error) {
+func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "near.transfer", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
 	// Get wallet info from Privy
 	wallet, err := h.client.Wallets().Get(ctx, walletID)
 	if err != nil {
@@ -164,7 +196,9 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 
 	// Sign via Privy raw_sign
 	hashHex := "0x" + hex.EncodeToString(hash[:])
-	signResp, err := h.client.RawSign(ctx, walletID, hashHex)
+	signCtx, signSpan := h.client.StartSpan(ctx, "near.sign")
+	signResp, err := h.client.RawSign(signCtx, walletID, hashHex)
+	signSpan.End(err)
 	if err != nil {
 		return "", fmt.Errorf("near: sign transaction: %w", err)
 	}
@@ -194,9 +228,124 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 		return "", fmt.Errorf("near: broadcast transaction: %w", err)
 	}
 
+	h.mu.Lock()
+	h.senders[txHash] = wallet.Address
+	h.mu.Unlock()
+
 	return txHash, nil
 }
 
+// transferGas is the gas burnt by a transaction with a single Transfer
+// action: action receipt creation plus the transfer action, each charged
+// once to send and once to execute.
+const transferGas = 2*108059500000 + 2*115123062500
+
+// accountIDPattern matches NEAR account IDs: dot-separated parts of lowercase
+// alphanumerics joined by single '-' or '_'.
+var accountIDPattern = regexp.MustCompile(`^(([a-z\d]+[\-_])*[a-z\d]+\.)*([a-z\d]+[\-_])*[a-z\d]+$`)
+
+// Balance returns the balance of accountID in yoctoNEAR.
+func (h *Helper) Balance(ctx context.Context, accountID string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "near.balance")
+	defer func() { span.End(err) }()
+
+	resp, err := h.callRPC(ctx, "query", map[string]any{
+		"request_type": "view_account",
+		"finality":     "final",
+		"account_id":   accountID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("near: view account: %w", err)
+	}
+
+	var account struct {
+		Amount string `json:"amount"`
+	}
+	if err := json.Unmarshal(resp, &account); err != nil {
+		return "", fmt.Errorf("near: view account: %w", err)
+	}
+	return account.Amount, nil
+}
+
+// ValidateAddress checks that accountID is a valid named or implicit NEAR
+// account ID.
+func (h *Helper) ValidateAddress(accountID string) error {
+	if len(accountID) < 2 || len(accountID) > 64 || !accountIDPattern.MatchString(accountID) {
+		return fmt.Errorf("%w: %q is not a valid NEAR account ID", chains.ErrInvalidAddress, accountID)
+	}
+	return nil
+}
+
+// EstimateFee returns the fee in yoctoNEAR for a native transfer at the
+// current gas price. The fee does not depend on the amount.
+func (h *Helper) EstimateFee(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "near.estimate_fee", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	resp, err := h.callRPC(ctx, "gas_price", []any{nil})
+	if err != nil {
+		return "", fmt.Errorf("near: get gas price: %w", err)
+	}
+
+	var result struct {
+		GasPrice string `json:"gas_price"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", fmt.Errorf("near: get gas price: %w", err)
+	}
+	price, ok := new(big.Int).SetString(result.GasPrice, 10)
+	if !ok {
+		return "", fmt.Errorf("near: invalid gas price %q", result.GasPrice)
+	}
+	return price.Mul(price, big.NewInt(transferGas)).String(), nil
+}
+
+// WaitForConfirmation waits for a transaction sent by this helper's Transfer
+// to reach finality. Use WaitForTransaction for other transactions.
+func (h *Helper) WaitForConfirmation(ctx context.Context, txHash string) error {
+	h.mu.Lock()
+	sender, ok := h.senders[txHash]
+	h.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("near: unknown sender for transaction %s; use WaitForTransaction", txHash)
+	}
+	return h.WaitForTransaction(ctx, txHash, sender)
+}
+
+// WaitForTransaction polls the status of a transaction signed by senderID
+// until it is final. A failed execution is reported as
+// chains.ErrTransactionFailed.
+func (h *Helper) WaitForTransaction(ctx context.Context, txHash string, senderID string) (err error) {
+	ctx, span := h.client.StartSpan(ctx, "near.wait_for_confirmation")
+	defer func() { span.End(err) }()
+
+	return chains.Poll(ctx, h.pollInterval, func(ctx context.Context) (bool, error) {
+		resp, err := h.callRPC(ctx, "tx", map[string]any{
+			"tx_hash":           txHash,
+			"sender_account_id": senderID,
+			"wait_until":        "FINAL",
+		})
+		var rpcErr *jsonRPCError
+		if errors.As(err, &rpcErr) && rpcErr.Cause.Name == "UNKNOWN_TRANSACTION" {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("near: get transaction status: %w", err)
+		}
+
+		var result struct {
+			Status map[string]json.RawMessage `json:"status"`
+		}
+		if err := json.Unmarshal(resp, &result); err != nil {
+			return false, fmt.Errorf("near: get transaction status: %w", err)
+		}
+		if failure, ok := result.Status["Failure"]; ok {
+			return true, fmt.Errorf("near: %w: %s", chains.ErrTransactionFailed, failure)
+		}
+		return true, nil
+	})
+}
+
 // serializeTransaction manually Borsh-serializes a NEAR transaction with a single Transfer action.
 func serializeTransaction(signerID string, pubKey []byte, nonce uint64, receiverID string, blockHash []byte, deposit *big.Int) []byte {
 	var buf bytes.Buffer
@@ -303,7 +452,10 @@ func (h *Helper) queryBlock(ctx context.Context) (*blockResult, error) {
 }
 
 // broadcastTx broadcasts a signed transaction.
-func (h *Helper) broadcastTx(ctx context.Context, signedTxBytes []byte) (string, error) {
+func (h *Helper) broadcastTx(ctx context.Context, signedTxBytes []byte) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "near.broadcast")
+	defer func() { span.End(err) }()
+
 	encoded := base64.StdEncoding.EncodeToString(signedTxBytes)
 
 	resp, err := h.callRPC(ctx, "broadcast_tx_commit", []string{encoded})
@@ -319,7 +471,10 @@ func (h *Helper) broadcastTx(ctx context.Context, signedTxBytes []byte) (string,
 }
 
 // callRPC makes a JSON-RPC call to the NEAR node.
-func (h *Helper) callRPC(ctx context.Context, method string, params any) (json.RawMessage, error) {
+func (h *Helper) callRPC(ctx context.Context, method string, params any) (_ json.RawMessage, err error) {
+	ctx, span := h.client.StartSpan(ctx, "near.rpc", privy.Attr("rpc.method", method))
+	defer func() { span.End(err) }()
+
 	reqBody := &jsonRPCRequest{
 		JSONRPC: "2.0",
 		ID:      "privy",
@@ -355,7 +510,7 @@ func (h *Helper) callRPC(ctx context.Context, method string, params any) (json.R
 	}
 
 	if rpcResp.Error != nil {
-		return nil, fmt.Errorf("RPC error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+		return nil, rpcResp.Error
 	}
 
 	return rpcResp.Result, nil
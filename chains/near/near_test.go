@@ -2,12 +2,20 @@ package near
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
 )
 
 func TestNewHelper(t *testing.T) {
@@ -147,3 +155,150 @@ func TestTransfer_WithMockServer(t *testing.T) {
 		t.Error("Expected non-empty transaction hash")
 	}
 }
+
+func TestTransfer_SignatureVerifies(t *testing.T) {
+	var broadcast []byte
+	nearServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var result any
+		switch req.Method {
+		case "query":
+			result = map[string]any{"nonce": 7}
+		case "block":
+			result = map[string]any{"header": map[string]any{"hash": "11111111111111111111111111111111"}}
+		case "broadcast_tx_commit":
+			var params []string
+			json.Unmarshal(req.Params, &params)
+			broadcast, _ = base64.StdEncoding.DecodeString(params[0])
+			result = map[string]any{"transaction": map[string]any{"hash": "tx-hash"}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": "privy", "result": result})
+	}))
+	defer nearServer.Close()
+
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, err := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeNear})
+	if err != nil {
+		t.Fatalf("Create wallet failed: %v", err)
+	}
+
+	h := NewHelper(client, WithRPCURL(nearServer.URL))
+	if _, err := h.Transfer(context.Background(), wallet.ID, "recipient.near", "1"); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+
+	// A signed transaction is the transaction followed by key type 0 and
+	// an Ed25519 signature over its SHA-256 hash.
+	if len(broadcast) < 65 {
+		t.Fatalf("broadcast transaction too short: %d bytes", len(broadcast))
+	}
+	tx, sig := broadcast[:len(broadcast)-65], broadcast[len(broadcast)-64:]
+	pub, _ := hex.DecodeString(wallet.PublicKey[2:])
+	hash := sha256.Sum256(tx)
+	if !ed25519.Verify(pub, hash[:], sig) {
+		t.Error("transaction signature does not verify against the wallet key")
+	}
+}
+
+// newRPCServer returns a NEAR RPC node that answers each method with the
+// result or error returned by handle.
+func newRPCServer(t *testing.T, handle func(method string, params json.RawMessage) (result any, rpcErr any)) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		result, rpcErr := handle(req.Method, req.Params)
+		if rpcErr != nil {
+			json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": "privy", "error": rpcErr})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": "privy", "result": result})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestBalance(t *testing.T) {
+	node := newRPCServer(t, func(method string, params json.RawMessage) (any, any) {
+		return map[string]any{"amount": "2500000000000000000000000", "locked": "0"}, nil
+	})
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(node.URL))
+
+	balance, err := h.Balance(context.Background(), "alice.near")
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if balance != "2500000000000000000000000" {
+		t.Errorf("Expected 2.5 NEAR in yocto, got %s", balance)
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"))
+
+	valid := []string{"alice.near", "app_1.alice-b.testnet", "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"}
+	for _, id := range valid {
+		if err := h.ValidateAddress(id); err != nil {
+			t.Errorf("ValidateAddress(%q) = %v, want nil", id, err)
+		}
+	}
+	invalid := []string{"a", "Alice.near", "alice..near", "-alice.near", "alice.near.", "alice@near"}
+	for _, id := range invalid {
+		if err := h.ValidateAddress(id); !errors.Is(err, chains.ErrInvalidAddress) {
+			t.Errorf("ValidateAddress(%q) = %v, want ErrInvalidAddress", id, err)
+		}
+	}
+}
+
+func TestEstimateFee(t *testing.T) {
+	node := newRPCServer(t, func(method string, params json.RawMessage) (any, any) {
+		return map[string]any{"gas_price": "100000000"}, nil
+	})
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(node.URL))
+
+	fee, err := h.EstimateFee(context.Background(), "wallet-123", "bob.near", "1")
+	if err != nil {
+		t.Fatalf("EstimateFee failed: %v", err)
+	}
+	if fee != "44636512500000000000" {
+		t.Errorf("Expected 44636512500000000000, got %s", fee)
+	}
+}
+
+func TestWaitForTransaction(t *testing.T) {
+	polls := 0
+	status := map[string]any{"SuccessValue": ""}
+	node := newRPCServer(t, func(method string, params json.RawMessage) (any, any) {
+		polls++
+		if polls == 1 {
+			return nil, map[string]any{"code": -32000, "message": "Server error", "cause": map[string]any{"name": "UNKNOWN_TRANSACTION"}}
+		}
+		return map[string]any{"status": status}, nil
+	})
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(node.URL), WithPollInterval(time.Millisecond))
+
+	if err := h.WaitForTransaction(context.Background(), "tx-hash", "alice.near"); err != nil {
+		t.Fatalf("WaitForTransaction failed: %v", err)
+	}
+	if polls != 2 {
+		t.Errorf("Expected 2 polls, got %d", polls)
+	}
+
+	status = map[string]any{"Failure": map[string]any{"ActionError": map[string]any{"index": 0}}}
+	if err := h.WaitForTransaction(context.Background(), "tx-hash", "alice.near"); !errors.Is(err, chains.ErrTransactionFailed) {
+		t.Errorf("Expected ErrTransactionFailed, got %v", err)
+	}
+
+	if err := h.WaitForConfirmation(context.Background(), "unknown-hash"); err == nil {
+		t.Error("Expected error for a transaction not sent by this helper")
+	}
+}
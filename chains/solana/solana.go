@@ -7,13 +7,16 @@ package solana
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"strconv"
+	"time"
 
 	solanago "github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/programs/system"
 	"github.com/gagliardetto/solana-go/rpc"
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
 )
 
 const (
@@ -23,12 +26,15 @@ const (
 	DevnetCAIP2 = "solana:EtWTRABZaYq6iMfeYKouRu166VU2xqa1"
 )
 
+var _ chains.Chain = (*Helper)(nil)
+
 // Helper provides convenience methods for Solana operations using Privy wallets.
 type Helper struct {
-	client    *privy.Client
-	rpcURL    string
-	caip2     string
-	rpcClient *rpc.Client
+	client       *privy.Client
+	rpcURL       string
+	caip2        string
+	rpcClient    *rpc.Client
+	pollInterval time.Duration
 }
 
 // Option configures the Helper.
@@ -61,13 +67,19 @@ func WithTestnet() Option {
 	return WithDevnet()
 }
 
+// WithPollInterval sets how often WaitForConfirmation polls the RPC node.
+func WithPollInterval(d time.Duration) Option {
+	return func(h *Helper) { h.pollInterval = d }
+}
+
 // NewHelper creates a new Solana helper.
 // Options are applied in order: testnet defaults, client-level chain options, then direct options.
 func NewHelper(client *privy.Client, opts ...Option) *Helper {
 	h := &Helper{
-		client: client,
-		rpcURL: rpc.MainNetBeta_RPC,
-		caip2:  MainnetCAIP2,
+		client:       client,
+		rpcURL:       rpc.MainNetBeta_RPC,
+		caip2:        MainnetCAIP2,
+		pollInterval: chains.DefaultPollInterval,
 	}
 	h.rpcClient = rpc.New(h.rpcURL)
 
@@ -88,34 +100,63 @@ func NewHelper(client *privy.Client, opts ...Option) *Helper {
 // Transfer sends native SOL from a Privy wallet to a destination address.
 // amount is in lamports (1 SOL = 1_000_000_000 lamports) as a decimal string.
 // Returns the transaction signature (hash).
-func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (string, error) {
+func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "solana.transfer", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
 	// Get wallet address from Privy
 	wallet, err := h.client.Wallets().Get(ctx, walletID)
 	if err != nil {
 		return "", fmt.Errorf("solana: get wallet: %w", err)
 	}
 
+	tx, err := h.buildTransfer(ctx, wallet.Address, destination, amount)
+	if err != nil {
+		return "", err
+	}
+
+	// Serialize to base64
+	txBase64, err := tx.ToBase64()
+	if err != nil {
+		return "", fmt.Errorf("solana: serialize transaction: %w", err)
+	}
+
+	// Sign and send via Privy
+	resp, err := h.client.Wallets().Solana().SignAndSendTransactionWithCAIP2(
+		ctx, walletID, txBase64, h.caip2, "",
+	)
+	if err != nil {
+		return "", fmt.Errorf("solana: sign and send: %w", err)
+	}
+
+	return resp.Data.Hash, nil
+}
+
+// buildTransfer builds an unsigned system transfer transaction paid by from.
+func (h *Helper) buildTransfer(ctx context.Context, from, destination, amount string) (*solanago.Transaction, error) {
 	// Parse amount
 	lamports, err := strconv.ParseUint(amount, 10, 64)
 	if err != nil {
-		return "", fmt.Errorf("solana: invalid amount %q: %w", amount, err)
+		return nil, fmt.Errorf("solana: invalid amount %q: %w", amount, err)
 	}
 
 	// Parse addresses
-	fromPubKey, err := solanago.PublicKeyFromBase58(wallet.Address)
+	fromPubKey, err := solanago.PublicKeyFromBase58(from)
 	if err != nil {
-		return "", fmt.Errorf("solana: invalid sender address %q: %w", wallet.Address, err)
+		return nil, fmt.Errorf("solana: invalid sender address %q: %w", from, err)
 	}
 
 	toPubKey, err := solanago.PublicKeyFromBase58(destination)
 	if err != nil {
-		return "", fmt.Errorf("solana: invalid destination address %q: %w", destination, err)
+		return nil, fmt.Errorf("solana: invalid destination address %q: %w", destination, err)
 	}
 
 	// Get recent blockhash
-	recent, err := h.rpcClient.GetLatestBlockhash(ctx, rpc.CommitmentConfirmed)
+	rpcCtx, rpcSpan := h.client.StartSpan(ctx, "solana.rpc", privy.Attr("rpc.method", "getLatestBlockhash"))
+	recent, err := h.rpcClient.GetLatestBlockhash(rpcCtx, rpc.CommitmentConfirmed)
+	rpcSpan.End(err)
 	if err != nil {
-		return "", fmt.Errorf("solana: get recent blockhash: %w", err)
+		return nil, fmt.Errorf("solana: get recent blockhash: %w", err)
 	}
 
 	// Build transfer instruction
@@ -128,22 +169,93 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 		solanago.TransactionPayer(fromPubKey),
 	)
 	if err != nil {
-		return "", fmt.Errorf("solana: build transaction: %w", err)
+		return nil, fmt.Errorf("solana: build transaction: %w", err)
 	}
+	return tx, nil
+}
 
-	// Serialize to base64
-	txBase64, err := tx.ToBase64()
+// Balance returns the SOL balance of address in lamports.
+func (h *Helper) Balance(ctx context.Context, address string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "solana.balance")
+	defer func() { span.End(err) }()
+
+	pubKey, err := solanago.PublicKeyFromBase58(address)
 	if err != nil {
-		return "", fmt.Errorf("solana: serialize transaction: %w", err)
+		return "", fmt.Errorf("solana: invalid address %q: %w", address, err)
+	}
+	result, err := h.rpcClient.GetBalance(ctx, pubKey, rpc.CommitmentConfirmed)
+	if err != nil {
+		return "", fmt.Errorf("solana: get balance: %w", err)
 	}
+	return strconv.FormatUint(result.Value, 10), nil
+}
 
-	// Sign and send via Privy
-	resp, err := h.client.Wallets().Solana().SignAndSendTransactionWithCAIP2(
-		ctx, walletID, txBase64, h.caip2, "",
-	)
+// ValidateAddress checks that address is a base58-encoded 32-byte public key.
+func (h *Helper) ValidateAddress(address string) error {
+	if _, err := solanago.PublicKeyFromBase58(address); err != nil {
+		return fmt.Errorf("%w: %v", chains.ErrInvalidAddress, err)
+	}
+	return nil
+}
+
+// EstimateFee returns the fee in lamports the network charges for the
+// transfer message, as reported by getFeeForMessage.
+func (h *Helper) EstimateFee(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "solana.estimate_fee", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
 	if err != nil {
-		return "", fmt.Errorf("solana: sign and send: %w", err)
+		return "", fmt.Errorf("solana: get wallet: %w", err)
+	}
+	tx, err := h.buildTransfer(ctx, wallet.Address, destination, amount)
+	if err != nil {
+		return "", err
+	}
+	message, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return "", fmt.Errorf("solana: serialize message: %w", err)
 	}
 
-	return resp.Data.Hash, nil
+	rpcCtx, rpcSpan := h.client.StartSpan(ctx, "solana.rpc", privy.Attr("rpc.method", "getFeeForMessage"))
+	result, err := h.rpcClient.GetFeeForMessage(rpcCtx, base64.StdEncoding.EncodeToString(message), rpc.CommitmentConfirmed)
+	rpcSpan.End(err)
+	if err != nil {
+		return "", fmt.Errorf("solana: get fee for message: %w", err)
+	}
+	if result.Value == nil {
+		return "", fmt.Errorf("solana: get fee for message: blockhash expired")
+	}
+	return strconv.FormatUint(*result.Value, 10), nil
+}
+
+// WaitForConfirmation polls getSignatureStatuses until the transaction
+// reaches confirmed commitment. A transaction with an error is reported as
+// chains.ErrTransactionFailed.
+func (h *Helper) WaitForConfirmation(ctx context.Context, txHash string) (err error) {
+	ctx, span := h.client.StartSpan(ctx, "solana.wait_for_confirmation")
+	defer func() { span.End(err) }()
+
+	sig, err := solanago.SignatureFromBase58(txHash)
+	if err != nil {
+		return fmt.Errorf("solana: invalid signature %q: %w", txHash, err)
+	}
+	return chains.Poll(ctx, h.pollInterval, func(ctx context.Context) (bool, error) {
+		result, err := h.rpcClient.GetSignatureStatuses(ctx, true, sig)
+		if err != nil {
+			return false, fmt.Errorf("solana: get signature status: %w", err)
+		}
+		if len(result.Value) == 0 || result.Value[0] == nil {
+			return false, nil
+		}
+		status := result.Value[0]
+		if status.Err != nil {
+			return true, fmt.Errorf("solana: %w: %v", chains.ErrTransactionFailed, status.Err)
+		}
+		switch status.ConfirmationStatus {
+		case rpc.ConfirmationStatusConfirmed, rpc.ConfirmationStatusFinalized:
+			return true, nil
+		}
+		return false, nil
+	})
 }
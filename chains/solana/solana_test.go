@@ -1,9 +1,16 @@
 package solana
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
 )
 
 func TestNewHelper(t *testing.T) {
@@ -51,3 +58,78 @@ func TestNewHelper_WithRPCURL(t *testing.T) {
 		t.Errorf("Expected custom RPC URL, got %s", h.rpcURL)
 	}
 }
+
+// newRPCServer starts a JSON-RPC server that answers each method with the
+// result returned by handle.
+func newRPCServer(t *testing.T, handle func(method string) any) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     any    `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": handle(req.Method)})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestBalance(t *testing.T) {
+	srv := newRPCServer(t, func(method string) any {
+		if method != "getBalance" {
+			t.Errorf("unexpected method %s", method)
+		}
+		return map[string]any{"context": map[string]any{"slot": 1}, "value": 1500000000}
+	})
+
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(srv.URL))
+	balance, err := h.Balance(context.Background(), "11111111111111111111111111111111")
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if balance != "1500000000" {
+		t.Errorf("Expected 1500000000, got %s", balance)
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"))
+
+	if err := h.ValidateAddress("11111111111111111111111111111111"); err != nil {
+		t.Errorf("Expected valid address, got %v", err)
+	}
+	for _, addr := range []string{"", "0OIl", "1111"} {
+		if err := h.ValidateAddress(addr); !errors.Is(err, chains.ErrInvalidAddress) {
+			t.Errorf("ValidateAddress(%q) = %v, want ErrInvalidAddress", addr, err)
+		}
+	}
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	polls := 0
+	srv := newRPCServer(t, func(method string) any {
+		polls++
+		status := any(nil)
+		if polls == 2 {
+			status = map[string]any{"slot": 10, "confirmations": 0, "err": nil, "confirmationStatus": "processed"}
+		} else if polls > 2 {
+			status = map[string]any{"slot": 10, "confirmations": 1, "err": nil, "confirmationStatus": "confirmed"}
+		}
+		return map[string]any{"context": map[string]any{"slot": 10}, "value": []any{status}}
+	})
+
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"),
+		WithRPCURL(srv.URL),
+		WithPollInterval(time.Millisecond),
+	)
+	sig := "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW"
+	if err := h.WaitForConfirmation(context.Background(), sig); err != nil {
+		t.Fatalf("WaitForConfirmation failed: %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("Expected 3 polls, got %d", polls)
+	}
+}
@@ -0,0 +1,232 @@
+package starknet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+var (
+	// DEPLOY_ACCOUNT transaction type prefix.
+	deployAccountPrefix = stringToFelt("deploy_account")
+
+	// Contract address prefix: the address of a contract is derived from
+	// its deployer, salt, class hash and constructor calldata.
+	contractAddressPrefix = stringToFelt("STARKNET_CONTRACT_ADDRESS")
+
+	// Addresses are reduced below 2^251 - 256.
+	addressBound = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 251), big.NewInt(256))
+)
+
+// AccountConstructor returns the constructor calldata of an account class
+// for the owner's public key (the x coordinate of its Stark key).
+type AccountConstructor func(publicKey *big.Int) []*big.Int
+
+// defaultAccountConstructor passes the public key alone, as the
+// OpenZeppelin account does.
+func defaultAccountConstructor(publicKey *big.Int) []*big.Int {
+	return []*big.Int{publicKey}
+}
+
+// WithAccountConstructor sets the constructor calldata of the account
+// class that DeployAccount deploys. By default it is the public key alone.
+func WithAccountConstructor(c AccountConstructor) Option {
+	return func(h *Helper) {
+		h.accountConstructor = c
+	}
+}
+
+// WithAutoDeploy makes Transfer and the other send methods deploy the
+// wallet's account with classHash first if it is not deployed yet. They
+// wait for the deployment to be accepted before sending.
+func WithAutoDeploy(classHash string) Option {
+	return func(h *Helper) {
+		h.autoDeployClassHash = classHash
+	}
+}
+
+// AccountAddress returns the address at which DeployAccount deploys an
+// account of classHash for a public key, using the public key as the salt.
+// It must match the wallet address for the deployment to succeed.
+func (h *Helper) AccountAddress(classHash string, publicKey string) string {
+	pubKey := hexToBigInt(publicKey)
+	return "0x" + contractAddress(hexToBigInt(classHash), pubKey, h.accountConstructor(pubKey)).Text(16)
+}
+
+// contractAddress computes the address of a contract deployed from the
+// zero address, as DEPLOY_ACCOUNT does.
+func contractAddress(classHash, salt *big.Int, calldata []*big.Int) *big.Int {
+	addr := computeHashOnElements([]*big.Int{
+		contractAddressPrefix,
+		big.NewInt(0), // deployer
+		salt,
+		classHash,
+		computeHashOnElements(calldata),
+	})
+	return addr.Mod(addr, addressBound)
+}
+
+// IsDeployed reports whether an account or other contract is deployed at
+// address.
+func (h *Helper) IsDeployed(ctx context.Context, address string) (_ bool, err error) {
+	ctx, span := h.client.StartSpan(ctx, "starknet.is_deployed")
+	defer func() { span.End(err) }()
+
+	_, err = h.callRPC(ctx, "starknet_getClassHashAt", []any{"latest", address})
+	var rpcErr *jsonRPCError
+	if errors.As(err, &rpcErr) && rpcErr.Code == errContractNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("starknet: get class hash: %w", err)
+	}
+	return true, nil
+}
+
+// DeployAccount deploys the account contract of a Privy wallet with a
+// DEPLOY_ACCOUNT transaction. classHash is the declared account class; its
+// constructor calldata comes from WithAccountConstructor. The wallet
+// address must hold enough ETH (V1) or STRK (v3) to pay the fee.
+// Returns the transaction hash.
+func (h *Helper) DeployAccount(ctx context.Context, walletID string, classHash string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "starknet.deploy_account", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("starknet: get wallet: %w", err)
+	}
+	return h.deployAccount(ctx, wallet, classHash)
+}
+
+// deployAccount signs and submits a DEPLOY_ACCOUNT transaction for wallet.
+func (h *Helper) deployAccount(ctx context.Context, wallet *privy.Wallet, classHash string) (string, error) {
+	class, ok := parseFelt(classHash)
+	if !ok {
+		return "", fmt.Errorf("starknet: invalid class hash %q", classHash)
+	}
+	if wallet.PublicKey == "" {
+		return "", fmt.Errorf("starknet: wallet %s has no public key", wallet.ID)
+	}
+	pubKey := hexToBigInt(wallet.PublicKey)
+	calldata := h.accountConstructor(pubKey)
+
+	addr := contractAddress(class, pubKey, calldata)
+	if addr.Cmp(hexToBigInt(wallet.Address)) != 0 {
+		return "", fmt.Errorf("starknet: class %s deploys to 0x%x, not the wallet address %s", classHash, addr, wallet.Address)
+	}
+
+	tx := &deployAccountTx{
+		v3Fee:     h.newV3Fee(),
+		address:   addr,
+		classHash: class,
+		salt:      pubKey,
+		calldata:  calldata,
+	}
+	if h.version == 1 {
+		return h.sendDeployAccountV1(ctx, wallet.ID, tx)
+	}
+	return h.sendV3(ctx, wallet.ID, tx, "starknet_addDeployAccountTransaction")
+}
+
+// ensureDeployed deploys wallet's account with the WithAutoDeploy class if
+// it is not deployed yet, and waits for the deployment to be accepted.
+func (h *Helper) ensureDeployed(ctx context.Context, wallet *privy.Wallet) error {
+	deployed, err := h.IsDeployed(ctx, wallet.Address)
+	if err != nil || deployed {
+		return err
+	}
+	txHash, err := h.deployAccount(ctx, wallet, h.autoDeployClassHash)
+	if err != nil {
+		return err
+	}
+	return h.WaitForConfirmation(ctx, txHash)
+}
+
+// deployAccountTx is a DEPLOY_ACCOUNT transaction. Its nonce is always 0.
+type deployAccountTx struct {
+	v3Fee
+	address   *big.Int
+	classHash *big.Int
+	salt      *big.Int
+	calldata  []*big.Int
+}
+
+func (tx *deployAccountTx) fee() *v3Fee { return &tx.v3Fee }
+
+// hash computes the v3 transaction hash (SNIP-8) with Poseidon.
+func (tx *deployAccountTx) hash(chainID *big.Int) *big.Int {
+	feeFieldsHash, paymasterHash, daModes := tx.hashFields()
+	return poseidonHashMany([]*big.Int{
+		deployAccountPrefix,
+		big.NewInt(3), // version
+		tx.address,
+		feeFieldsHash,
+		paymasterHash,
+		chainID,
+		big.NewInt(0), // nonce
+		daModes,
+		poseidonHashMany(tx.calldata),
+		tx.classHash,
+		tx.salt,
+	})
+}
+
+// hashV1 computes the V1 transaction hash with Pedersen.
+func (tx *deployAccountTx) hashV1(maxFee, chainID *big.Int) *big.Int {
+	return computeHashOnElements([]*big.Int{
+		deployAccountPrefix,
+		big.NewInt(1), // version
+		tx.address,
+		big.NewInt(0), // entry_point_selector
+		computeHashOnElements(append([]*big.Int{tx.classHash, tx.salt}, tx.calldata...)),
+		maxFee,
+		chainID,
+		big.NewInt(0), // nonce
+	})
+}
+
+// rpcFields returns the fields common to both versions in JSON-RPC form.
+func (tx *deployAccountTx) rpcFields(version string, signature []string) map[string]any {
+	return map[string]any{
+		"type":                  "DEPLOY_ACCOUNT",
+		"version":               version,
+		"signature":             signature,
+		"nonce":                 "0x0",
+		"contract_address_salt": "0x" + tx.salt.Text(16),
+		"constructor_calldata":  feltsToHex(tx.calldata),
+		"class_hash":            "0x" + tx.classHash.Text(16),
+	}
+}
+
+// rpc returns the v3 transaction in JSON-RPC form with the given signature.
+func (tx *deployAccountTx) rpc(signature []string) map[string]any {
+	return tx.addRPC(tx.rpcFields("0x3", signature))
+}
+
+// sendDeployAccountV1 signs and submits a DEPLOY_ACCOUNT V1 transaction
+// with the helper's max fee. Returns the transaction hash.
+func (h *Helper) sendDeployAccountV1(ctx context.Context, walletID string, tx *deployAccountTx) (string, error) {
+	r, s, err := h.sign(ctx, walletID, tx.hashV1(h.maxFee, h.chainID))
+	if err != nil {
+		return "", err
+	}
+
+	rpcTx := tx.rpcFields("0x1", []string{r, s})
+	rpcTx["max_fee"] = "0x" + h.maxFee.Text(16)
+	resp, err := h.callRPC(ctx, "starknet_addDeployAccountTransaction", []any{rpcTx})
+	if err != nil {
+		return "", fmt.Errorf("starknet: submit transaction: %w", err)
+	}
+	var result struct {
+		TransactionHash string `json:"transaction_hash"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", fmt.Errorf("starknet: submit transaction: %w", err)
+	}
+	return result.TransactionHash, nil
+}
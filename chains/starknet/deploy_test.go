@@ -0,0 +1,196 @@
+package starknet
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
+)
+
+const testAccountClass = "0x61dac032f228abef9c6626f995015233097ae253a7f72d68552db02f2971b8f"
+
+// counterfactualWallet adds a StarkNet wallet whose address is that of
+// its undeployed account of testAccountClass, as Privy reports it. The
+// server signs for it with a fresh key, which the tests do not check.
+func counterfactualWallet(t *testing.T, srv *privytest.Server, client *privy.Client, h *Helper) *privy.Wallet {
+	t.Helper()
+	wallet, err := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStarknet})
+	if err != nil {
+		t.Fatalf("Create wallet failed: %v", err)
+	}
+	wallet.Address = h.AccountAddress(testAccountClass, wallet.PublicKey)
+	srv.AddWallet(wallet)
+	return wallet
+}
+
+// signedHashes returns the hashes passed to raw_sign, in order.
+func signedHashes(t *testing.T, srv *privytest.Server) []*big.Int {
+	t.Helper()
+	var hashes []*big.Int
+	for _, req := range srv.RequestsMatching(privytest.Route("POST", "/v1/wallets/*/raw_sign")) {
+		var body struct {
+			Params struct {
+				Hash string `json:"hash"`
+			} `json:"params"`
+		}
+		json.Unmarshal(req.Body, &body)
+		hashes = append(hashes, hexToBigInt(body.Params.Hash))
+	}
+	return hashes
+}
+
+func TestDeployAccount(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+
+	node := newStarknetNode(t)
+	h := NewHelper(client, WithRPCURL(node.URL))
+	wallet := counterfactualWallet(t, srv, client, h)
+
+	txHash, err := h.DeployAccount(context.Background(), wallet.ID, testAccountClass)
+	if err != nil {
+		t.Fatalf("DeployAccount failed: %v", err)
+	}
+	if txHash != "0xdef456" {
+		t.Errorf("Expected tx hash 0xdef456, got %s", txHash)
+	}
+	if !node.deployed[wallet.Address] {
+		t.Errorf("Expected the account to be deployed at %s", wallet.Address)
+	}
+
+	tx := node.submitted[0]
+	if tx["type"] != "DEPLOY_ACCOUNT" || tx["version"] != "0x3" || tx["nonce"] != "0x0" {
+		t.Errorf("Unexpected transaction %v", tx)
+	}
+	if hexToBigInt(tx["contract_address_salt"].(string)).Cmp(hexToBigInt(wallet.PublicKey)) != 0 {
+		t.Errorf("Expected the public key %s as salt, got %v", wallet.PublicKey, tx["contract_address_salt"])
+	}
+	if len(node.estimated) != 1 || node.estimated[0]["type"] != "DEPLOY_ACCOUNT" {
+		t.Errorf("Expected a DEPLOY_ACCOUNT fee estimate, got %v", node.estimated)
+	}
+
+	pubKey := hexToBigInt(wallet.PublicKey)
+	want := &deployAccountTx{
+		v3Fee: v3Fee{bounds: ResourceBounds{
+			L1Gas:     resourceBound(t, tx, "l1_gas"),
+			L2Gas:     resourceBound(t, tx, "l2_gas"),
+			L1DataGas: resourceBound(t, tx, "l1_data_gas"),
+		}},
+		address:   hexToBigInt(wallet.Address),
+		classHash: hexToBigInt(testAccountClass),
+		salt:      pubKey,
+		calldata:  []*big.Int{pubKey},
+	}
+	if hashes := signedHashes(t, srv); len(hashes) != 1 || hashes[0].Cmp(want.hash(mainnetChainID)) != 0 {
+		t.Errorf("Signed hashes %v do not match the submitted transaction", hashes)
+	}
+}
+
+func TestDeployAccount_V1(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+
+	node := newStarknetNode(t)
+	h := NewHelper(client, WithRPCURL(node.URL), WithMaxFee(big.NewInt(1e15)))
+	wallet := counterfactualWallet(t, srv, client, h)
+
+	if _, err := h.DeployAccount(context.Background(), wallet.ID, testAccountClass); err != nil {
+		t.Fatalf("DeployAccount failed: %v", err)
+	}
+	tx := node.submitted[0]
+	if tx["version"] != "0x1" || tx["max_fee"] != "0x38d7ea4c68000" {
+		t.Errorf("Expected a DEPLOY_ACCOUNT V1, got %v", tx)
+	}
+
+	pubKey := hexToBigInt(wallet.PublicKey)
+	want := &deployAccountTx{
+		address:   hexToBigInt(wallet.Address),
+		classHash: hexToBigInt(testAccountClass),
+		salt:      pubKey,
+		calldata:  []*big.Int{pubKey},
+	}
+	if hashes := signedHashes(t, srv); len(hashes) != 1 || hashes[0].Cmp(want.hashV1(big.NewInt(1e15), mainnetChainID)) != 0 {
+		t.Errorf("Signed hashes %v do not match the submitted transaction", hashes)
+	}
+}
+
+func TestDeployAccount_AddressMismatch(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStarknet})
+
+	node := newStarknetNode(t)
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	_, err := h.DeployAccount(context.Background(), wallet.ID, testAccountClass)
+	if err == nil || !strings.Contains(err.Error(), "not the wallet address") {
+		t.Errorf("Expected an address mismatch error, got %v", err)
+	}
+	if len(node.submitted) != 0 {
+		t.Error("Expected nothing to be submitted")
+	}
+}
+
+func TestWithAccountConstructor(t *testing.T) {
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"))
+	argent := NewHelper(privy.NewClient("test-app-id", "test-app-secret"),
+		WithAccountConstructor(func(pubKey *big.Int) []*big.Int {
+			return []*big.Int{pubKey, big.NewInt(0)} // owner, no guardian
+		}))
+
+	pubKey := "0x1234"
+	if h.AccountAddress(testAccountClass, pubKey) == argent.AccountAddress(testAccountClass, pubKey) {
+		t.Error("Expected the constructor calldata to change the address")
+	}
+	want := contractAddress(hexToBigInt(testAccountClass), big.NewInt(0x1234), []*big.Int{big.NewInt(0x1234), big.NewInt(0)})
+	if got := argent.AccountAddress(testAccountClass, pubKey); got != "0x"+want.Text(16) {
+		t.Errorf("AccountAddress = %s, want 0x%x", got, want)
+	}
+	if want.Cmp(addressBound) >= 0 {
+		t.Errorf("Address 0x%x is out of range", want)
+	}
+}
+
+func TestIsDeployed(t *testing.T) {
+	node := newStarknetNode(t)
+	node.deployed["0x1234"] = true
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(node.URL))
+
+	for addr, want := range map[string]bool{"0x1234": true, "0x5678": false} {
+		got, err := h.IsDeployed(context.Background(), addr)
+		if err != nil {
+			t.Fatalf("IsDeployed(%s) failed: %v", addr, err)
+		}
+		if got != want {
+			t.Errorf("IsDeployed(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestTransfer_AutoDeploy(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+
+	node := newStarknetNode(t)
+	h := NewHelper(client, WithRPCURL(node.URL), WithAutoDeploy(testAccountClass))
+	wallet := counterfactualWallet(t, srv, client, h)
+
+	if _, err := h.Transfer(context.Background(), wallet.ID, "0x1234", "1"); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+	if len(node.submitted) != 2 || node.submitted[0]["type"] != "DEPLOY_ACCOUNT" || node.submitted[1]["type"] != "INVOKE" {
+		t.Fatalf("Expected DEPLOY_ACCOUNT then INVOKE, got %v", node.submitted)
+	}
+
+	// Once deployed, transfers go straight through.
+	if _, err := h.Transfer(context.Background(), wallet.ID, "0x1234", "1"); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+	if len(node.submitted) != 3 || node.submitted[2]["type"] != "INVOKE" {
+		t.Errorf("Expected a single INVOKE, got %v", node.submitted[2:])
+	}
+}
@@ -0,0 +1,92 @@
+package starknet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+// TransferERC20 sends amount of an ERC-20 token (such as STRK or USDC) from
+// a Privy wallet to destination. amount is in the token's smallest unit as
+// a decimal string. Returns the transaction hash.
+func (h *Helper) TransferERC20(ctx context.Context, walletID string, tokenContract string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "starknet.transfer_erc20", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	call, err := erc20Call(tokenContract, transferSelector, destination, amount)
+	if err != nil {
+		return "", err
+	}
+	return h.execute(ctx, walletID, []Call{call})
+}
+
+// Approve allows spender to transfer up to amount of an ERC-20 token from a
+// Privy wallet. amount is in the token's smallest unit as a decimal string.
+// Returns the transaction hash.
+func (h *Helper) Approve(ctx context.Context, walletID string, tokenContract string, spender string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "starknet.approve", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	call, err := erc20Call(tokenContract, approveSelector, spender, amount)
+	if err != nil {
+		return "", err
+	}
+	return h.execute(ctx, walletID, []Call{call})
+}
+
+// BalanceERC20 returns the ERC-20 token balance of address in the token's
+// smallest unit, read with the contract's balanceOf.
+func (h *Helper) BalanceERC20(ctx context.Context, tokenContract string, address string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "starknet.balance_erc20")
+	defer func() { span.End(err) }()
+
+	return h.balanceOf(ctx, tokenContract, address)
+}
+
+// balanceOf calls balanceOf(address) on tokenContract and returns the
+// uint256 result as a decimal string.
+func (h *Helper) balanceOf(ctx context.Context, tokenContract string, address string) (string, error) {
+	if err := h.ValidateAddress(address); err != nil {
+		return "", fmt.Errorf("starknet: invalid address: %w", err)
+	}
+	result, err := h.Call(ctx, Call{
+		ContractAddress: tokenContract,
+		Selector:        balanceOfSelector,
+		Calldata:        []*big.Int{hexToBigInt(address)},
+	})
+	if err != nil {
+		return "", fmt.Errorf("starknet: get balance: %w", err)
+	}
+	if len(result) != 2 {
+		return "", fmt.Errorf("starknet: get balance: expected uint256, got %d felts", len(result))
+	}
+	balance := new(big.Int).Lsh(result[1], 128)
+	return balance.Add(balance, result[0]).String(), nil
+}
+
+// erc20Call builds a call to an ERC-20 entry point taking an address and a
+// uint256 amount, such as transfer or approve.
+func erc20Call(tokenContract string, selector *big.Int, address string, amount string) (Call, error) {
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok || value.Sign() < 0 || value.BitLen() > 256 {
+		return Call{}, fmt.Errorf("starknet: invalid amount %q", amount)
+	}
+	recipient, ok := parseFelt(address)
+	if !ok {
+		return Call{}, fmt.Errorf("starknet: invalid address %q", address)
+	}
+
+	// A uint256 is passed as two felts: the low and high 128 bits.
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	return Call{
+		ContractAddress: tokenContract,
+		Selector:        selector,
+		Calldata: []*big.Int{
+			recipient,
+			new(big.Int).And(value, mask),
+			new(big.Int).Rsh(value, 128),
+		},
+	}, nil
+}
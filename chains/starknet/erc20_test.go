@@ -0,0 +1,138 @@
+package starknet
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
+)
+
+// submittedCalldata returns the calldata of the n-th submitted transaction
+// as hex strings.
+func submittedCalldata(t *testing.T, node *starknetNode, n int) []string {
+	t.Helper()
+	if len(node.submitted) <= n {
+		t.Fatalf("Expected at least %d submitted transactions, got %d", n+1, len(node.submitted))
+	}
+	var calldata []string
+	for _, v := range node.submitted[n]["calldata"].([]any) {
+		calldata = append(calldata, v.(string))
+	}
+	return calldata
+}
+
+func TestTransferERC20(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStarknet})
+
+	node := newStarknetNode(t)
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	// 2^128 + 5 splits into low = 5, high = 1.
+	amount := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(5))
+	txHash, err := h.TransferERC20(context.Background(), wallet.ID, "0x4718", "0xbeef", amount.String())
+	if err != nil {
+		t.Fatalf("TransferERC20 failed: %v", err)
+	}
+	if txHash != "0xabc123" {
+		t.Errorf("Expected tx hash 0xabc123, got %s", txHash)
+	}
+
+	want := []string{"0x1", "0x4718", "0x" + transferSelector.Text(16), "0x3", "0xbeef", "0x5", "0x1"}
+	if got := submittedCalldata(t, node, 0); !reflect.DeepEqual(got, want) {
+		t.Errorf("calldata = %v, want %v", got, want)
+	}
+
+	for _, amount := range []string{"-1", "abc", new(big.Int).Lsh(big.NewInt(1), 256).String()} {
+		if _, err := h.TransferERC20(context.Background(), wallet.ID, "0x4718", "0xbeef", amount); err == nil {
+			t.Errorf("Expected error for amount %q", amount)
+		}
+	}
+	if _, err := h.TransferERC20(context.Background(), wallet.ID, "0x4718", "beef", "1"); err == nil {
+		t.Error("Expected error for invalid destination")
+	}
+	if len(node.submitted) != 1 {
+		t.Errorf("Expected invalid transfers not to be submitted, got %d submissions", len(node.submitted))
+	}
+}
+
+func TestApprove(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStarknet})
+
+	node := newStarknetNode(t)
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	if _, err := h.Approve(context.Background(), wallet.ID, "0x4718", "0xcafe", "1000"); err != nil {
+		t.Fatalf("Approve failed: %v", err)
+	}
+	want := []string{"0x1", "0x4718", "0x" + approveSelector.Text(16), "0x3", "0xcafe", "0x3e8", "0x0"}
+	if got := submittedCalldata(t, node, 0); !reflect.DeepEqual(got, want) {
+		t.Errorf("calldata = %v, want %v", got, want)
+	}
+}
+
+func TestExecute_Multicall(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStarknet})
+
+	node := newStarknetNode(t)
+	h := NewHelper(client, WithRPCURL(node.URL), WithMaxFee(big.NewInt(1e15)))
+
+	swap := big.NewInt(0x5a)
+	_, err := h.Execute(context.Background(), wallet.ID, []Call{
+		{ContractAddress: "0x4718", Selector: approveSelector, Calldata: []*big.Int{big.NewInt(0xd3), big.NewInt(100), big.NewInt(0)}},
+		{ContractAddress: "0xd3", Selector: swap, Calldata: []*big.Int{big.NewInt(100)}},
+	})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	tx := node.submitted[0]
+	if tx["version"] != "0x1" {
+		t.Errorf("Expected INVOKE V1 with WithMaxFee, got version %v", tx["version"])
+	}
+	want := []string{
+		"0x2",
+		"0x4718", "0x" + approveSelector.Text(16), "0x3", "0xd3", "0x64", "0x0",
+		"0xd3", "0x5a", "0x1", "0x64",
+	}
+	if got := submittedCalldata(t, node, 0); !reflect.DeepEqual(got, want) {
+		t.Errorf("calldata = %v, want %v", got, want)
+	}
+
+	if _, err := h.Execute(context.Background(), wallet.ID, nil); err == nil {
+		t.Error("Expected error for no calls")
+	}
+	if _, err := h.Execute(context.Background(), wallet.ID, []Call{{ContractAddress: "token", Selector: swap}}); err == nil {
+		t.Error("Expected error for invalid contract address")
+	}
+}
+
+func TestBalanceERC20(t *testing.T) {
+	node := newStarknetNode(t)
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(node.URL))
+
+	balance, err := h.BalanceERC20(context.Background(), "0x4718", "0x1234")
+	if err != nil {
+		t.Fatalf("BalanceERC20 failed: %v", err)
+	}
+	want := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(5))
+	if balance != want.String() {
+		t.Errorf("Expected %s, got %s", want, balance)
+	}
+
+	call := node.called[0]
+	if call["contract_address"] != "0x4718" || call["entry_point_selector"] != "0x"+balanceOfSelector.Text(16) {
+		t.Errorf("Unexpected call %v", call)
+	}
+	if calldata, _ := call["calldata"].([]any); len(calldata) != 1 || calldata[0] != "0x1234" {
+		t.Errorf("Unexpected calldata %v", call["calldata"])
+	}
+}
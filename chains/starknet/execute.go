@@ -0,0 +1,116 @@
+package starknet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+// Call is a call to a contract entry point. Selector is the
+// starknet_keccak of the entry point name, e.g. "transfer".
+type Call struct {
+	ContractAddress string
+	Selector        *big.Int
+	Calldata        []*big.Int
+}
+
+// Execute invokes calls from a Privy wallet's account in a single
+// transaction. The calls run in order and revert together if any fails.
+// Returns the transaction hash.
+func (h *Helper) Execute(ctx context.Context, walletID string, calls []Call) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "starknet.execute", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	return h.execute(ctx, walletID, calls)
+}
+
+// execute sends calls as an INVOKE V1 or v3 transaction, depending on the
+// helper's configuration.
+func (h *Helper) execute(ctx context.Context, walletID string, calls []Call) (string, error) {
+	if len(calls) == 0 {
+		return "", fmt.Errorf("starknet: no calls to execute")
+	}
+	for _, c := range calls {
+		if _, ok := parseFelt(c.ContractAddress); !ok {
+			return "", fmt.Errorf("starknet: invalid contract address %q", c.ContractAddress)
+		}
+	}
+
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("starknet: get wallet: %w", err)
+	}
+	if h.autoDeployClassHash != "" {
+		if err := h.ensureDeployed(ctx, wallet); err != nil {
+			return "", fmt.Errorf("starknet: deploy account: %w", err)
+		}
+	}
+	nonce, err := h.getNonce(ctx, wallet.Address)
+	if err != nil {
+		return "", fmt.Errorf("starknet: get nonce: %w", err)
+	}
+
+	calldata := encodeCalls(calls)
+	if h.version == 1 {
+		return h.sendV1(ctx, walletID, wallet.Address, calldata, nonce)
+	}
+	return h.sendV3(ctx, walletID, h.newInvokeV3(wallet.Address, calldata, nonce), "starknet_addInvokeTransaction")
+}
+
+// encodeCalls encodes calls as the calldata of a Cairo 1 account's
+// __execute__: the number of calls, then for each call its contract
+// address, selector, calldata length and calldata.
+func encodeCalls(calls []Call) []*big.Int {
+	calldata := []*big.Int{big.NewInt(int64(len(calls)))}
+	for _, c := range calls {
+		calldata = append(calldata,
+			hexToBigInt(c.ContractAddress),
+			c.Selector,
+			big.NewInt(int64(len(c.Calldata))),
+		)
+		calldata = append(calldata, c.Calldata...)
+	}
+	return calldata
+}
+
+// parseFelt parses a 0x-prefixed hex felt.
+func parseFelt(s string) (*big.Int, bool) {
+	digits, ok := strings.CutPrefix(s, "0x")
+	if !ok || digits == "" || len(digits) > 64 {
+		return nil, false
+	}
+	return new(big.Int).SetString(digits, 16)
+}
+
+// Call calls a view entry point with starknet_call against the latest
+// block and returns the result felts. Nothing is signed or sent.
+func (h *Helper) Call(ctx context.Context, call Call) (_ []*big.Int, err error) {
+	ctx, span := h.client.StartSpan(ctx, "starknet.call")
+	defer func() { span.End(err) }()
+
+	resp, err := h.callRPC(ctx, "starknet_call", []any{
+		map[string]any{
+			"contract_address":     call.ContractAddress,
+			"entry_point_selector": "0x" + call.Selector.Text(16),
+			"calldata":             feltsToHex(call.Calldata),
+		},
+		"latest",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("starknet: call contract: %w", err)
+	}
+
+	var result []string
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("starknet: call contract: %w", err)
+	}
+	felts := make([]*big.Int, len(result))
+	for i, v := range result {
+		felts[i] = hexToBigInt(v)
+	}
+	return felts, nil
+}
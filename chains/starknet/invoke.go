@@ -0,0 +1,318 @@
+package starknet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// DAMode is the data availability mode of a transaction's nonce or fee.
+type DAMode uint32
+
+// Data availability modes.
+const (
+	DAModeL1 DAMode = 0
+	DAModeL2 DAMode = 1
+)
+
+func (m DAMode) String() string {
+	if m == DAModeL2 {
+		return "L2"
+	}
+	return "L1"
+}
+
+// ResourceBound limits one resource an INVOKE v3 transaction may consume:
+// at most MaxAmount units at up to MaxPricePerUnit fri (10^-18 STRK) each.
+type ResourceBound struct {
+	MaxAmount       uint64
+	MaxPricePerUnit *big.Int
+}
+
+// price returns MaxPricePerUnit, treating nil as zero.
+func (b ResourceBound) price() *big.Int {
+	if b.MaxPricePerUnit == nil {
+		return new(big.Int)
+	}
+	return b.MaxPricePerUnit
+}
+
+// ResourceBounds limits the fee of an INVOKE v3 transaction. The most it
+// can pay is the sum of MaxAmount × MaxPricePerUnit over the resources,
+// plus the tip.
+type ResourceBounds struct {
+	L1Gas     ResourceBound
+	L2Gas     ResourceBound
+	L1DataGas ResourceBound
+}
+
+// feeMargin scales estimated amounts and prices into resource bounds, as
+// a fraction: 3/2 allows 50% more than the estimate.
+var feeMargin = [2]int64{3, 2}
+
+// Resource names as encoded in the transaction hash.
+var (
+	l1GasName     = stringToFelt("L1_GAS")
+	l2GasName     = stringToFelt("L2_GAS")
+	l1DataGasName = stringToFelt("L1_DATA")
+)
+
+// WithResourceBounds sets fixed resource bounds for INVOKE v3 transactions
+// instead of estimating them with starknet_estimateFee.
+func WithResourceBounds(bounds ResourceBounds) Option {
+	return func(h *Helper) {
+		h.resourceBounds = &bounds
+	}
+}
+
+// WithTip sets the tip, in fri per unit of L2 gas, that INVOKE v3
+// transactions pay to be prioritized.
+func WithTip(tip uint64) Option {
+	return func(h *Helper) {
+		h.tip = tip
+	}
+}
+
+// WithPaymasterData sets the paymaster data of INVOKE v3 transactions.
+func WithPaymasterData(data ...*big.Int) Option {
+	return func(h *Helper) {
+		h.paymasterData = data
+	}
+}
+
+// WithDataAvailabilityModes sets where the nonce and the fee of INVOKE v3
+// transactions are published. Both default to DAModeL1.
+func WithDataAvailabilityModes(nonce, fee DAMode) Option {
+	return func(h *Helper) {
+		h.nonceDAMode = nonce
+		h.feeDAMode = fee
+	}
+}
+
+// v3Fee holds the fee fields shared by v3 transactions.
+type v3Fee struct {
+	bounds        ResourceBounds
+	tip           uint64
+	paymasterData []*big.Int
+	nonceDAMode   DAMode
+	feeDAMode     DAMode
+}
+
+// newV3Fee returns the helper's v3 fee settings with zero resource bounds.
+func (h *Helper) newV3Fee() v3Fee {
+	return v3Fee{
+		tip:           h.tip,
+		paymasterData: h.paymasterData,
+		nonceDAMode:   h.nonceDAMode,
+		feeDAMode:     h.feeDAMode,
+	}
+}
+
+// hashFields returns the fee fields as they appear in a v3 transaction hash
+// (SNIP-8): the hash of the tip and resource bounds, the hash of the
+// paymaster data and the packed data availability modes.
+func (f *v3Fee) hashFields() (feeFieldsHash, paymasterHash, daModes *big.Int) {
+	feeFieldsHash = poseidonHashMany([]*big.Int{
+		new(big.Int).SetUint64(f.tip),
+		resourceBoundFelt(l1GasName, f.bounds.L1Gas),
+		resourceBoundFelt(l2GasName, f.bounds.L2Gas),
+		resourceBoundFelt(l1DataGasName, f.bounds.L1DataGas),
+	})
+	daModes = new(big.Int).SetUint64(uint64(f.nonceDAMode)<<32 | uint64(f.feeDAMode))
+	return feeFieldsHash, poseidonHashMany(f.paymasterData), daModes
+}
+
+// resourceBoundFelt packs a resource bound as hashed: the resource name in
+// the top 60 bits, then the 64-bit max amount and 128-bit max price.
+func resourceBoundFelt(name *big.Int, b ResourceBound) *big.Int {
+	felt := new(big.Int).Lsh(name, 192)
+	felt.Or(felt, new(big.Int).Lsh(new(big.Int).SetUint64(b.MaxAmount), 128))
+	return felt.Or(felt, b.price())
+}
+
+// addRPC adds the fee fields to a transaction in JSON-RPC form.
+func (f *v3Fee) addRPC(tx map[string]any) map[string]any {
+	bound := func(b ResourceBound) map[string]string {
+		return map[string]string{
+			"max_amount":         fmt.Sprintf("0x%x", b.MaxAmount),
+			"max_price_per_unit": "0x" + b.price().Text(16),
+		}
+	}
+	tx["resource_bounds"] = map[string]any{
+		"l1_gas":      bound(f.bounds.L1Gas),
+		"l2_gas":      bound(f.bounds.L2Gas),
+		"l1_data_gas": bound(f.bounds.L1DataGas),
+	}
+	tx["tip"] = fmt.Sprintf("0x%x", f.tip)
+	tx["paymaster_data"] = feltsToHex(f.paymasterData)
+	tx["nonce_data_availability_mode"] = f.nonceDAMode.String()
+	tx["fee_data_availability_mode"] = f.feeDAMode.String()
+	return tx
+}
+
+// v3Transaction is a v3 transaction that sendV3 can estimate, sign and
+// submit.
+type v3Transaction interface {
+	fee() *v3Fee
+	hash(chainID *big.Int) *big.Int
+	rpc(signature []string) map[string]any
+}
+
+// invokeV3 is an INVOKE v3 transaction.
+type invokeV3 struct {
+	v3Fee
+	sender   *big.Int
+	calldata []*big.Int
+	nonce    *big.Int
+}
+
+// newInvokeV3 returns an INVOKE v3 transaction with the helper's fee
+// settings and zero resource bounds.
+func (h *Helper) newInvokeV3(sender string, calldata []*big.Int, nonce *big.Int) *invokeV3 {
+	return &invokeV3{
+		v3Fee:    h.newV3Fee(),
+		sender:   hexToBigInt(sender),
+		calldata: calldata,
+		nonce:    nonce,
+	}
+}
+
+func (tx *invokeV3) fee() *v3Fee { return &tx.v3Fee }
+
+// hash computes the transaction hash (SNIP-8) with Poseidon.
+func (tx *invokeV3) hash(chainID *big.Int) *big.Int {
+	feeFieldsHash, paymasterHash, daModes := tx.hashFields()
+	return poseidonHashMany([]*big.Int{
+		invokePrefix,
+		big.NewInt(3), // version
+		tx.sender,
+		feeFieldsHash,
+		paymasterHash,
+		chainID,
+		tx.nonce,
+		daModes,
+		poseidonHashMany(nil), // account_deployment_data
+		poseidonHashMany(tx.calldata),
+	})
+}
+
+// rpc returns the transaction in JSON-RPC form with the given signature.
+func (tx *invokeV3) rpc(signature []string) map[string]any {
+	return tx.addRPC(map[string]any{
+		"type":                    "INVOKE",
+		"sender_address":          "0x" + tx.sender.Text(16),
+		"calldata":                feltsToHex(tx.calldata),
+		"version":                 "0x3",
+		"signature":               signature,
+		"nonce":                   "0x" + tx.nonce.Text(16),
+		"account_deployment_data": []string{},
+	})
+}
+
+// feeEstimate is a starknet_estimateFee result. Amounts are hex felts.
+type feeEstimate struct {
+	L1GasConsumed     string `json:"l1_gas_consumed"`
+	L1GasPrice        string `json:"l1_gas_price"`
+	L2GasConsumed     string `json:"l2_gas_consumed"`
+	L2GasPrice        string `json:"l2_gas_price"`
+	L1DataGasConsumed string `json:"l1_data_gas_consumed"`
+	L1DataGasPrice    string `json:"l1_data_gas_price"`
+	OverallFee        string `json:"overall_fee"`
+	Unit              string `json:"unit"`
+}
+
+// bounds returns resource bounds with the fee margin over the estimate.
+func (e *feeEstimate) bounds() ResourceBounds {
+	bound := func(amount, price string) ResourceBound {
+		a := hexToBigInt(amount)
+		a.Mul(a, big.NewInt(feeMargin[0])).Quo(a, big.NewInt(feeMargin[1]))
+		p := hexToBigInt(price)
+		p.Mul(p, big.NewInt(feeMargin[0])).Quo(p, big.NewInt(feeMargin[1]))
+		return ResourceBound{MaxAmount: a.Uint64(), MaxPricePerUnit: p}
+	}
+	return ResourceBounds{
+		L1Gas:     bound(e.L1GasConsumed, e.L1GasPrice),
+		L2Gas:     bound(e.L2GasConsumed, e.L2GasPrice),
+		L1DataGas: bound(e.L1DataGasConsumed, e.L1DataGasPrice),
+	}
+}
+
+// estimateFee estimates an unsigned transaction with starknet_estimateFee,
+// skipping signature validation.
+func (h *Helper) estimateFee(ctx context.Context, tx map[string]any) (*feeEstimate, error) {
+	resp, err := h.callRPC(ctx, "starknet_estimateFee", []any{[]any{tx}, []string{"SKIP_VALIDATE"}, "latest"})
+	if err != nil {
+		return nil, err
+	}
+	var estimates []feeEstimate
+	if err := json.Unmarshal(resp, &estimates); err != nil {
+		return nil, err
+	}
+	if len(estimates) == 0 {
+		return nil, fmt.Errorf("empty response")
+	}
+	return &estimates[0], nil
+}
+
+// sendV3 sets the resource bounds of tx, from WithResourceBounds or an
+// estimate, then signs it and submits it with method, e.g.
+// "starknet_addInvokeTransaction". Returns the transaction hash.
+func (h *Helper) sendV3(ctx context.Context, walletID string, tx v3Transaction, method string) (string, error) {
+	if h.resourceBounds != nil {
+		tx.fee().bounds = *h.resourceBounds
+	} else {
+		estimate, err := h.estimateFee(ctx, tx.rpc([]string{}))
+		if err != nil {
+			return "", fmt.Errorf("starknet: estimate fee: %w", err)
+		}
+		tx.fee().bounds = estimate.bounds()
+	}
+
+	r, s, err := h.sign(ctx, walletID, tx.hash(h.chainID))
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := h.callRPC(ctx, method, []any{tx.rpc([]string{r, s})})
+	if err != nil {
+		return "", fmt.Errorf("starknet: submit transaction: %w", err)
+	}
+	var result struct {
+		TransactionHash string `json:"transaction_hash"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", fmt.Errorf("starknet: submit transaction: %w", err)
+	}
+	return result.TransactionHash, nil
+}
+
+// sign signs a transaction hash via Privy raw_sign and returns the r and s
+// signature felts.
+func (h *Helper) sign(ctx context.Context, walletID string, txHash *big.Int) (r, s string, err error) {
+	hashHex := "0x" + txHash.Text(16)
+	signCtx, signSpan := h.client.StartSpan(ctx, "starknet.sign")
+	signResp, err := h.client.RawSign(signCtx, walletID, hashHex)
+	signSpan.End(err)
+	if err != nil {
+		return "", "", fmt.Errorf("starknet: sign transaction: %w", err)
+	}
+
+	// Parse signature (r, s)
+	sigHex := strings.TrimPrefix(signResp.Data.Signature, "0x")
+	if len(sigHex) < 128 {
+		// Pad to 128 hex chars (64 bytes = r(32) + s(32))
+		sigHex = strings.Repeat("0", 128-len(sigHex)) + sigHex
+	}
+	return "0x" + sigHex[:64], "0x" + sigHex[64:128], nil
+}
+
+// feltsToHex formats felts as 0x-prefixed hex strings for JSON-RPC.
+func feltsToHex(felts []*big.Int) []string {
+	out := make([]string, len(felts))
+	for i, v := range felts {
+		out[i] = "0x" + v.Text(16)
+	}
+	return out
+}
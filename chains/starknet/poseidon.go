@@ -0,0 +1,78 @@
+package starknet
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+// StarkNet's Poseidon is the Hades permutation over the Stark field with a
+// state of three elements, x^3 as the S-box, 8 full and 83 partial rounds.
+const (
+	poseidonFullRounds    = 8
+	poseidonPartialRounds = 83
+	poseidonRounds        = poseidonFullRounds + poseidonPartialRounds
+)
+
+// poseidonConstants holds the round constants, three per round. Like the
+// reference implementation, the i-th constant is sha256("Hades<i>") mod p.
+var poseidonConstants = func() [poseidonRounds][3]fp.Element {
+	var ark [poseidonRounds][3]fp.Element
+	for r := range ark {
+		for j := range ark[r] {
+			sum := sha256.Sum256([]byte(fmt.Sprintf("Hades%d", 3*r+j)))
+			ark[r][j].SetBigInt(new(big.Int).SetBytes(sum[:]))
+		}
+	}
+	return ark
+}()
+
+// hadesPermutation applies the Hades permutation to state in place.
+func hadesPermutation(state *[3]fp.Element) {
+	var sq, t fp.Element
+	for r := 0; r < poseidonRounds; r++ {
+		for j := range state {
+			state[j].Add(&state[j], &poseidonConstants[r][j])
+		}
+
+		full := r < poseidonFullRounds/2 || r >= poseidonFullRounds/2+poseidonPartialRounds
+		for j := range state {
+			if full || j == 2 {
+				sq.Square(&state[j])
+				state[j].Mul(&state[j], &sq)
+			}
+		}
+
+		// MDS matrix [[3, 1, 1], [1, -1, 1], [1, 1, -2]].
+		t.Add(&state[0], &state[1])
+		t.Add(&t, &state[2])
+		state[0].Double(&state[0])
+		state[0].Add(&state[0], &t)
+		state[1].Double(&state[1])
+		state[1].Sub(&t, &state[1])
+		sq.Double(&state[2])
+		state[2].Add(&state[2], &sq)
+		state[2].Sub(&t, &state[2])
+	}
+}
+
+// poseidonHashMany computes StarkNet's Poseidon hash of a sequence of felts:
+// the input is padded with 1 and then 0s to an even length and absorbed two
+// elements at a time.
+func poseidonHashMany(elements []*big.Int) *big.Int {
+	padded := append(append([]*big.Int{}, elements...), big.NewInt(1))
+	if len(padded)%2 == 1 {
+		padded = append(padded, big.NewInt(0))
+	}
+
+	var state [3]fp.Element
+	var e fp.Element
+	for i := 0; i < len(padded); i += 2 {
+		state[0].Add(&state[0], e.SetBigInt(padded[i]))
+		state[1].Add(&state[1], e.SetBigInt(padded[i+1]))
+		hadesPermutation(&state)
+	}
+	return state[0].BigInt(new(big.Int))
+}
@@ -0,0 +1,44 @@
+package starknet
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+func TestHadesPermutation(t *testing.T) {
+	var state [3]fp.Element
+	hadesPermutation(&state)
+
+	want := []string{
+		"79e8d1e78258000a28fc9d49e233bc6852357968577b1e386550ed6a9086133",
+		"3840d003d0f3f96dbb796ff6aa6a63be5b5404b91ccaabca256154cbb6fb984",
+		"1eb39da3f7d3b04142d0ac83d9da00c9325a61fb2ef326e50b70eaa8a3c7cc7",
+	}
+	for i := range state {
+		if got := state[i].BigInt(new(big.Int)).Text(16); got != want[i] {
+			t.Errorf("state[%d] = %s, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestPoseidonHashMany(t *testing.T) {
+	tests := []struct {
+		input []int64
+		want  string
+	}{
+		{nil, "2272be0f580fd156823304800919530eaa97430e972d7213ee13f4fbf7a5dbc"},
+		{[]int64{1, 2}, "371cb6995ea5e7effcd2e174de264b5b407027a75a231a70c2c8d196107f0e7"},
+		{[]int64{1, 2, 3}, "2f0d8840bcf3bc629598d8a6cc80cb7c0d9e52d93dab244bbf9cd0dca0ad082"},
+	}
+	for _, tt := range tests {
+		elements := make([]*big.Int, len(tt.input))
+		for i, v := range tt.input {
+			elements[i] = big.NewInt(v)
+		}
+		if got := poseidonHashMany(elements).Text(16); got != tt.want {
+			t.Errorf("poseidonHashMany(%v) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
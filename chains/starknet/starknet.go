@@ -1,23 +1,31 @@
 // Package starknet provides a high-level helper for StarkNet transactions
 // using Privy's raw_sign endpoint and the StarkNet JSON-RPC API.
 //
-// Transactions are built as INVOKE V1, hashed using Pedersen hash,
-// signed via Privy, and submitted to a StarkNet node.
+// Transactions are built as INVOKE v3, hashed using Poseidon, signed via
+// Privy, and submitted to a StarkNet node. Fees are paid in STRK within
+// resource bounds estimated by the node. The deprecated INVOKE V1, hashed
+// using Pedersen and paying fees in ETH, is used when WithMaxFee is set.
+//
+// Breaking change: INVOKE V1 used to be the default. Accounts that hold
+// only ETH can no longer pay fees unless WithMaxFee is set explicitly.
 package starknet
 
 import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
 	pedersenhash "github.com/consensys/gnark-crypto/ecc/stark-curve/pedersen-hash"
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
 )
 
 // Well-known StarkNet constants.
@@ -28,6 +36,12 @@ var (
 	// Selector for the "transfer" function: starknet_keccak("transfer").
 	transferSelector = hexToBigInt("0x83afd3f4caedc6eebf44246fe54e38c95e3179a5ec9ea81740eca5b482d12e")
 
+	// Selector for the "balanceOf" function: starknet_keccak("balanceOf").
+	balanceOfSelector = hexToBigInt("0x2e4263afad30923c891518314c3c95dbe830a16874e8abc5777a9a20b54c76e")
+
+	// Selector for the "approve" function: starknet_keccak("approve").
+	approveSelector = hexToBigInt("0x219209e083275171774dab1df80982e9df2096516f06319c5c6d71ae0a8480c")
+
 	// INVOKE transaction type prefix.
 	invokePrefix = stringToFelt("invoke")
 
@@ -35,13 +49,34 @@ var (
 	mainnetChainID = stringToFelt("SN_MAIN")
 )
 
+// JSON-RPC error codes.
+const (
+	errContractNotFound = 20 // no contract at the address
+	errTxNotFound       = 29 // unknown transaction hash
+)
+
+var _ chains.Chain = (*Helper)(nil)
+
 // Helper provides high-level StarkNet transaction methods using Privy wallets.
 type Helper struct {
-	client     *privy.Client
-	rpcURL     string
-	chainID    *big.Int
-	maxFee     *big.Int
-	httpClient *http.Client
+	client       *privy.Client
+	rpcURL       string
+	chainID      *big.Int
+	maxFee       *big.Int
+	httpClient   *http.Client
+	pollInterval time.Duration
+
+	// INVOKE version and v3 fee settings.
+	version        int
+	resourceBounds *ResourceBounds
+	tip            uint64
+	paymasterData  []*big.Int
+	nonceDAMode    DAMode
+	feeDAMode      DAMode
+
+	// Account deployment.
+	accountConstructor  AccountConstructor
+	autoDeployClassHash string
 }
 
 // Option configures the Helper.
@@ -61,10 +96,13 @@ func WithChainID(chainID string) Option {
 	}
 }
 
-// WithMaxFee sets the maximum fee for transactions (in wei).
+// WithMaxFee sends deprecated INVOKE V1 transactions, which pay fees in
+// ETH, with the given maximum fee (in wei). Set it to keep the V1 behaviour
+// that was the default before INVOKE v3.
 func WithMaxFee(maxFee *big.Int) Option {
 	return func(h *Helper) {
 		h.maxFee = maxFee
+		h.version = 1
 	}
 }
 
@@ -83,15 +121,26 @@ func WithHTTPClient(c *http.Client) Option {
 	}
 }
 
+// WithPollInterval sets how often WaitForConfirmation polls the node.
+func WithPollInterval(d time.Duration) Option {
+	return func(h *Helper) {
+		h.pollInterval = d
+	}
+}
+
 // NewHelper creates a new StarkNet helper.
 // Options are applied in order: testnet defaults, client-level chain options, then direct options.
 func NewHelper(client *privy.Client, opts ...Option) *Helper {
 	h := &Helper{
-		client:     client,
-		rpcURL:     "https://starknet-mainnet.public.blastapi.io",
-		chainID:    mainnetChainID,
-		maxFee:     big.NewInt(1e16), // 0.01 ETH default
-		httpClient: http.DefaultClient,
+		client:       client,
+		rpcURL:       "https://starknet-mainnet.public.blastapi.io",
+		chainID:      mainnetChainID,
+		maxFee:       big.NewInt(1e16), // 0.01 ETH default
+		httpClient:   http.DefaultClient,
+		pollInterval: chains.DefaultPollInterval,
+		version:      3,
+
+		accountConstructor: defaultAccountConstructor,
 	}
 	if client.Testnet() {
 		WithTestnet()(h)
@@ -128,33 +177,27 @@ type jsonRPCError struct {
 	Message string `json:"message"`
 }
 
+func (e *jsonRPCError) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
+}
+
 // Transfer sends native ETH on StarkNet from a Privy wallet to a destination.
 // amount is in wei as a decimal string.
 // Returns the transaction hash.
-func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (string, error) {
-	// Get wallet info from Privy
-	wallet, err := h.client.Wallets().Get(ctx, walletID)
-	if err != nil {
-		return "", fmt.Errorf("starknet: get wallet: %w", err)
-	}
-
-	senderAddress := hexToBigInt(wallet.Address)
+func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "starknet.transfer", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
 
-	// Parse amount as uint256 (low, high)
-	amountBig := new(big.Int)
-	if _, ok := amountBig.SetString(amount, 10); !ok {
-		return "", fmt.Errorf("starknet: invalid amount %q", amount)
-	}
-
-	// Query nonce
-	nonce, err := h.getNonce(ctx, wallet.Address)
+	call, err := erc20Call("0x"+ethContractAddress.Text(16), transferSelector, destination, amount)
 	if err != nil {
-		return "", fmt.Errorf("starknet: get nonce: %w", err)
+		return "", err
 	}
+	return h.execute(ctx, walletID, []Call{call})
+}
 
-	// Build calldata for ETH transfer via account's __execute__
-	calldata := buildETHTransferCalldata(destination, amountBig)
-
+// sendV1 signs and submits an INVOKE V1 transaction. Returns the
+// transaction hash.
+func (h *Helper) sendV1(ctx context.Context, walletID string, sender string, calldata []*big.Int, nonce *big.Int) (string, error) {
 	// Compute calldata hash
 	calldataHash := computeHashOnElements(calldata)
 
@@ -162,7 +205,7 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 	txHash := computeHashOnElements([]*big.Int{
 		invokePrefix,
 		big.NewInt(1), // version
-		senderAddress,
+		hexToBigInt(sender),
 		big.NewInt(0), // entry_point_selector (0 for invoke)
 		calldataHash,
 		h.maxFee,
@@ -170,30 +213,13 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 		nonce,
 	})
 
-	// Sign via Privy raw_sign
-	hashHex := "0x" + txHash.Text(16)
-	signResp, err := h.client.RawSign(ctx, walletID, hashHex)
+	r, s, err := h.sign(ctx, walletID, txHash)
 	if err != nil {
-		return "", fmt.Errorf("starknet: sign transaction: %w", err)
-	}
-
-	// Parse signature (r, s)
-	sigHex := strings.TrimPrefix(signResp.Data.Signature, "0x")
-	if len(sigHex) < 128 {
-		// Pad to 128 hex chars (64 bytes = r(32) + s(32))
-		sigHex = strings.Repeat("0", 128-len(sigHex)) + sigHex
-	}
-	r := "0x" + sigHex[:64]
-	s := "0x" + sigHex[64:128]
-
-	// Build calldata hex strings for RPC
-	calldataHex := make([]string, len(calldata))
-	for i, v := range calldata {
-		calldataHex[i] = "0x" + v.Text(16)
+		return "", err
 	}
 
 	// Submit via RPC
-	resultHash, err := h.addInvokeTransaction(ctx, wallet.Address, calldataHex, h.maxFee, nonce, r, s)
+	resultHash, err := h.addInvokeTransaction(ctx, sender, feltsToHex(calldata), h.maxFee, nonce, r, s)
 	if err != nil {
 		return "", fmt.Errorf("starknet: submit transaction: %w", err)
 	}
@@ -201,31 +227,11 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 	return resultHash, nil
 }
 
-// buildETHTransferCalldata builds the calldata for an ETH transfer via __execute__.
-// Uses the standard account contract format for INVOKE V1.
-func buildETHTransferCalldata(recipient string, amount *big.Int) []*big.Int {
-	recipientFelt := hexToBigInt(recipient)
-
-	// Split amount into Uint256 (low, high)
-	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
-	amountLow := new(big.Int).And(amount, mask)
-	amountHigh := new(big.Int).Rsh(amount, 128)
-
-	return []*big.Int{
-		big.NewInt(1),          // call_array_len
-		ethContractAddress,     // to
-		transferSelector,       // selector
-		big.NewInt(0),          // data_offset
-		big.NewInt(3),          // data_len
-		big.NewInt(3),          // calldata_len
-		recipientFelt,          // recipient
-		amountLow,              // amount.low
-		amountHigh,             // amount.high
-	}
-}
-
 // getNonce queries the nonce for an account.
-func (h *Helper) getNonce(ctx context.Context, address string) (*big.Int, error) {
+func (h *Helper) getNonce(ctx context.Context, address string) (_ *big.Int, err error) {
+	ctx, span := h.client.StartSpan(ctx, "starknet.get_nonce")
+	defer func() { span.End(err) }()
+
 	resp, err := h.callRPC(ctx, "starknet_getNonce", []any{
 		"latest",
 		address,
@@ -246,7 +252,10 @@ func (h *Helper) getNonce(ctx context.Context, address string) (*big.Int, error)
 }
 
 // addInvokeTransaction submits a signed INVOKE V1 transaction.
-func (h *Helper) addInvokeTransaction(ctx context.Context, senderAddr string, calldata []string, maxFee *big.Int, nonce *big.Int, r, s string) (string, error) {
+func (h *Helper) addInvokeTransaction(ctx context.Context, senderAddr string, calldata []string, maxFee *big.Int, nonce *big.Int, r, s string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "starknet.broadcast")
+	defer func() { span.End(err) }()
+
 	tx := map[string]any{
 		"type":               "INVOKE",
 		"sender_address":     senderAddr,
@@ -272,13 +281,113 @@ func (h *Helper) addInvokeTransaction(ctx context.Context, senderAddr string, ca
 	return result.TransactionHash, nil
 }
 
-// TransferERC20 sends an ERC-20 token on StarkNet.
-func (h *Helper) TransferERC20(ctx context.Context, walletID string, tokenContract string, destination string, amount string) (string, error) {
-	return "", fmt.Errorf("starknet: TransferERC20 not yet implemented")
+// Balance returns the ETH balance of address in wei.
+func (h *Helper) Balance(ctx context.Context, address string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "starknet.balance")
+	defer func() { span.End(err) }()
+
+	return h.balanceOf(ctx, "0x"+ethContractAddress.Text(16), address)
+}
+
+// ValidateAddress checks that address is a 0x-prefixed hex felt below 2^251.
+func (h *Helper) ValidateAddress(address string) error {
+	digits, ok := strings.CutPrefix(address, "0x")
+	if !ok || digits == "" || len(digits) > 64 {
+		return fmt.Errorf("%w: %q is not a 0x-prefixed felt", chains.ErrInvalidAddress, address)
+	}
+	n, ok := new(big.Int).SetString(digits, 16)
+	if !ok {
+		return fmt.Errorf("%w: %q is not hex", chains.ErrInvalidAddress, address)
+	}
+	if n.BitLen() > 251 {
+		return fmt.Errorf("%w: %q is out of range", chains.ErrInvalidAddress, address)
+	}
+	return nil
+}
+
+// EstimateFee returns the overall fee for the transfer, as reported by
+// starknet_estimateFee with signature validation skipped. It is in fri
+// (10^-18 STRK) for INVOKE v3 and in wei for INVOKE V1.
+func (h *Helper) EstimateFee(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "starknet.estimate_fee", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	call, err := erc20Call("0x"+ethContractAddress.Text(16), transferSelector, destination, amount)
+	if err != nil {
+		return "", err
+	}
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("starknet: get wallet: %w", err)
+	}
+	nonce, err := h.getNonce(ctx, wallet.Address)
+	if err != nil {
+		return "", fmt.Errorf("starknet: get nonce: %w", err)
+	}
+
+	calldata := encodeCalls([]Call{call})
+	var tx map[string]any
+	if h.version == 1 {
+		tx = map[string]any{
+			"type":           "INVOKE",
+			"sender_address": wallet.Address,
+			"calldata":       feltsToHex(calldata),
+			"max_fee":        "0x" + h.maxFee.Text(16),
+			"version":        "0x1",
+			"signature":      []string{},
+			"nonce":          "0x" + nonce.Text(16),
+		}
+	} else {
+		tx = h.newInvokeV3(wallet.Address, calldata, nonce).rpc([]string{})
+	}
+
+	estimate, err := h.estimateFee(ctx, tx)
+	if err != nil {
+		return "", fmt.Errorf("starknet: estimate fee: %w", err)
+	}
+	return hexToBigInt(estimate.OverallFee).String(), nil
+}
+
+// WaitForConfirmation polls the transaction receipt until it is accepted on
+// L2. A reverted transaction is reported as chains.ErrTransactionFailed.
+func (h *Helper) WaitForConfirmation(ctx context.Context, txHash string) (err error) {
+	ctx, span := h.client.StartSpan(ctx, "starknet.wait_for_confirmation")
+	defer func() { span.End(err) }()
+
+	return chains.Poll(ctx, h.pollInterval, func(ctx context.Context) (bool, error) {
+		resp, err := h.callRPC(ctx, "starknet_getTransactionReceipt", []any{txHash})
+		var rpcErr *jsonRPCError
+		if errors.As(err, &rpcErr) && rpcErr.Code == errTxNotFound {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("starknet: get receipt: %w", err)
+		}
+
+		var receipt struct {
+			ExecutionStatus string `json:"execution_status"`
+			FinalityStatus  string `json:"finality_status"`
+			RevertReason    string `json:"revert_reason"`
+		}
+		if err := json.Unmarshal(resp, &receipt); err != nil {
+			return false, fmt.Errorf("starknet: get receipt: %w", err)
+		}
+		if receipt.ExecutionStatus == "REVERTED" {
+			return true, fmt.Errorf("starknet: %w: %s", chains.ErrTransactionFailed, receipt.RevertReason)
+		}
+		switch receipt.FinalityStatus {
+		case "ACCEPTED_ON_L2", "ACCEPTED_ON_L1":
+			return true, nil
+		}
+		return false, nil
+	})
 }
 
 // callRPC makes a JSON-RPC call to the StarkNet node.
-func (h *Helper) callRPC(ctx context.Context, method string, params any) (json.RawMessage, error) {
+func (h *Helper) callRPC(ctx context.Context, method string, params any) (_ json.RawMessage, err error) {
+	ctx, span := h.client.StartSpan(ctx, "starknet.rpc", privy.Attr("rpc.method", method))
+	defer func() { span.End(err) }()
+
 	reqBody := &jsonRPCRequest{
 		JSONRPC: "2.0",
 		ID:      1,
@@ -314,7 +423,7 @@ func (h *Helper) callRPC(ctx context.Context, method string, params any) (json.R
 	}
 
 	if rpcResp.Error != nil {
-		return nil, fmt.Errorf("RPC error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+		return nil, rpcResp.Error
 	}
 
 	return rpcResp.Result, nil
@@ -3,12 +3,17 @@ package starknet
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
 )
 
 func TestNewHelper(t *testing.T) {
@@ -90,38 +95,21 @@ func TestHexToBigInt(t *testing.T) {
 	}
 }
 
-func TestBuildETHTransferCalldata(t *testing.T) {
-	recipient := "0x1234567890abcdef"
-	amount := big.NewInt(1000000)
+func TestEncodeCalls(t *testing.T) {
+	calldata := encodeCalls([]Call{
+		{ContractAddress: "0x10", Selector: big.NewInt(0x20), Calldata: []*big.Int{big.NewInt(1), big.NewInt(2)}},
+		{ContractAddress: "0x30", Selector: big.NewInt(0x40)},
+	})
 
-	calldata := buildETHTransferCalldata(recipient, amount)
-
-	// Should have 9 elements
-	if len(calldata) != 9 {
-		t.Fatalf("Expected 9 calldata elements, got %d", len(calldata))
-	}
-
-	// call_array_len should be 1
-	if calldata[0].Int64() != 1 {
-		t.Errorf("Expected call_array_len=1, got %d", calldata[0].Int64())
-	}
-
-	// data_len and calldata_len should be 3
-	if calldata[4].Int64() != 3 {
-		t.Errorf("Expected data_len=3, got %d", calldata[4].Int64())
-	}
-	if calldata[5].Int64() != 3 {
-		t.Errorf("Expected calldata_len=3, got %d", calldata[5].Int64())
-	}
-
-	// amount.low should be 1000000 (fits in 128 bits)
-	if calldata[7].Int64() != 1000000 {
-		t.Errorf("Expected amount.low=1000000, got %d", calldata[7].Int64())
+	// n_calls, then (to, selector, calldata_len, calldata...) per call.
+	want := []int64{2, 0x10, 0x20, 2, 1, 2, 0x30, 0x40, 0}
+	if len(calldata) != len(want) {
+		t.Fatalf("Expected %d calldata elements, got %d", len(want), len(calldata))
 	}
-
-	// amount.high should be 0
-	if calldata[8].Int64() != 0 {
-		t.Errorf("Expected amount.high=0, got %d", calldata[8].Int64())
+	for i, w := range want {
+		if calldata[i].Int64() != w {
+			t.Errorf("calldata[%d] = %d, want %d", i, calldata[i].Int64(), w)
+		}
 	}
 }
 
@@ -192,12 +180,315 @@ func TestTransfer_WithMockServer(t *testing.T) {
 	}
 }
 
-func TestTransferERC20_NotImplemented(t *testing.T) {
-	client := privy.NewClient("test-app-id", "test-app-secret")
-	h := NewHelper(client)
+func TestBalance(t *testing.T) {
+	rpcServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonRPCRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Method != "starknet_call" {
+			http.Error(w, "unknown method", http.StatusBadRequest)
+			return
+		}
+		// uint256 with low = 5, high = 1.
+		json.NewEncoder(w).Encode(jsonRPCResponse{JSONRPC: "2.0", ID: 1, Result: json.RawMessage(`["0x5","0x1"]`)})
+	}))
+	defer rpcServer.Close()
+
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(rpcServer.URL))
+	balance, err := h.Balance(context.Background(), "0x1234")
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	want := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(5))
+	if balance != want.String() {
+		t.Errorf("Expected %s, got %s", want, balance)
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"))
 
-	_, err := h.TransferERC20(context.Background(), "wallet-123", "0xtoken", "0xdest", "1000")
-	if err == nil {
-		t.Error("Expected error for unimplemented TransferERC20")
+	valid := []string{"0x1", "0x049d36570d4e46f48e99674bd3fcc84644ddd6b96f7c741b1562b82f9e004dc7"}
+	for _, addr := range valid {
+		if err := h.ValidateAddress(addr); err != nil {
+			t.Errorf("ValidateAddress(%q) = %v", addr, err)
+		}
+	}
+	invalid := []string{"", "0x", "1234", "0xzz", "0x" + strings.Repeat("f", 64)}
+	for _, addr := range invalid {
+		if err := h.ValidateAddress(addr); !errors.Is(err, chains.ErrInvalidAddress) {
+			t.Errorf("ValidateAddress(%q) = %v, want ErrInvalidAddress", addr, err)
+		}
+	}
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	polls := 0
+	rpcServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []string `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		resp := jsonRPCResponse{JSONRPC: "2.0", ID: 1}
+		switch req.Params[0] {
+		case "0xreverted":
+			resp.Result = json.RawMessage(`{"execution_status":"REVERTED","finality_status":"ACCEPTED_ON_L2","revert_reason":"insufficient balance"}`)
+		default:
+			polls++
+			if polls == 1 {
+				resp.Error = &jsonRPCError{Code: errTxNotFound, Message: "Transaction hash not found"}
+			} else {
+				resp.Result = json.RawMessage(`{"execution_status":"SUCCEEDED","finality_status":"ACCEPTED_ON_L2"}`)
+			}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer rpcServer.Close()
+
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"),
+		WithRPCURL(rpcServer.URL),
+		WithPollInterval(time.Millisecond),
+	)
+
+	if err := h.WaitForConfirmation(context.Background(), "0xabc123"); err != nil {
+		t.Fatalf("WaitForConfirmation failed: %v", err)
+	}
+	if polls != 2 {
+		t.Errorf("Expected 2 polls, got %d", polls)
+	}
+	if err := h.WaitForConfirmation(context.Background(), "0xreverted"); !errors.Is(err, chains.ErrTransactionFailed) {
+		t.Errorf("Expected ErrTransactionFailed, got %v", err)
+	}
+}
+
+// starknetNode is a mock StarkNet JSON-RPC node. It records the
+// transactions passed to starknet_estimateFee and
+// starknet_addInvokeTransaction, and the calls passed to starknet_call.
+type starknetNode struct {
+	*httptest.Server
+	estimated []map[string]any
+	submitted []map[string]any
+	called    []map[string]any
+	deployed  map[string]bool // by address, set by DEPLOY_ACCOUNT
+}
+
+func newStarknetNode(t *testing.T) *starknetNode {
+	t.Helper()
+	n := &starknetNode{deployed: map[string]bool{}}
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		resp := jsonRPCResponse{JSONRPC: "2.0", ID: 1}
+		switch req.Method {
+		case "starknet_getNonce":
+			resp.Result = json.RawMessage(`"0x5"`)
+		case "starknet_estimateFee":
+			var txs []map[string]any
+			json.Unmarshal(req.Params[0], &txs)
+			n.estimated = append(n.estimated, txs...)
+			resp.Result = json.RawMessage(`[{
+				"l1_gas_consumed": "0x0", "l1_gas_price": "0x2540be400",
+				"l2_gas_consumed": "0xf4240", "l2_gas_price": "0x1dcd65000",
+				"l1_data_gas_consumed": "0x80", "l1_data_gas_price": "0x3e8",
+				"overall_fee": "0x1c6bf52635f400", "unit": "FRI"
+			}]`)
+		case "starknet_addInvokeTransaction":
+			var tx map[string]any
+			json.Unmarshal(req.Params[0], &tx)
+			n.submitted = append(n.submitted, tx)
+			resp.Result = json.RawMessage(`{"transaction_hash":"0xabc123"}`)
+		case "starknet_addDeployAccountTransaction":
+			var tx map[string]any
+			json.Unmarshal(req.Params[0], &tx)
+			n.submitted = append(n.submitted, tx)
+			addr := "0x" + contractAddress(
+				hexToBigInt(tx["class_hash"].(string)),
+				hexToBigInt(tx["contract_address_salt"].(string)),
+				hexFelts(tx["constructor_calldata"]),
+			).Text(16)
+			n.deployed[addr] = true
+			resp.Result = json.RawMessage(`{"transaction_hash":"0xdef456","contract_address":"` + addr + `"}`)
+		case "starknet_getClassHashAt":
+			var addr string
+			json.Unmarshal(req.Params[1], &addr)
+			if n.deployed[addr] {
+				resp.Result = json.RawMessage(`"0x1"`)
+			} else {
+				resp.Error = &jsonRPCError{Code: errContractNotFound, Message: "Contract not found"}
+			}
+		case "starknet_getTransactionReceipt":
+			resp.Result = json.RawMessage(`{"execution_status":"SUCCEEDED","finality_status":"ACCEPTED_ON_L2"}`)
+		case "starknet_call":
+			var call map[string]any
+			json.Unmarshal(req.Params[0], &call)
+			n.called = append(n.called, call)
+			resp.Result = json.RawMessage(`["0x5","0x1"]`)
+		default:
+			resp.Error = &jsonRPCError{Code: -32601, Message: "method not found"}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(n.Close)
+	return n
+}
+
+// hexFelts converts a JSON array of hex felts.
+func hexFelts(v any) []*big.Int {
+	var felts []*big.Int
+	for _, f := range v.([]any) {
+		felts = append(felts, hexToBigInt(f.(string)))
+	}
+	return felts
+}
+
+// resourceBound reads a resource bound from a JSON-RPC transaction.
+func resourceBound(t *testing.T, tx map[string]any, name string) ResourceBound {
+	t.Helper()
+	bounds, _ := tx["resource_bounds"].(map[string]any)
+	b, ok := bounds[name].(map[string]any)
+	if !ok {
+		t.Fatalf("Missing resource bound %s in %v", name, tx)
+	}
+	return ResourceBound{
+		MaxAmount:       hexToBigInt(b["max_amount"].(string)).Uint64(),
+		MaxPricePerUnit: hexToBigInt(b["max_price_per_unit"].(string)),
+	}
+}
+
+func TestTransfer_InvokeV3(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStarknet})
+
+	node := newStarknetNode(t)
+	h := NewHelper(client, WithRPCURL(node.URL), WithTip(10), WithDataAvailabilityModes(DAModeL1, DAModeL2))
+
+	destination := "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+	txHash, err := h.Transfer(context.Background(), wallet.ID, destination, "1000000000000000000")
+	if err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+	if txHash != "0xabc123" {
+		t.Errorf("Expected tx hash 0xabc123, got %s", txHash)
+	}
+
+	if len(node.estimated) != 1 || len(node.submitted) != 1 {
+		t.Fatalf("Expected one estimate and one submission, got %d and %d", len(node.estimated), len(node.submitted))
+	}
+	tx := node.submitted[0]
+	if tx["version"] != "0x3" || tx["tip"] != "0xa" || tx["nonce"] != "0x5" {
+		t.Errorf("Unexpected transaction %v", tx)
+	}
+	if tx["nonce_data_availability_mode"] != "L1" || tx["fee_data_availability_mode"] != "L2" {
+		t.Errorf("Unexpected DA modes %v, %v", tx["nonce_data_availability_mode"], tx["fee_data_availability_mode"])
+	}
+	if _, ok := tx["max_fee"]; ok {
+		t.Error("INVOKE v3 must not carry max_fee")
+	}
+
+	// Bounds are the estimate plus 50%.
+	wantBounds := map[string]ResourceBound{
+		"l1_gas":      {MaxAmount: 0, MaxPricePerUnit: big.NewInt(15_000_000_000)},
+		"l2_gas":      {MaxAmount: 1_500_000, MaxPricePerUnit: big.NewInt(12_000_000_000)},
+		"l1_data_gas": {MaxAmount: 192, MaxPricePerUnit: big.NewInt(1500)},
+	}
+	for name, want := range wantBounds {
+		got := resourceBound(t, tx, name)
+		if got.MaxAmount != want.MaxAmount || got.MaxPricePerUnit.Cmp(want.MaxPricePerUnit) != 0 {
+			t.Errorf("%s = %d @ %s, want %d @ %s", name, got.MaxAmount, got.MaxPricePerUnit, want.MaxAmount, want.MaxPricePerUnit)
+		}
+	}
+
+	// The signed hash covers the submitted fields.
+	calldata := hexFelts(tx["calldata"])
+	want := &invokeV3{
+		v3Fee: v3Fee{
+			tip:         10,
+			nonceDAMode: DAModeL1,
+			feeDAMode:   DAModeL2,
+			bounds: ResourceBounds{
+				L1Gas:     resourceBound(t, tx, "l1_gas"),
+				L2Gas:     resourceBound(t, tx, "l2_gas"),
+				L1DataGas: resourceBound(t, tx, "l1_data_gas"),
+			},
+		},
+		sender:   hexToBigInt(wallet.Address),
+		calldata: calldata,
+		nonce:    big.NewInt(5),
+	}
+	signs := srv.RequestsMatching(privytest.Route("POST", "/v1/wallets/*/raw_sign"))
+	if len(signs) != 1 {
+		t.Fatalf("Expected one raw_sign request, got %d", len(signs))
+	}
+	var signReq struct {
+		Params struct {
+			Hash string `json:"hash"`
+		} `json:"params"`
+	}
+	json.Unmarshal(signs[0].Body, &signReq)
+	if got := hexToBigInt(signReq.Params.Hash); got.Cmp(want.hash(mainnetChainID)) != 0 {
+		t.Errorf("Signed hash 0x%x does not match the submitted transaction", got)
+	}
+	if sig, _ := tx["signature"].([]any); len(sig) != 2 {
+		t.Errorf("Expected an (r, s) signature, got %v", tx["signature"])
+	}
+}
+
+func TestTransfer_FixedResourceBounds(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStarknet})
+
+	node := newStarknetNode(t)
+	bounds := ResourceBounds{
+		L1Gas:     ResourceBound{MaxAmount: 0, MaxPricePerUnit: big.NewInt(1)},
+		L2Gas:     ResourceBound{MaxAmount: 2_000_000, MaxPricePerUnit: big.NewInt(9_000_000_000)},
+		L1DataGas: ResourceBound{MaxAmount: 256, MaxPricePerUnit: big.NewInt(2000)},
+	}
+	h := NewHelper(client, WithRPCURL(node.URL), WithResourceBounds(bounds), WithPaymasterData(big.NewInt(7)))
+
+	if _, err := h.Transfer(context.Background(), wallet.ID, "0x1234", "1"); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+	if len(node.estimated) != 0 {
+		t.Error("Expected no fee estimate with fixed resource bounds")
+	}
+	tx := node.submitted[0]
+	if got := resourceBound(t, tx, "l2_gas"); got.MaxAmount != 2_000_000 || got.MaxPricePerUnit.Int64() != 9_000_000_000 {
+		t.Errorf("Unexpected l2_gas bound %+v", got)
+	}
+	if pd, _ := tx["paymaster_data"].([]any); len(pd) != 1 || pd[0] != "0x7" {
+		t.Errorf("Unexpected paymaster data %v", tx["paymaster_data"])
+	}
+}
+
+func TestEstimateFee_InvokeV3(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStarknet})
+
+	node := newStarknetNode(t)
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	fee, err := h.EstimateFee(context.Background(), wallet.ID, "0x1234", "1")
+	if err != nil {
+		t.Fatalf("EstimateFee failed: %v", err)
+	}
+	if fee != "8000000000128000" {
+		t.Errorf("Expected fee 8000000000128000 fri, got %s", fee)
+	}
+	if len(node.estimated) != 1 || node.estimated[0]["version"] != "0x3" {
+		t.Errorf("Expected an INVOKE v3 estimate, got %v", node.estimated)
+	}
+
+	// WithMaxFee falls back to INVOKE V1.
+	h = NewHelper(client, WithRPCURL(node.URL), WithMaxFee(big.NewInt(1e15)))
+	if _, err := h.EstimateFee(context.Background(), wallet.ID, "0x1234", "1"); err != nil {
+		t.Fatalf("EstimateFee failed: %v", err)
+	}
+	if v1 := node.estimated[1]; v1["version"] != "0x1" || v1["max_fee"] != "0x38d7ea4c68000" {
+		t.Errorf("Expected an INVOKE V1 estimate, got %v", v1)
 	}
 }
@@ -0,0 +1,249 @@
+package stellar
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/keypair"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/txnbuild"
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+// NewAsset returns the asset with the given code and issuer: a
+// credit_alphanum4 asset for codes of up to 4 characters, credit_alphanum12
+// for up to 12. An empty code and issuer, or the code "XLM" with no issuer,
+// is the native asset.
+func NewAsset(code, issuer string) (txnbuild.Asset, error) {
+	if issuer == "" && (code == "" || code == "XLM") {
+		return txnbuild.NativeAsset{}, nil
+	}
+	asset := txnbuild.CreditAsset{Code: code, Issuer: issuer}
+	if _, err := asset.GetType(); err != nil {
+		return nil, fmt.Errorf("stellar: invalid asset code %q: %w", code, err)
+	}
+	if _, err := keypair.ParseAddress(issuer); err != nil {
+		return nil, fmt.Errorf("stellar: invalid asset issuer %q: %w", issuer, err)
+	}
+	return asset, nil
+}
+
+// PaymentWithAsset sends amount of the asset identified by assetCode and
+// assetIssuer from a Privy wallet to destination, which must hold a
+// trustline for it. amount is a decimal string in units of the asset.
+// Returns the transaction hash.
+func (h *Helper) PaymentWithAsset(ctx context.Context, walletID string, destination string, amount string, assetCode string, assetIssuer string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "stellar.payment_with_asset", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	asset, err := NewAsset(assetCode, assetIssuer)
+	if err != nil {
+		return "", err
+	}
+	return h.submit(ctx, walletID, []txnbuild.Operation{&txnbuild.Payment{
+		Destination: destination,
+		Amount:      amount,
+		Asset:       asset,
+	}})
+}
+
+// ChangeTrust creates or updates the Privy wallet's trustline to an asset,
+// which it needs before it can hold the asset. An empty limit trusts the
+// maximum amount; a limit of "0" removes the trustline, which requires a
+// zero balance.
+// Returns the transaction hash.
+func (h *Helper) ChangeTrust(ctx context.Context, walletID string, assetCode string, assetIssuer string, limit string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "stellar.change_trust", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	asset, err := NewAsset(assetCode, assetIssuer)
+	if err != nil {
+		return "", err
+	}
+	if asset.IsNative() {
+		return "", fmt.Errorf("stellar: cannot change trust in the native asset")
+	}
+	line, err := asset.ToChangeTrustAsset()
+	if err != nil {
+		return "", fmt.Errorf("stellar: invalid asset: %w", err)
+	}
+	return h.submit(ctx, walletID, []txnbuild.Operation{&txnbuild.ChangeTrust{Line: line, Limit: limit}})
+}
+
+// RemoveTrustline removes the Privy wallet's trustline to an asset. It is
+// ChangeTrust with a limit of "0".
+func (h *Helper) RemoveTrustline(ctx context.Context, walletID string, assetCode string, assetIssuer string) (string, error) {
+	return h.ChangeTrust(ctx, walletID, assetCode, assetIssuer, "0")
+}
+
+// CreateAccount creates and funds the account destination, which must not
+// exist yet, with startingBalance XLM from a Privy wallet. The balance must
+// cover the base reserve (1 XLM).
+// Returns the transaction hash.
+func (h *Helper) CreateAccount(ctx context.Context, walletID string, destination string, startingBalance string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "stellar.create_account", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	return h.submit(ctx, walletID, []txnbuild.Operation{&txnbuild.CreateAccount{
+		Destination: destination,
+		Amount:      startingBalance,
+	}})
+}
+
+// PathPaymentStrictSend sends exactly sendAmount of sendAsset from a Privy
+// wallet, converted through the DEX so that destination receives at least
+// destMin of destAsset. The conversion path is the one Horizon reports as
+// delivering the most.
+// Returns the transaction hash.
+func (h *Helper) PathPaymentStrictSend(ctx context.Context, walletID string, destination string, sendAsset txnbuild.Asset, sendAmount string, destAsset txnbuild.Asset, destMin string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "stellar.path_payment_strict_send", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	finder, ok := h.horizonClient.(interface {
+		StrictSendPaths(horizonclient.StrictSendPathsRequest) (hProtocol.PathsPage, error)
+	})
+	if !ok {
+		return "", fmt.Errorf("stellar: horizon client does not support strict send path discovery")
+	}
+	sendType, sendCode, sendIssuer := horizonAsset(sendAsset)
+	_, pathSpan := h.client.StartSpan(ctx, "stellar.find_path")
+	page, err := finder.StrictSendPaths(horizonclient.StrictSendPathsRequest{
+		SourceAssetType:   sendType,
+		SourceAssetCode:   sendCode,
+		SourceAssetIssuer: sendIssuer,
+		SourceAmount:      sendAmount,
+		DestinationAssets: assetString(destAsset),
+	})
+	pathSpan.End(err)
+	if err != nil {
+		return "", fmt.Errorf("stellar: find path: %w", err)
+	}
+	best, err := bestPath(page.Embedded.Records, func(p hProtocol.Path) string { return p.DestinationAmount }, true)
+	if err != nil {
+		return "", err
+	}
+	if less(best.DestinationAmount, destMin) {
+		return "", fmt.Errorf("stellar: best path delivers %s, less than the minimum %s", best.DestinationAmount, destMin)
+	}
+	path, err := pathAssets(best.Path)
+	if err != nil {
+		return "", err
+	}
+
+	return h.submit(ctx, walletID, []txnbuild.Operation{&txnbuild.PathPaymentStrictSend{
+		SendAsset:   sendAsset,
+		SendAmount:  sendAmount,
+		Destination: destination,
+		DestAsset:   destAsset,
+		DestMin:     destMin,
+		Path:        path,
+	}})
+}
+
+// PathPaymentStrictReceive delivers exactly destAmount of destAsset to
+// destination, paid from a Privy wallet in sendAsset converted through the
+// DEX, spending at most sendMax. The conversion path is the one Horizon
+// reports as costing the least.
+// Returns the transaction hash.
+func (h *Helper) PathPaymentStrictReceive(ctx context.Context, walletID string, destination string, sendAsset txnbuild.Asset, sendMax string, destAsset txnbuild.Asset, destAmount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "stellar.path_payment_strict_receive", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	destType, destCode, destIssuer := horizonAsset(destAsset)
+	_, pathSpan := h.client.StartSpan(ctx, "stellar.find_path")
+	page, err := h.horizonClient.Paths(horizonclient.PathsRequest{
+		DestinationAssetType:   destType,
+		DestinationAssetCode:   destCode,
+		DestinationAssetIssuer: destIssuer,
+		DestinationAmount:      destAmount,
+		SourceAssets:           assetString(sendAsset),
+	})
+	pathSpan.End(err)
+	if err != nil {
+		return "", fmt.Errorf("stellar: find path: %w", err)
+	}
+	best, err := bestPath(page.Embedded.Records, func(p hProtocol.Path) string { return p.SourceAmount }, false)
+	if err != nil {
+		return "", err
+	}
+	if less(sendMax, best.SourceAmount) {
+		return "", fmt.Errorf("stellar: best path costs %s, more than the maximum %s", best.SourceAmount, sendMax)
+	}
+	path, err := pathAssets(best.Path)
+	if err != nil {
+		return "", err
+	}
+
+	return h.submit(ctx, walletID, []txnbuild.Operation{&txnbuild.PathPaymentStrictReceive{
+		SendAsset:   sendAsset,
+		SendMax:     sendMax,
+		Destination: destination,
+		DestAsset:   destAsset,
+		DestAmount:  destAmount,
+		Path:        path,
+	}})
+}
+
+// bestPath returns the path with the highest (or lowest) amount.
+func bestPath(paths []hProtocol.Path, amount func(hProtocol.Path) string, highest bool) (hProtocol.Path, error) {
+	if len(paths) == 0 {
+		return hProtocol.Path{}, fmt.Errorf("stellar: no payment path found")
+	}
+	best := paths[0]
+	for _, p := range paths[1:] {
+		if less(amount(best), amount(p)) == highest {
+			best = p
+		}
+	}
+	return best, nil
+}
+
+// less reports whether decimal amount a is smaller than b. Unparsable
+// amounts compare as zero.
+func less(a, b string) bool {
+	x, _ := new(big.Rat).SetString(a)
+	y, _ := new(big.Rat).SetString(b)
+	if x == nil {
+		x = new(big.Rat)
+	}
+	if y == nil {
+		y = new(big.Rat)
+	}
+	return x.Cmp(y) < 0
+}
+
+// pathAssets converts the intermediate assets of a Horizon path.
+func pathAssets(assets []hProtocol.Asset) ([]txnbuild.Asset, error) {
+	path := make([]txnbuild.Asset, 0, len(assets))
+	for _, a := range assets {
+		asset, err := NewAsset(a.Code, a.Issuer)
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, asset)
+	}
+	return path, nil
+}
+
+// horizonAsset returns the asset type, code and issuer Horizon's path
+// requests take.
+func horizonAsset(a txnbuild.Asset) (horizonclient.AssetType, string, string) {
+	if a.IsNative() {
+		return horizonclient.AssetTypeNative, "", ""
+	}
+	if len(a.GetCode()) > 4 {
+		return horizonclient.AssetType12, a.GetCode(), a.GetIssuer()
+	}
+	return horizonclient.AssetType4, a.GetCode(), a.GetIssuer()
+}
+
+// assetString formats an asset as Horizon's asset list parameters expect:
+// "native" or "CODE:ISSUER".
+func assetString(a txnbuild.Asset) string {
+	if a.IsNative() {
+		return "native"
+	}
+	return a.GetCode() + ":" + a.GetIssuer()
+}
@@ -3,28 +3,40 @@
 //
 // Transactions are built using txnbuild, hashed, signed via Privy,
 // and submitted to a Horizon server.
+//
+// Like every chains.Chain, Transfer, TransferWithMemo, Balance and
+// EstimateFee use the smallest unit: stroops (1 XLM = 10_000_000 stroops).
+// Methods that take txnbuild amounts, such as PaymentWithAsset and Submit,
+// use Stellar's decimal amounts (e.g. "12.5").
 package stellar
 
 import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/stellar/go/amount"
 	"github.com/stellar/go/clients/horizonclient"
 	"github.com/stellar/go/keypair"
 	"github.com/stellar/go/network"
 	"github.com/stellar/go/txnbuild"
 	"github.com/stellar/go/xdr"
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
 )
 
+var _ chains.Chain = (*Helper)(nil)
+
 // Helper provides high-level Stellar transaction methods using Privy wallets.
 type Helper struct {
 	client        *privy.Client
 	horizonURL    string
 	networkPass   string
 	horizonClient horizonclient.ClientInterface
+	pollInterval  time.Duration
 }
 
 // Option configures the Helper.
@@ -59,13 +71,19 @@ func WithHorizonClient(c horizonclient.ClientInterface) Option {
 	return func(h *Helper) { h.horizonClient = c }
 }
 
+// WithPollInterval sets how often WaitForConfirmation polls Horizon.
+func WithPollInterval(d time.Duration) Option {
+	return func(h *Helper) { h.pollInterval = d }
+}
+
 // NewHelper creates a new Stellar helper.
 // Options are applied in order: testnet defaults, client-level chain options, then direct options.
 func NewHelper(client *privy.Client, opts ...Option) *Helper {
 	h := &Helper{
-		client:      client,
-		horizonURL:  "https://horizon.stellar.org",
-		networkPass: network.PublicNetworkPassphrase,
+		client:       client,
+		horizonURL:   "https://horizon.stellar.org",
+		networkPass:  network.PublicNetworkPassphrase,
+		pollInterval: chains.DefaultPollInterval,
 	}
 	h.horizonClient = &horizonclient.Client{HorizonURL: h.horizonURL}
 
@@ -84,9 +102,66 @@ func NewHelper(client *privy.Client, opts ...Option) *Helper {
 }
 
 // Transfer sends native XLM from a Privy wallet to a destination address.
-// amount is in XLM as a decimal string (e.g. "100.50").
+// amount is in stroops as a decimal string (e.g. "1005000000" for 100.5
+// XLM). The destination account must exist; use CreateAccount to fund a
+// new one.
+// Returns the transaction hash.
+func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "stellar.transfer", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	xlm, err := stroopsToXLM(amount)
+	if err != nil {
+		return "", err
+	}
+	return h.submit(ctx, walletID, []txnbuild.Operation{&txnbuild.Payment{
+		Destination: destination,
+		Amount:      xlm,
+		Asset:       txnbuild.NativeAsset{},
+	}})
+}
+
+// TransferWithMemo sends native XLM like Transfer, attaching a text memo
+// (up to 28 bytes) as exchanges require for deposits. amount is in stroops.
 // Returns the transaction hash.
-func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (string, error) {
+func (h *Helper) TransferWithMemo(ctx context.Context, walletID string, destination string, amount string, memo string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "stellar.transfer", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	xlm, err := stroopsToXLM(amount)
+	if err != nil {
+		return "", err
+	}
+	return h.submit(ctx, walletID, []txnbuild.Operation{&txnbuild.Payment{
+		Destination: destination,
+		Amount:      xlm,
+		Asset:       txnbuild.NativeAsset{},
+	}}, WithMemoText(memo))
+}
+
+// Submit builds a transaction with ops from the Privy wallet's account,
+// signs it via Privy and submits it to Horizon. Operations without a
+// source account act on the wallet. By default the transaction bids the
+// minimum base fee per operation, has no memo and is valid for 5 minutes.
+// Returns the transaction hash, which is the fee-bump hash when WithFeeBump
+// is used.
+func (h *Helper) Submit(ctx context.Context, walletID string, ops []txnbuild.Operation, opts ...TxOption) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "stellar.submit", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	return h.submit(ctx, walletID, ops, opts...)
+}
+
+// submit implements Submit.
+func (h *Helper) submit(ctx context.Context, walletID string, ops []txnbuild.Operation, opts ...TxOption) (string, error) {
+	cfg := txConfig{
+		baseFee:    txnbuild.MinBaseFee,
+		timeBounds: txnbuild.NewTimeout(300),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	// Get wallet info from Privy
 	wallet, err := h.client.Wallets().Get(ctx, walletID)
 	if err != nil {
@@ -95,27 +170,23 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 
 	// Load source account from Horizon
 	accountReq := horizonclient.AccountRequest{AccountID: wallet.Address}
+	_, loadSpan := h.client.StartSpan(ctx, "stellar.load_account")
 	sourceAccount, err := h.horizonClient.AccountDetail(accountReq)
+	loadSpan.End(err)
 	if err != nil {
 		return "", fmt.Errorf("stellar: load account: %w", err)
 	}
 
-	// Build payment operation
-	paymentOp := &txnbuild.Payment{
-		Destination: destination,
-		Amount:      amount,
-		Asset:       txnbuild.NativeAsset{},
-	}
-
 	// Build transaction
 	tx, err := txnbuild.NewTransaction(
 		txnbuild.TransactionParams{
 			SourceAccount:        &sourceAccount,
 			IncrementSequenceNum: true,
-			Operations:           []txnbuild.Operation{paymentOp},
-			BaseFee:              txnbuild.MinBaseFee,
+			Operations:           ops,
+			BaseFee:              cfg.baseFee,
+			Memo:                 cfg.memo,
 			Preconditions: txnbuild.Preconditions{
-				TimeBounds: txnbuild.NewTimeout(300),
+				TimeBounds: cfg.timeBounds,
 			},
 		},
 	)
@@ -123,56 +194,176 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 		return "", fmt.Errorf("stellar: build transaction: %w", err)
 	}
 
-	// Hash for signing
 	txHash, err := tx.Hash(h.networkPass)
 	if err != nil {
 		return "", fmt.Errorf("stellar: hash transaction: %w", err)
 	}
+	sig, err := h.sign(ctx, wallet, txHash)
+	if err != nil {
+		return "", err
+	}
+	signedTx, err := tx.AddSignatureDecorated(sig)
+	if err != nil {
+		return "", fmt.Errorf("stellar: add signature: %w", err)
+	}
+
+	if cfg.feePayerWalletID != "" {
+		return h.submitFeeBump(ctx, signedTx, cfg)
+	}
+
+	// Submit to Horizon
+	_, submitSpan := h.client.StartSpan(ctx, "stellar.broadcast")
+	resp, err := h.horizonClient.SubmitTransaction(signedTx)
+	submitSpan.End(err)
+	if err != nil {
+		return "", fmt.Errorf("stellar: submit transaction: %w", err)
+	}
+
+	return resp.Hash, nil
+}
+
+// submitFeeBump wraps the signed inner transaction in a fee-bump
+// transaction paid and signed by the fee payer wallet, and submits it.
+func (h *Helper) submitFeeBump(ctx context.Context, inner *txnbuild.Transaction, cfg txConfig) (string, error) {
+	payer, err := h.client.Wallets().Get(ctx, cfg.feePayerWalletID)
+	if err != nil {
+		return "", fmt.Errorf("stellar: get fee payer wallet: %w", err)
+	}
+
+	baseFee := cfg.feeBumpBaseFee
+	if baseFee == 0 {
+		baseFee = cfg.baseFee
+	}
+	feeBump, err := txnbuild.NewFeeBumpTransaction(txnbuild.FeeBumpTransactionParams{
+		Inner:      inner,
+		FeeAccount: payer.Address,
+		BaseFee:    baseFee,
+	})
+	if err != nil {
+		return "", fmt.Errorf("stellar: build fee bump transaction: %w", err)
+	}
 
+	hash, err := feeBump.Hash(h.networkPass)
+	if err != nil {
+		return "", fmt.Errorf("stellar: hash fee bump transaction: %w", err)
+	}
+	sig, err := h.sign(ctx, payer, hash)
+	if err != nil {
+		return "", err
+	}
+	signed, err := feeBump.AddSignatureDecorated(sig)
+	if err != nil {
+		return "", fmt.Errorf("stellar: add fee bump signature: %w", err)
+	}
+
+	_, submitSpan := h.client.StartSpan(ctx, "stellar.broadcast")
+	resp, err := h.horizonClient.SubmitFeeBumpTransaction(signed)
+	submitSpan.End(err)
+	if err != nil {
+		return "", fmt.Errorf("stellar: submit fee bump transaction: %w", err)
+	}
+	return resp.Hash, nil
+}
+
+// sign signs a transaction hash with the wallet's key via Privy raw_sign and
+// returns it as a decorated signature.
+func (h *Helper) sign(ctx context.Context, wallet *privy.Wallet, hash [32]byte) (xdr.DecoratedSignature, error) {
 	// Sign via Privy raw_sign (Ed25519 — signs the hash bytes directly)
-	hashHex := "0x" + hex.EncodeToString(txHash[:])
-	signResp, err := h.client.RawSign(ctx, walletID, hashHex)
+	hashHex := "0x" + hex.EncodeToString(hash[:])
+	signCtx, signSpan := h.client.StartSpan(ctx, "stellar.sign", privy.Attr(privy.AttrWalletID, wallet.ID))
+	signResp, err := h.client.RawSign(signCtx, wallet.ID, hashHex)
+	signSpan.End(err)
 	if err != nil {
-		return "", fmt.Errorf("stellar: sign transaction: %w", err)
+		return xdr.DecoratedSignature{}, fmt.Errorf("stellar: sign transaction: %w", err)
 	}
 
 	// Decode Ed25519 signature
 	sigHex := strings.TrimPrefix(signResp.Data.Signature, "0x")
 	sigBytes, err := hex.DecodeString(sigHex)
 	if err != nil {
-		return "", fmt.Errorf("stellar: decode signature: %w", err)
+		return xdr.DecoratedSignature{}, fmt.Errorf("stellar: decode signature: %w", err)
 	}
 
 	// Build decorated signature (hint = last 4 bytes of public key)
 	fromAddr, err := keypair.ParseAddress(wallet.Address)
 	if err != nil {
-		return "", fmt.Errorf("stellar: parse address: %w", err)
+		return xdr.DecoratedSignature{}, fmt.Errorf("stellar: parse address: %w", err)
 	}
-	hint := fromAddr.Hint()
-
-	decoratedSig := xdr.DecoratedSignature{
-		Hint:      xdr.SignatureHint(hint),
+	return xdr.DecoratedSignature{
+		Hint:      xdr.SignatureHint(fromAddr.Hint()),
 		Signature: xdr.Signature(sigBytes),
-	}
+	}, nil
+}
 
-	// Attach signature to transaction
-	signedTx, err := tx.AddSignatureDecorated(decoratedSig)
+// Balance returns the native XLM balance of address in stroops (e.g.
+// "1005000000" for 100.5 XLM). An account that has not been created yet has
+// a balance of "0".
+func (h *Helper) Balance(ctx context.Context, address string) (_ string, err error) {
+	_, span := h.client.StartSpan(ctx, "stellar.balance")
+	defer func() { span.End(err) }()
+
+	account, err := h.horizonClient.AccountDetail(horizonclient.AccountRequest{AccountID: address})
+	if horizonclient.IsNotFoundError(err) {
+		return "0", nil
+	}
 	if err != nil {
-		return "", fmt.Errorf("stellar: add signature: %w", err)
+		return "", fmt.Errorf("stellar: load account: %w", err)
 	}
-
-	// Submit to Horizon
-	resp, err := h.horizonClient.SubmitTransaction(signedTx)
+	balance, err := account.GetNativeBalance()
 	if err != nil {
-		return "", fmt.Errorf("stellar: submit transaction: %w", err)
+		return "", fmt.Errorf("stellar: native balance: %w", err)
+	}
+	stroops, err := amount.ParseInt64(balance)
+	if err != nil {
+		return "", fmt.Errorf("stellar: native balance: %w", err)
+	}
+	return strconv.FormatInt(stroops, 10), nil
+}
+
+// ValidateAddress checks that address is a G... account ID (strkey-encoded
+// Ed25519 public key).
+func (h *Helper) ValidateAddress(address string) error {
+	if _, err := keypair.ParseAddress(address); err != nil {
+		return fmt.Errorf("%w: %v", chains.ErrInvalidAddress, err)
 	}
+	return nil
+}
 
-	return resp.Hash, nil
+// EstimateFee returns the fee Transfer bids for its single payment operation,
+// in stroops. Stellar never charges more than the bid.
+func (h *Helper) EstimateFee(ctx context.Context, walletID string, destination string, amt string) (string, error) {
+	return strconv.FormatInt(txnbuild.MinBaseFee, 10), nil
 }
 
-// PaymentWithAsset sends a Stellar asset from a Privy wallet to a destination.
-func (h *Helper) PaymentWithAsset(ctx context.Context, walletID string, destination string, amount string, assetCode string, assetIssuer string) (string, error) {
-	return "", fmt.Errorf("stellar: PaymentWithAsset not yet implemented")
+// stroopsToXLM converts a positive amount in stroops to the 7-decimal XLM
+// amount txnbuild expects.
+func stroopsToXLM(stroops string) (string, error) {
+	n, err := strconv.ParseInt(stroops, 10, 64)
+	if err != nil || n <= 0 {
+		return "", fmt.Errorf("stellar: invalid amount %q: must be a positive number of stroops", stroops)
+	}
+	return amount.StringFromInt64(n), nil
+}
+
+// WaitForConfirmation polls Horizon until the transaction has been included
+// in a ledger. A failed transaction is reported as chains.ErrTransactionFailed.
+func (h *Helper) WaitForConfirmation(ctx context.Context, txHash string) (err error) {
+	ctx, span := h.client.StartSpan(ctx, "stellar.wait_for_confirmation")
+	defer func() { span.End(err) }()
+
+	return chains.Poll(ctx, h.pollInterval, func(ctx context.Context) (bool, error) {
+		tx, err := h.horizonClient.TransactionDetail(txHash)
+		if horizonclient.IsNotFoundError(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("stellar: get transaction: %w", err)
+		}
+		if !tx.Successful {
+			return true, fmt.Errorf("stellar: %w: result %s", chains.ErrTransactionFailed, tx.ResultXdr)
+		}
+		return true, nil
+	})
 }
 
 // RawSign signs a pre-computed hash using the Stellar wallet's key via Privy.
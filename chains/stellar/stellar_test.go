@@ -1,10 +1,23 @@
 package stellar
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/stellar/go/keypair"
 	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
 )
 
 func TestNewHelper(t *testing.T) {
@@ -41,12 +54,436 @@ func TestNewHelper_WithOptions(t *testing.T) {
 	}
 }
 
-func TestPaymentWithAsset_NotImplemented(t *testing.T) {
-	client := privy.NewClient("test-app-id", "test-app-secret")
-	h := NewHelper(client)
+// horizonNode is a mock Horizon server that knows every account except
+// the unfunded ones, records submitted transactions and serves canned
+// payment paths.
+type horizonNode struct {
+	*httptest.Server
+	unfunded  map[string]bool
+	submitted []*txnbuild.Transaction
+	feeBumps  []*txnbuild.FeeBumpTransaction
+	paths     []map[string]any
+	pathQuery url.Values
+}
+
+func newHorizonNode(t *testing.T) *horizonNode {
+	t.Helper()
+	n := &horizonNode{unfunded: map[string]bool{}}
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case len(parts) == 2 && parts[0] == "accounts" && !n.unfunded[parts[1]]:
+			json.NewEncoder(w).Encode(map[string]any{"id": parts[1], "account_id": parts[1], "sequence": "100", "balances": []any{
+				map[string]any{"asset_type": "native", "balance": "100.5000000"},
+			}})
+		case r.URL.Path == "/transactions" && r.Method == "POST":
+			r.ParseForm()
+			generic, err := txnbuild.TransactionFromXDR(r.PostForm.Get("tx"))
+			if err != nil {
+				t.Errorf("invalid transaction XDR: %v", err)
+			}
+			if feeBump, ok := generic.FeeBump(); ok {
+				n.feeBumps = append(n.feeBumps, feeBump)
+			} else if tx, ok := generic.Transaction(); ok {
+				n.submitted = append(n.submitted, tx)
+			}
+			json.NewEncoder(w).Encode(map[string]any{"hash": "abc123", "successful": true})
+		case strings.HasPrefix(r.URL.Path, "/paths"):
+			n.pathQuery = r.URL.Query()
+			json.NewEncoder(w).Encode(map[string]any{"_embedded": map[string]any{"records": n.paths}})
+		default:
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{"type": "https://stellar.org/horizon-errors/not_found", "title": "Resource Missing", "status": 404})
+		}
+	}))
+	t.Cleanup(n.Close)
+	return n
+}
+
+// lastOperation returns the single operation of the last submitted
+// transaction after checking its signature against wallet.
+func (n *horizonNode) lastOperation(t *testing.T, wallet *privy.Wallet) txnbuild.Operation {
+	t.Helper()
+	if len(n.submitted) == 0 {
+		t.Fatal("no transaction was submitted")
+	}
+	tx := n.submitted[len(n.submitted)-1]
+	hash, _ := tx.Hash(network.PublicNetworkPassphrase)
+	kp, _ := keypair.ParseAddress(wallet.Address)
+	if sigs := tx.Signatures(); len(sigs) != 1 || kp.Verify(hash[:], sigs[0].Signature) != nil {
+		t.Error("Transaction is not signed by the wallet")
+	}
+	if ops := tx.Operations(); len(ops) != 1 {
+		t.Fatalf("Expected one operation, got %d", len(ops))
+	}
+	return tx.Operations()[0]
+}
+
+const (
+	testIssuer      = "GAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAWHF"
+	testDestination = "GAAQCAIBAEAQCAIBAEAQCAIBAEAQCAIBAEAQCAIBAEAQCAIBAEAQDZ7H"
+)
+
+func TestNewAsset(t *testing.T) {
+	tests := []struct {
+		code, issuer string
+		native       bool
+		typ          txnbuild.AssetType
+	}{
+		{"", "", true, txnbuild.AssetTypeNative},
+		{"XLM", "", true, txnbuild.AssetTypeNative},
+		{"USDC", testIssuer, false, txnbuild.AssetTypeCreditAlphanum4},
+		{"LONGCODE", testIssuer, false, txnbuild.AssetTypeCreditAlphanum12},
+	}
+	for _, tt := range tests {
+		asset, err := NewAsset(tt.code, tt.issuer)
+		if err != nil {
+			t.Fatalf("NewAsset(%q, %q) failed: %v", tt.code, tt.issuer, err)
+		}
+		if typ, _ := asset.GetType(); asset.IsNative() != tt.native || typ != tt.typ {
+			t.Errorf("NewAsset(%q) = %v, want type %v", tt.code, asset, tt.typ)
+		}
+	}
+
+	for _, bad := range [][2]string{{"USDC", ""}, {"USDC", "GBAD"}, {"THIRTEENCHARS", testIssuer}} {
+		if _, err := NewAsset(bad[0], bad[1]); err == nil {
+			t.Errorf("Expected error for asset %v", bad)
+		}
+	}
+}
+
+func TestPaymentWithAsset(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStellar})
+
+	node := newHorizonNode(t)
+	h := NewHelper(client, WithHorizonURL(node.URL))
+
+	hash, err := h.PaymentWithAsset(context.Background(), wallet.ID, testDestination, "12.5", "USDC", testIssuer)
+	if err != nil {
+		t.Fatalf("PaymentWithAsset failed: %v", err)
+	}
+	if hash != "abc123" {
+		t.Errorf("Expected hash abc123, got %s", hash)
+	}
+
+	payment, ok := node.lastOperation(t, wallet).(*txnbuild.Payment)
+	if !ok {
+		t.Fatal("Expected a payment operation")
+	}
+	if payment.Destination != testDestination || payment.Amount != "12.5000000" ||
+		payment.Asset.GetCode() != "USDC" || payment.Asset.GetIssuer() != testIssuer {
+		t.Errorf("Unexpected payment %+v", payment)
+	}
+
+	if _, err := h.PaymentWithAsset(context.Background(), wallet.ID, testDestination, "1", "USDC", "GBAD"); err == nil {
+		t.Error("Expected error for invalid issuer")
+	}
+}
+
+func TestChangeTrust(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStellar})
+
+	node := newHorizonNode(t)
+	h := NewHelper(client, WithHorizonURL(node.URL))
+
+	if _, err := h.ChangeTrust(context.Background(), wallet.ID, "USDC", testIssuer, ""); err != nil {
+		t.Fatalf("ChangeTrust failed: %v", err)
+	}
+	trust := node.lastOperation(t, wallet).(*txnbuild.ChangeTrust)
+	if trust.Line.GetCode() != "USDC" || trust.Limit != txnbuild.MaxTrustlineLimit {
+		t.Errorf("Unexpected change trust %+v", trust)
+	}
+
+	if _, err := h.RemoveTrustline(context.Background(), wallet.ID, "USDC", testIssuer); err != nil {
+		t.Fatalf("RemoveTrustline failed: %v", err)
+	}
+	if trust := node.lastOperation(t, wallet).(*txnbuild.ChangeTrust); trust.Limit != "0.0000000" {
+		t.Errorf("Expected limit 0, got %s", trust.Limit)
+	}
+
+	if _, err := h.ChangeTrust(context.Background(), wallet.ID, "XLM", "", ""); err == nil {
+		t.Error("Expected error for a native trustline")
+	}
+}
+
+func TestCreateAccount(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStellar})
+
+	node := newHorizonNode(t)
+	h := NewHelper(client, WithHorizonURL(node.URL))
+
+	if _, err := h.CreateAccount(context.Background(), wallet.ID, testDestination, "2"); err != nil {
+		t.Fatalf("CreateAccount failed: %v", err)
+	}
+	create := node.lastOperation(t, wallet).(*txnbuild.CreateAccount)
+	if create.Destination != testDestination || create.Amount != "2.0000000" {
+		t.Errorf("Unexpected create account %+v", create)
+	}
+}
+
+func TestTransfer_Stroops(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStellar})
+
+	node := newHorizonNode(t)
+	h := NewHelper(client, WithHorizonURL(node.URL))
+
+	if _, err := h.Transfer(context.Background(), wallet.ID, testDestination, "1005000000"); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+	payment, ok := node.lastOperation(t, wallet).(*txnbuild.Payment)
+	if !ok || payment.Amount != "100.5000000" {
+		t.Errorf("Expected a payment of 100.5 XLM, got %+v", payment)
+	}
+
+	for _, amt := range []string{"100.5", "0", "-1"} {
+		if _, err := h.Transfer(context.Background(), wallet.ID, testDestination, amt); err == nil {
+			t.Errorf("Expected error for amount %q", amt)
+		}
+	}
+}
+
+func TestBalance(t *testing.T) {
+	node := newHorizonNode(t)
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithHorizonURL(node.URL))
+
+	balance, err := h.Balance(context.Background(), testDestination)
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if balance != "1005000000" {
+		t.Errorf("Expected 1005000000 stroops, got %s", balance)
+	}
+}
+
+func TestBalance_Unfunded(t *testing.T) {
+	node := newHorizonNode(t)
+	node.unfunded[testDestination] = true
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithHorizonURL(node.URL))
+
+	balance, err := h.Balance(context.Background(), testDestination)
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if balance != "0" {
+		t.Errorf("Expected 0 for an unfunded account, got %s", balance)
+	}
+}
+
+func TestPathPayments(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStellar})
+
+	node := newHorizonNode(t)
+	node.paths = []map[string]any{
+		{"source_amount": "10.0000000", "destination_amount": "9.5000000", "path": []any{}},
+		{"source_amount": "9.0000000", "destination_amount": "9.9000000", "path": []any{
+			map[string]any{"asset_type": "credit_alphanum4", "asset_code": "EURC", "asset_issuer": testIssuer},
+		}},
+	}
+	h := NewHelper(client, WithHorizonURL(node.URL))
+	usdc, _ := NewAsset("USDC", testIssuer)
+
+	// Strict send picks the path delivering the most.
+	if _, err := h.PathPaymentStrictSend(context.Background(), wallet.ID, testDestination, txnbuild.NativeAsset{}, "10", usdc, "9.8"); err != nil {
+		t.Fatalf("PathPaymentStrictSend failed: %v", err)
+	}
+	if got := node.pathQuery.Get("destination_assets"); got != "USDC:"+testIssuer {
+		t.Errorf("Unexpected destination_assets %q", got)
+	}
+	send := node.lastOperation(t, wallet).(*txnbuild.PathPaymentStrictSend)
+	if len(send.Path) != 1 || send.Path[0].GetCode() != "EURC" || send.DestMin != "9.8000000" {
+		t.Errorf("Unexpected strict send %+v", send)
+	}
+	if _, err := h.PathPaymentStrictSend(context.Background(), wallet.ID, testDestination, txnbuild.NativeAsset{}, "10", usdc, "10"); err == nil {
+		t.Error("Expected error when no path delivers the minimum")
+	}
+
+	// Strict receive picks the cheapest path.
+	if _, err := h.PathPaymentStrictReceive(context.Background(), wallet.ID, testDestination, txnbuild.NativeAsset{}, "9.5", usdc, "9"); err != nil {
+		t.Fatalf("PathPaymentStrictReceive failed: %v", err)
+	}
+	if got := node.pathQuery.Get("source_assets"); got != "native" {
+		t.Errorf("Unexpected source_assets %q", got)
+	}
+	receive := node.lastOperation(t, wallet).(*txnbuild.PathPaymentStrictReceive)
+	if len(receive.Path) != 1 || receive.SendMax != "9.5000000" {
+		t.Errorf("Unexpected strict receive %+v", receive)
+	}
+
+	node.paths = nil
+	if _, err := h.PathPaymentStrictReceive(context.Background(), wallet.ID, testDestination, txnbuild.NativeAsset{}, "9.5", usdc, "9"); err == nil {
+		t.Error("Expected error when no path exists")
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStellar})
+
+	node := newHorizonNode(t)
+	h := NewHelper(client, WithHorizonURL(node.URL))
+
+	ops := []txnbuild.Operation{
+		&txnbuild.Payment{Destination: testDestination, Amount: "1", Asset: txnbuild.NativeAsset{}},
+		&txnbuild.Payment{Destination: testIssuer, Amount: "2", Asset: txnbuild.NativeAsset{}},
+	}
+	validUntil := time.Now().Add(time.Hour).Truncate(time.Second)
+	if _, err := h.Submit(context.Background(), wallet.ID, ops,
+		WithMemoID(42),
+		WithBaseFee(500),
+		WithTimeBounds(time.Time{}, validUntil),
+	); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+
+	tx := node.submitted[0]
+	if len(tx.Operations()) != 2 {
+		t.Errorf("Expected 2 operations, got %d", len(tx.Operations()))
+	}
+	if memo, ok := tx.Memo().(txnbuild.MemoID); !ok || memo != 42 {
+		t.Errorf("Expected memo ID 42, got %v", tx.Memo())
+	}
+	if tx.BaseFee() != 500 || tx.MaxFee() != 1000 {
+		t.Errorf("Expected base fee 500 and max fee 1000, got %d and %d", tx.BaseFee(), tx.MaxFee())
+	}
+	if tb := tx.Timebounds(); tb.MinTime != 0 || tb.MaxTime != validUntil.Unix() {
+		t.Errorf("Unexpected time bounds %+v", tb)
+	}
+}
+
+func TestTransferWithMemo(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStellar})
+
+	node := newHorizonNode(t)
+	h := NewHelper(client, WithHorizonURL(node.URL))
+
+	if _, err := h.TransferWithMemo(context.Background(), wallet.ID, testDestination, "100000000", "deposit-1234"); err != nil {
+		t.Fatalf("TransferWithMemo failed: %v", err)
+	}
+	payment, ok := node.lastOperation(t, wallet).(*txnbuild.Payment)
+	if !ok || payment.Amount != "10.0000000" {
+		t.Errorf("Expected a payment of 10 XLM, got %+v", payment)
+	}
+	if memo, ok := node.submitted[0].Memo().(txnbuild.MemoText); !ok || memo != "deposit-1234" {
+		t.Errorf("Expected text memo, got %v", node.submitted[0].Memo())
+	}
+
+	if _, err := h.TransferWithMemo(context.Background(), wallet.ID, testDestination, "100000000", strings.Repeat("x", 29)); err == nil {
+		t.Error("Expected error for a memo longer than 28 bytes")
+	}
+}
+
+func TestSubmit_FeeBump(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStellar})
+	payer, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeStellar})
+
+	node := newHorizonNode(t)
+	h := NewHelper(client, WithHorizonURL(node.URL))
+
+	ops := []txnbuild.Operation{&txnbuild.Payment{Destination: testDestination, Amount: "1", Asset: txnbuild.NativeAsset{}}}
+	if _, err := h.Submit(context.Background(), wallet.ID, ops, WithFeeBump(payer.ID, 1000)); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if len(node.submitted) != 0 || len(node.feeBumps) != 1 {
+		t.Fatalf("Expected one fee-bump transaction, got %d transactions and %d fee bumps", len(node.submitted), len(node.feeBumps))
+	}
+
+	feeBump := node.feeBumps[0]
+	if feeBump.FeeAccount() != payer.Address || feeBump.BaseFee() != 1000 {
+		t.Errorf("Unexpected fee account %s or base fee %d", feeBump.FeeAccount(), feeBump.BaseFee())
+	}
+	verify := func(address string, hash [32]byte, sigs []xdr.DecoratedSignature) {
+		t.Helper()
+		kp, _ := keypair.ParseAddress(address)
+		if len(sigs) != 1 || kp.Verify(hash[:], sigs[0].Signature) != nil {
+			t.Errorf("Expected a valid signature from %s", address)
+		}
+	}
+	outerHash, _ := feeBump.Hash(network.PublicNetworkPassphrase)
+	verify(payer.Address, outerHash, feeBump.Signatures())
+	inner := feeBump.InnerTransaction()
+	innerHash, _ := inner.Hash(network.PublicNetworkPassphrase)
+	verify(wallet.Address, innerHash, inner.Signatures())
+}
+
+func TestValidateAddress(t *testing.T) {
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"))
 
-	_, err := h.PaymentWithAsset(nil, "wallet-id", "GDEST...", "100.0", "USDC", "GA5ZS...")
-	if err == nil {
-		t.Error("Expected error for unimplemented PaymentWithAsset")
+	if err := h.ValidateAddress("GAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAWHF"); err != nil {
+		t.Errorf("Expected valid address, got %v", err)
+	}
+	invalid := []string{
+		"",
+		"GAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAWHG", // bad checksum
+		"SAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA", // not an account ID
+	}
+	for _, addr := range invalid {
+		if err := h.ValidateAddress(addr); !errors.Is(err, chains.ErrInvalidAddress) {
+			t.Errorf("ValidateAddress(%q) = %v, want ErrInvalidAddress", addr, err)
+		}
+	}
+}
+
+func TestEstimateFee(t *testing.T) {
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"))
+
+	fee, err := h.EstimateFee(context.Background(), "wallet-id", "GDEST", "1")
+	if err != nil {
+		t.Fatalf("EstimateFee failed: %v", err)
+	}
+	if fee != "100" {
+		t.Errorf("Expected 100 stroops, got %s", fee)
+	}
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	polls := 0
+	horizon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/transactions/pending":
+			polls++
+			if polls == 1 {
+				w.Header().Set("Content-Type", "application/problem+json")
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]any{"type": "https://stellar.org/horizon-errors/not_found", "title": "Resource Missing", "status": 404})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"hash": "pending", "successful": true, "ledger": 100})
+		case "/transactions/failed":
+			json.NewEncoder(w).Encode(map[string]any{"hash": "failed", "successful": false, "ledger": 100, "result_xdr": "AAAAAAAAAGT/////AAAAAQAAAAAAAAAB////+wAAAAA="})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer horizon.Close()
+
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"),
+		WithHorizonURL(horizon.URL),
+		WithPollInterval(time.Millisecond),
+	)
+
+	if err := h.WaitForConfirmation(context.Background(), "pending"); err != nil {
+		t.Fatalf("WaitForConfirmation failed: %v", err)
+	}
+	if polls != 2 {
+		t.Errorf("Expected 2 polls, got %d", polls)
+	}
+	if err := h.WaitForConfirmation(context.Background(), "failed"); !errors.Is(err, chains.ErrTransactionFailed) {
+		t.Errorf("Expected ErrTransactionFailed, got %v", err)
 	}
 }
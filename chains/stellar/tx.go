@@ -0,0 +1,88 @@
+package stellar
+
+import (
+	"time"
+
+	"github.com/stellar/go/txnbuild"
+)
+
+// TxOption configures a transaction built by Submit.
+type TxOption func(*txConfig)
+
+type txConfig struct {
+	memo       txnbuild.Memo
+	baseFee    int64
+	timeBounds txnbuild.TimeBounds
+
+	// feePayerWalletID is the Privy wallet paying for a fee bump, if any.
+	feePayerWalletID string
+	feeBumpBaseFee   int64
+}
+
+// WithMemoText attaches a text memo of up to 28 bytes. An empty text
+// attaches no memo.
+func WithMemoText(text string) TxOption {
+	return func(c *txConfig) {
+		if text == "" {
+			c.memo = nil
+			return
+		}
+		c.memo = txnbuild.MemoText(text)
+	}
+}
+
+// WithMemoID attaches an ID memo.
+func WithMemoID(id uint64) TxOption {
+	return func(c *txConfig) {
+		c.memo = txnbuild.MemoID(id)
+	}
+}
+
+// WithMemoHash attaches a hash memo.
+func WithMemoHash(hash [32]byte) TxOption {
+	return func(c *txConfig) {
+		c.memo = txnbuild.MemoHash(hash)
+	}
+}
+
+// WithBaseFee sets the fee bid per operation in stroops. The default is
+// txnbuild.MinBaseFee (100 stroops).
+func WithBaseFee(stroops int64) TxOption {
+	return func(c *txConfig) {
+		c.baseFee = stroops
+	}
+}
+
+// WithTimeBounds makes the transaction valid only between minTime and
+// maxTime. A zero time leaves that side unbounded.
+func WithTimeBounds(minTime, maxTime time.Time) TxOption {
+	return func(c *txConfig) {
+		var lo, hi int64
+		if !minTime.IsZero() {
+			lo = minTime.Unix()
+		}
+		if !maxTime.IsZero() {
+			hi = maxTime.Unix()
+		}
+		c.timeBounds = txnbuild.NewTimebounds(lo, hi)
+	}
+}
+
+// WithTimeout makes the transaction valid for d from now. The default is
+// 5 minutes.
+func WithTimeout(d time.Duration) TxOption {
+	return func(c *txConfig) {
+		c.timeBounds = txnbuild.NewTimeout(int64(d / time.Second))
+	}
+}
+
+// WithFeeBump wraps the signed transaction in a fee-bump transaction whose
+// fee is paid by the Privy wallet feePayerWalletID, which signs it too.
+// baseFee is the fee-bump bid per operation in stroops; 0 reuses the inner
+// transaction's base fee.
+func WithFeeBump(feePayerWalletID string, baseFee int64) TxOption {
+	return func(c *txConfig) {
+		c.feePayerWalletID = feePayerWalletID
+		c.feeBumpBaseFee = baseFee
+	}
+}
@@ -0,0 +1,191 @@
+package sui
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// bcsWriter encodes values in BCS, the canonical serialization Sui signs:
+// integers little-endian, lengths and enum variants as ULEB128.
+type bcsWriter struct {
+	bytes.Buffer
+}
+
+func (w *bcsWriter) uleb128(v uint64) {
+	for v >= 0x80 {
+		w.WriteByte(byte(v) | 0x80)
+		v >>= 7
+	}
+	w.WriteByte(byte(v))
+}
+
+func (w *bcsWriter) u8(v uint8) { w.WriteByte(v) }
+
+func (w *bcsWriter) u16(v uint16) { w.Write(binary.LittleEndian.AppendUint16(nil, v)) }
+
+func (w *bcsWriter) u64(v uint64) { w.Write(binary.LittleEndian.AppendUint64(nil, v)) }
+
+func (w *bcsWriter) bool(v bool) {
+	if v {
+		w.WriteByte(1)
+	} else {
+		w.WriteByte(0)
+	}
+}
+
+// bytes writes a length-prefixed byte vector.
+func (w *bcsWriter) bytes(b []byte) {
+	w.uleb128(uint64(len(b)))
+	w.Write(b)
+}
+
+func (w *bcsWriter) str(s string) { w.bytes([]byte(s)) }
+
+// address writes a 32-byte address or object ID, which has no length
+// prefix.
+func (w *bcsWriter) address(a [32]byte) { w.Write(a[:]) }
+
+// parseAddress parses a 0x-prefixed hex address or object ID, which may be
+// shortened (e.g. "0x2").
+func parseAddress(s string) ([32]byte, error) {
+	var a [32]byte
+	digits, ok := strings.CutPrefix(s, "0x")
+	if !ok || digits == "" || len(digits) > 64 {
+		return a, fmt.Errorf("invalid address %q", s)
+	}
+	if len(digits)%2 == 1 {
+		digits = "0" + digits
+	}
+	b, err := hex.DecodeString(digits)
+	if err != nil {
+		return a, fmt.Errorf("invalid address %q", s)
+	}
+	copy(a[32-len(b):], b)
+	return a, nil
+}
+
+// normalizeAddress returns address as 0x followed by 64 hex digits, or
+// address itself if it does not parse.
+func normalizeAddress(address string) string {
+	a, err := parseAddress(address)
+	if err != nil {
+		return address
+	}
+	return "0x" + hex.EncodeToString(a[:])
+}
+
+// base58Alphabet is the Bitcoin alphabet Sui uses for digests.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// decodeBase58 decodes a base58 string such as an object digest.
+func decodeBase58(s string) ([]byte, error) {
+	n := new(big.Int)
+	for _, c := range s {
+		i := strings.IndexRune(base58Alphabet, c)
+		if i < 0 {
+			return nil, fmt.Errorf("invalid base58 %q", s)
+		}
+		n.Mul(n, big.NewInt(58))
+		n.Add(n, big.NewInt(int64(i)))
+	}
+	zeros := len(s) - len(strings.TrimLeft(s, "1"))
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// Type tag variants.
+const (
+	typeTagBool = iota
+	typeTagU8
+	typeTagU64
+	typeTagU128
+	typeTagAddress
+	typeTagSigner
+	typeTagVector
+	typeTagStruct
+	typeTagU16
+	typeTagU32
+	typeTagU256
+)
+
+var primitiveTypeTags = map[string]uint64{
+	"bool":    typeTagBool,
+	"u8":      typeTagU8,
+	"u16":     typeTagU16,
+	"u32":     typeTagU32,
+	"u64":     typeTagU64,
+	"u128":    typeTagU128,
+	"u256":    typeTagU256,
+	"address": typeTagAddress,
+	"signer":  typeTagSigner,
+}
+
+// typeTag writes a Move type such as "u64", "vector<u8>" or
+// "0x2::coin::Coin<0x2::sui::SUI>".
+func (w *bcsWriter) typeTag(t string) error {
+	t = strings.TrimSpace(t)
+	if tag, ok := primitiveTypeTags[t]; ok {
+		w.uleb128(tag)
+		return nil
+	}
+	if inner, ok := strings.CutPrefix(t, "vector<"); ok && strings.HasSuffix(inner, ">") {
+		w.uleb128(typeTagVector)
+		return w.typeTag(inner[:len(inner)-1])
+	}
+
+	name, params := t, ""
+	if i := strings.IndexByte(t, '<'); i >= 0 {
+		if !strings.HasSuffix(t, ">") {
+			return fmt.Errorf("invalid type %q", t)
+		}
+		name, params = t[:i], t[i+1:len(t)-1]
+	}
+	parts := strings.Split(name, "::")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return fmt.Errorf("invalid type %q", t)
+	}
+	addr, err := parseAddress(parts[0])
+	if err != nil {
+		return fmt.Errorf("invalid type %q: %w", t, err)
+	}
+
+	w.uleb128(typeTagStruct)
+	w.address(addr)
+	w.str(parts[1])
+	w.str(parts[2])
+	typeParams := splitTypeParams(params)
+	w.uleb128(uint64(len(typeParams)))
+	for _, p := range typeParams {
+		if err := w.typeTag(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitTypeParams splits a comma-separated list of type parameters,
+// ignoring commas nested in angle brackets.
+func splitTypeParams(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	var params []string
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case ',':
+			if depth == 0 {
+				params = append(params, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(params, s[start:])
+}
@@ -0,0 +1,100 @@
+package sui
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// encodeBase58 is the inverse of decodeBase58, for building digests in
+// tests.
+func encodeBase58(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	var out []byte
+	mod := new(big.Int)
+	for n.Sign() > 0 {
+		n.DivMod(n, big.NewInt(58), mod)
+		out = append([]byte{base58Alphabet[mod.Int64()]}, out...)
+	}
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append([]byte{'1'}, out...)
+	}
+	return string(out)
+}
+
+func TestULEB128(t *testing.T) {
+	tests := map[uint64]string{0: "00", 1: "01", 127: "7f", 128: "8001", 300: "ac02", 624485: "e58e26"}
+	for v, want := range tests {
+		var w bcsWriter
+		w.uleb128(v)
+		if got := hex.EncodeToString(w.Bytes()); got != want {
+			t.Errorf("uleb128(%d) = %s, want %s", v, got, want)
+		}
+	}
+}
+
+func TestParseAddress(t *testing.T) {
+	a, err := parseAddress("0x2")
+	if err != nil {
+		t.Fatalf("parseAddress failed: %v", err)
+	}
+	if want := strings.Repeat("00", 31) + "02"; hex.EncodeToString(a[:]) != want {
+		t.Errorf("parseAddress(0x2) = %x, want %s", a, want)
+	}
+	if got := normalizeAddress("0xABC"); got != "0x"+strings.Repeat("0", 61)+"abc" {
+		t.Errorf("normalizeAddress(0xABC) = %s", got)
+	}
+	for _, s := range []string{"", "2", "0x", "0xzz", "0x" + strings.Repeat("1", 65)} {
+		if _, err := parseAddress(s); err == nil {
+			t.Errorf("parseAddress(%q) succeeded, want error", s)
+		}
+	}
+}
+
+func TestDecodeBase58(t *testing.T) {
+	got, err := decodeBase58("StV1DL6CwTryKyV")
+	if err != nil || string(got) != "hello world" {
+		t.Errorf("decodeBase58 = %q, %v; want hello world", got, err)
+	}
+	digest := bytes.Repeat([]byte{0}, 2)
+	digest = append(digest, bytes.Repeat([]byte{0xab}, 30)...)
+	if got, _ := decodeBase58(encodeBase58(digest)); !bytes.Equal(got, digest) {
+		t.Errorf("round trip = %x, want %x", got, digest)
+	}
+	if _, err := decodeBase58("0OIl"); err == nil {
+		t.Error("Expected error for characters outside the alphabet")
+	}
+}
+
+func TestTypeTag(t *testing.T) {
+	two := strings.Repeat("00", 31) + "02"
+	tests := map[string]string{
+		"u64":           "02",
+		"vector<u8>":    "0601",
+		"0x2::sui::SUI": "07" + two + "03737569" + "03535549" + "00",
+		"0x2::coin::Coin<0x2::sui::SUI>": "07" + two + "04636f696e" + "04436f696e" + "01" +
+			"07" + two + "03737569" + "03535549" + "00",
+		"0x2::m::Pair<u8, vector<address>>": "07" + two + "016d" + "0450616972" + "02" + "01" + "0604",
+	}
+	for typ, want := range tests {
+		var w bcsWriter
+		if err := w.typeTag(typ); err != nil {
+			t.Errorf("typeTag(%q) failed: %v", typ, err)
+			continue
+		}
+		if got := hex.EncodeToString(w.Bytes()); got != want {
+			t.Errorf("typeTag(%q) = %s, want %s", typ, got, want)
+		}
+	}
+	for _, typ := range []string{"", "u7", "0x2::sui", "0x2::coin::Coin<u8", "sui::sui::SUI"} {
+		var w bcsWriter
+		if err := w.typeTag(typ); err == nil {
+			t.Errorf("typeTag(%q) succeeded, want error", typ)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package sui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+// CoinBalance is an address's total balance of one coin type.
+type CoinBalance struct {
+	CoinType        string `json:"coinType"`
+	CoinObjectCount int    `json:"coinObjectCount"`
+	TotalBalance    string `json:"totalBalance"` // in the coin's smallest unit
+}
+
+// TransferCoin sends amount of a coin type such as USDC
+// ("0xdba3...::usdc::USDC") from a Privy wallet to destination. amount is
+// in the coin's smallest unit as a decimal string. The wallet's coins of
+// that type are merged as needed and the amount split off; gas is paid in
+// SUI. Returns the transaction digest.
+func (h *Helper) TransferCoin(ctx context.Context, walletID string, coinType string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "sui.transfer_coin", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	if isSUI(coinType) {
+		tx, err := transferTx(destination, amount)
+		if err != nil {
+			return "", err
+		}
+		return h.execute(ctx, walletID, tx)
+	}
+
+	value, err := strconv.ParseUint(amount, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("sui: invalid amount %q", amount)
+	}
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("sui: get wallet: %w", err)
+	}
+	coins, err := h.coins(ctx, wallet.Address, coinType)
+	if err != nil {
+		return "", fmt.Errorf("sui: get coins: %w", err)
+	}
+	sort.SliceStable(coins, func(i, j int) bool {
+		return coins[i].balance().Cmp(coins[j].balance()) > 0
+	})
+	refs := selectCoins(coins, new(big.Int).SetUint64(value))
+	if refs == nil {
+		total := new(big.Int)
+		for _, c := range coins {
+			total.Add(total, c.balance())
+		}
+		return "", fmt.Errorf("sui: insufficient %s: have %s, need %d", coinType, total, value)
+	}
+
+	tx := NewTransactionBuilder()
+	primary := tx.Object(refs[0])
+	if len(refs) > 1 {
+		sources := make([]Argument, len(refs)-1)
+		for i, ref := range refs[1:] {
+			sources[i] = tx.Object(ref)
+		}
+		tx.MergeCoins(primary, sources...)
+	}
+	split := tx.SplitCoins(primary, tx.PureU64(value))
+	tx.TransferObjects(split, tx.PureAddress(destination))
+	if err := tx.Err(); err != nil {
+		return "", fmt.Errorf("sui: invalid destination: %w", err)
+	}
+
+	txBytes, _, err := h.build(ctx, wallet.Address, tx)
+	if err != nil {
+		return "", err
+	}
+	return h.signAndExecute(ctx, wallet, txBytes)
+}
+
+// isSUI reports whether coinType is SUI, in either short or long form.
+func isSUI(coinType string) bool {
+	addr, rest, ok := strings.Cut(coinType, "::")
+	return ok && rest == "sui::SUI" && normalizeAddress(addr) == normalizeAddress("0x2")
+}
+
+// BalanceOf returns the balance of coinType held by address, in the
+// coin's smallest unit.
+func (h *Helper) BalanceOf(ctx context.Context, address string, coinType string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "sui.balance_of")
+	defer func() { span.End(err) }()
+
+	return h.balanceOf(ctx, address, coinType)
+}
+
+// balanceOf returns the total balance of coinType via suix_getBalance.
+func (h *Helper) balanceOf(ctx context.Context, address string, coinType string) (string, error) {
+	resp, err := h.callRPC(ctx, "suix_getBalance", []any{address, coinType})
+	if err != nil {
+		return "", fmt.Errorf("sui: get balance: %w", err)
+	}
+	var result CoinBalance
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", fmt.Errorf("sui: get balance: %w", err)
+	}
+	return result.TotalBalance, nil
+}
+
+// AllBalances returns the balance of every coin type held by address.
+func (h *Helper) AllBalances(ctx context.Context, address string) (_ []CoinBalance, err error) {
+	ctx, span := h.client.StartSpan(ctx, "sui.all_balances")
+	defer func() { span.End(err) }()
+
+	resp, err := h.callRPC(ctx, "suix_getAllBalances", []any{address})
+	if err != nil {
+		return nil, fmt.Errorf("sui: get balances: %w", err)
+	}
+	var balances []CoinBalance
+	if err := json.Unmarshal(resp, &balances); err != nil {
+		return nil, fmt.Errorf("sui: get balances: %w", err)
+	}
+	return balances, nil
+}
@@ -0,0 +1,167 @@
+package sui
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
+)
+
+const usdc = "0xdba34672e30cb065b1f93e3ab55318768fd6fef66c15942c9f7cb846e2f900e7::usdc::USDC"
+
+// addTokens gives the node's wallet coins of usdc with the given balances.
+func (n *suiNode) addTokens(balances ...string) {
+	for i, b := range balances {
+		n.tokens = append(n.tokens, map[string]any{
+			"coinType":     usdc,
+			"coinObjectId": fmt.Sprintf("0x%064x", 0x100+i),
+			"version":      "20",
+			"digest":       suiDigest,
+			"balance":      b,
+		})
+	}
+}
+
+// objectInput returns the BCS owned-object input for a coin.
+func objectInput(t *testing.T, c map[string]any) []byte {
+	t.Helper()
+	id, _ := parseAddress(c["coinObjectId"].(string))
+	input := append([]byte{1, 0}, id[:]...)
+	return binary.LittleEndian.AppendUint64(input, 20)
+}
+
+func TestTransferCoin(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeSui})
+
+	node := newSuiNode(t, wallet.Address, 100_000_000)
+	node.addTokens("2000000", "5000000", "4000000", "100")
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	if _, err := h.TransferCoin(context.Background(), wallet.ID, usdc, "0x"+strings.Repeat("cd", 32), "8000000"); err != nil {
+		t.Fatalf("TransferCoin failed: %v", err)
+	}
+	txBytes := node.executed[0]
+
+	// The two largest coins cover 8 USDC: the 4 USDC coin is merged into
+	// the 5 USDC coin and the amount split off it.
+	for i, c := range node.tokens {
+		if used := bytes.Contains(txBytes, objectInput(t, c)); used != (i == 1 || i == 2) {
+			t.Errorf("coin %d used = %v", i, used)
+		}
+	}
+	merge := []byte{3, 1, 0, 0, 1, 1, 1, 0}          // MergeCoins(Input(0), [Input(1)])
+	split := []byte{2, 1, 0, 0, 1, 1, 2, 0}          // SplitCoins(Input(0), [Input(2)])
+	transfer := []byte{1, 1, 3, 1, 0, 0, 0, 1, 3, 0} // TransferObjects([NestedResult(1, 0)], Input(3))
+	if !bytes.Contains(txBytes, append(append(merge, split...), transfer...)) {
+		t.Errorf("Expected merge, split and transfer commands in %x", txBytes)
+	}
+
+	// Gas is paid in SUI.
+	ids, _, _ := gasPayment(t, txBytes, 1)
+	if ids[0] != node.coins[0]["coinObjectId"] {
+		t.Errorf("Expected gas paid with %s, got %s", node.coins[0]["coinObjectId"], ids[0])
+	}
+}
+
+func TestTransferCoin_SingleCoin(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeSui})
+
+	node := newSuiNode(t, wallet.Address, 100_000_000)
+	node.addTokens("2000000", "5000000")
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	if _, err := h.TransferCoin(context.Background(), wallet.ID, usdc, "0x"+strings.Repeat("cd", 32), "3000000"); err != nil {
+		t.Fatalf("TransferCoin failed: %v", err)
+	}
+	txBytes := node.executed[0]
+	if bytes.Contains(txBytes, objectInput(t, node.tokens[0])) || !bytes.Contains(txBytes, objectInput(t, node.tokens[1])) {
+		t.Error("Expected only the 5 USDC coin to be used")
+	}
+	if split := []byte{2, 2, 1, 0, 0, 1, 1, 1, 0}; !bytes.Contains(txBytes, split) {
+		t.Errorf("Expected SplitCoins(Input(0), [Input(1)]) as the first command in %x", txBytes)
+	}
+}
+
+func TestTransferCoin_Insufficient(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeSui})
+
+	node := newSuiNode(t, wallet.Address, 100_000_000)
+	node.addTokens("2000000", "5000000")
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	_, err := h.TransferCoin(context.Background(), wallet.ID, usdc, "0x"+strings.Repeat("cd", 32), "8000000")
+	if err == nil || !strings.Contains(err.Error(), "have 7000000, need 8000000") {
+		t.Errorf("Expected an insufficient balance error, got %v", err)
+	}
+}
+
+func TestTransferCoin_SUI(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeSui})
+
+	node := newSuiNode(t, wallet.Address, 100_000_000)
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	// SUI is split off the gas coin rather than used as an input.
+	coinType := "0x" + strings.Repeat("0", 63) + "2::sui::SUI"
+	if _, err := h.TransferCoin(context.Background(), wallet.ID, coinType, "0x"+strings.Repeat("cd", 32), "1000"); err != nil {
+		t.Fatalf("TransferCoin failed: %v", err)
+	}
+	if split := []byte{2, 0, 1, 1, 0, 0}; !bytes.Contains(node.executed[0], split) {
+		t.Errorf("Expected SplitCoins(GasCoin, [Input(0)]) in %x", node.executed[0])
+	}
+}
+
+func TestBalanceOf(t *testing.T) {
+	var coinTypes []string
+	node := newRPCServer(t, func(method string, params []json.RawMessage) (any, any) {
+		switch method {
+		case "suix_getBalance":
+			var coinType string
+			json.Unmarshal(params[1], &coinType)
+			coinTypes = append(coinTypes, coinType)
+			return map[string]any{"coinType": coinType, "coinObjectCount": 3, "totalBalance": "7000000"}, nil
+		case "suix_getAllBalances":
+			return []any{
+				map[string]any{"coinType": "0x2::sui::SUI", "coinObjectCount": 2, "totalBalance": "1500000000"},
+				map[string]any{"coinType": usdc, "coinObjectCount": 3, "totalBalance": "7000000"},
+			}, nil
+		}
+		return nil, map[string]any{"code": -32601, "message": "method not found"}
+	})
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(node.URL))
+	address := "0x" + strings.Repeat("ab", 32)
+
+	balance, err := h.BalanceOf(context.Background(), address, usdc)
+	if err != nil {
+		t.Fatalf("BalanceOf failed: %v", err)
+	}
+	if balance != "7000000" || coinTypes[0] != usdc {
+		t.Errorf("Expected 7000000 of %s, got %s of %s", usdc, balance, coinTypes[0])
+	}
+
+	balances, err := h.AllBalances(context.Background(), address)
+	if err != nil {
+		t.Fatalf("AllBalances failed: %v", err)
+	}
+	want := []CoinBalance{
+		{CoinType: "0x2::sui::SUI", CoinObjectCount: 2, TotalBalance: "1500000000"},
+		{CoinType: usdc, CoinObjectCount: 3, TotalBalance: "7000000"},
+	}
+	if len(balances) != len(want) || balances[0] != want[0] || balances[1] != want[1] {
+		t.Errorf("AllBalances = %+v, want %+v", balances, want)
+	}
+}
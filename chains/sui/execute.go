@@ -0,0 +1,370 @@
+package sui
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// suiCoinType is the coin type of SUI.
+	suiCoinType = "0x2::sui::SUI"
+
+	// maxGasPaymentObjects is the most coins a transaction can pay gas
+	// with.
+	maxGasPaymentObjects = 256
+
+	// maxGasBudget is the protocol's maximum gas budget, 50 SUI, and the
+	// budget transactions are dry-run with.
+	maxGasBudget = 50_000_000_000
+
+	// gasSafeOverhead is added to the estimated computation cost, in units
+	// of the gas price, as the reference SDK does.
+	gasSafeOverhead = 1000
+)
+
+// coin is a coin object as returned by suix_getCoins.
+type coin struct {
+	CoinType     string `json:"coinType"`
+	CoinObjectID string `json:"coinObjectId"`
+	Version      string `json:"version"`
+	Digest       string `json:"digest"`
+	Balance      string `json:"balance"`
+}
+
+// ref returns the coin's object reference.
+func (c *coin) ref() ObjectRef {
+	version, _ := strconv.ParseUint(c.Version, 10, 64)
+	return ObjectRef{ObjectID: c.CoinObjectID, Version: version, Digest: c.Digest}
+}
+
+func (c *coin) balance() *big.Int {
+	n, ok := new(big.Int).SetString(c.Balance, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return n
+}
+
+// Execute builds tx with gas paid from the wallet's SUI coins, signs it via
+// Privy and executes it. Unless WithGasBudget is set, the gas budget is
+// estimated with a dry run and only as many coins as it needs pay for gas;
+// with a fixed budget every coin does. Returns the transaction digest.
+func (h *Helper) Execute(ctx context.Context, walletID string, tx *TransactionBuilder) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "sui.execute", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	return h.execute(ctx, walletID, tx)
+}
+
+// execute builds, signs and executes tx from a Privy wallet.
+func (h *Helper) execute(ctx context.Context, walletID string, tx *TransactionBuilder) (string, error) {
+	if err := tx.Err(); err != nil {
+		return "", fmt.Errorf("sui: build transaction: %w", err)
+	}
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("sui: get wallet: %w", err)
+	}
+	txBytes, _, err := h.build(ctx, wallet.Address, tx)
+	if err != nil {
+		return "", err
+	}
+	return h.signAndExecute(ctx, wallet, txBytes)
+}
+
+// build selects gas coins and a gas budget for tx and returns the
+// BCS-encoded transaction data, with the dry run the budget was estimated
+// from, if any.
+func (h *Helper) build(ctx context.Context, sender string, tx *TransactionBuilder) ([]byte, *dryRunResult, error) {
+	if err := tx.Err(); err != nil {
+		return nil, nil, fmt.Errorf("sui: build transaction: %w", err)
+	}
+	owner, err := parseAddress(sender)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sui: invalid wallet address: %w", err)
+	}
+	price, err := h.referenceGasPrice(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sui: get gas price: %w", err)
+	}
+	coins, err := h.gasCoins(ctx, sender, tx.objectIDs())
+	if err != nil {
+		return nil, nil, fmt.Errorf("sui: get gas coins: %w", err)
+	}
+	total := new(big.Int)
+	for _, c := range coins {
+		total.Add(total, c.balance())
+	}
+
+	// Pay with every gas coin to start with. With a fixed budget that is
+	// final: without a dry run the SUI the transaction takes from GasCoin
+	// is unknown.
+	gas := gasData{payment: coinRefs(coins), owner: owner, price: price, budget: h.gasBudget}
+	if h.gasBudget != 0 {
+		if total.Cmp(new(big.Int).SetUint64(h.gasBudget)) < 0 {
+			return nil, nil, fmt.Errorf("sui: insufficient SUI for gas: have %s, need %d", total, h.gasBudget)
+		}
+		txBytes, err := tx.encode(owner, gas)
+		if err != nil {
+			return nil, nil, fmt.Errorf("sui: build transaction: %w", err)
+		}
+		return txBytes, nil, nil
+	}
+
+	// Dry-run with as large a budget as the coins allow.
+	gas.budget = maxGasBudget
+	if total.IsUint64() && total.Uint64() < maxGasBudget {
+		gas.budget = total.Uint64()
+	}
+	txBytes, err := tx.encode(owner, gas)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sui: build transaction: %w", err)
+	}
+	result, err := h.dryRun(ctx, txBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	gas.budget = result.budget(price)
+
+	// The gas coins also pay whatever the transaction takes from GasCoin,
+	// which is the SUI spent beyond the fee.
+	required := new(big.Int).SetUint64(gas.budget)
+	if spent := result.suiSpent(sender); spent.Sign() > 0 {
+		required.Add(required, spent)
+	}
+	gas.payment = selectCoins(coins, required)
+	if gas.payment == nil {
+		return nil, nil, fmt.Errorf("sui: insufficient SUI for gas: have %s, need %s", total, required)
+	}
+	if txBytes, err = tx.encode(owner, gas); err != nil {
+		return nil, nil, fmt.Errorf("sui: build transaction: %w", err)
+	}
+	return txBytes, result, nil
+}
+
+// selectCoins returns the fewest of coins, which are sorted by descending
+// balance, whose balances add up to amount, or nil if they all do not.
+func selectCoins(coins []coin, amount *big.Int) []ObjectRef {
+	sum := new(big.Int)
+	for i := range coins {
+		sum.Add(sum, coins[i].balance())
+		if sum.Cmp(amount) >= 0 {
+			return coinRefs(coins[:i+1])
+		}
+	}
+	return nil
+}
+
+func coinRefs(coins []coin) []ObjectRef {
+	refs := make([]ObjectRef, len(coins))
+	for i := range coins {
+		refs[i] = coins[i].ref()
+	}
+	return refs
+}
+
+// gasCoins returns the owner's SUI coins that are not inputs of the
+// transaction, largest first, up to the most a transaction can pay with.
+func (h *Helper) gasCoins(ctx context.Context, owner string, exclude map[string]bool) ([]coin, error) {
+	all, err := h.coins(ctx, owner, suiCoinType)
+	if err != nil {
+		return nil, err
+	}
+	var coins []coin
+	for _, c := range all {
+		if !exclude[normalizeAddress(c.CoinObjectID)] {
+			coins = append(coins, c)
+		}
+	}
+	if len(coins) == 0 {
+		return nil, fmt.Errorf("no SUI coins found for %s", owner)
+	}
+	sort.SliceStable(coins, func(i, j int) bool {
+		return coins[i].balance().Cmp(coins[j].balance()) > 0
+	})
+	if len(coins) > maxGasPaymentObjects {
+		coins = coins[:maxGasPaymentObjects]
+	}
+	return coins, nil
+}
+
+// coins returns all of owner's coins of coinType, following pagination.
+func (h *Helper) coins(ctx context.Context, owner string, coinType string) ([]coin, error) {
+	var coins []coin
+	var cursor any
+	for {
+		resp, err := h.callRPC(ctx, "suix_getCoins", []any{owner, coinType, cursor, nil})
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Data        []coin  `json:"data"`
+			NextCursor  *string `json:"nextCursor"`
+			HasNextPage bool    `json:"hasNextPage"`
+		}
+		if err := json.Unmarshal(resp, &page); err != nil {
+			return nil, err
+		}
+		coins = append(coins, page.Data...)
+		if !page.HasNextPage || page.NextCursor == nil {
+			return coins, nil
+		}
+		cursor = *page.NextCursor
+	}
+}
+
+// referenceGasPrice returns the current epoch's reference gas price in MIST.
+func (h *Helper) referenceGasPrice(ctx context.Context) (uint64, error) {
+	resp, err := h.callRPC(ctx, "suix_getReferenceGasPrice", []any{})
+	if err != nil {
+		return 0, err
+	}
+	var price json.Number
+	if err := json.Unmarshal(resp, &price); err != nil {
+		var s string
+		if err := json.Unmarshal(resp, &s); err != nil {
+			return 0, err
+		}
+		price = json.Number(s)
+	}
+	return strconv.ParseUint(price.String(), 10, 64)
+}
+
+// dryRunResult is the part of a sui_dryRunTransactionBlock result the
+// helper uses.
+type dryRunResult struct {
+	Effects struct {
+		Status struct {
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"status"`
+		GasUsed struct {
+			ComputationCost string `json:"computationCost"`
+			StorageCost     string `json:"storageCost"`
+			StorageRebate   string `json:"storageRebate"`
+		} `json:"gasUsed"`
+	} `json:"effects"`
+	BalanceChanges []struct {
+		Owner struct {
+			AddressOwner string `json:"AddressOwner"`
+		} `json:"owner"`
+		CoinType string `json:"coinType"`
+		Amount   string `json:"amount"`
+	} `json:"balanceChanges"`
+
+	computation, storage, rebate *big.Int
+}
+
+// dryRun dry-runs a transaction and fails if its execution would fail.
+func (h *Helper) dryRun(ctx context.Context, txBytes []byte) (*dryRunResult, error) {
+	resp, err := h.callRPC(ctx, "sui_dryRunTransactionBlock", []any{base64.StdEncoding.EncodeToString(txBytes)})
+	if err != nil {
+		return nil, fmt.Errorf("sui: dry run: %w", err)
+	}
+	var result dryRunResult
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("sui: dry run: %w", err)
+	}
+	if result.Effects.Status.Status != "success" {
+		return nil, fmt.Errorf("sui: dry run failed: %s", result.Effects.Status.Error)
+	}
+
+	gas := result.Effects.GasUsed
+	costs := make([]*big.Int, 3)
+	for i, v := range []string{gas.ComputationCost, gas.StorageCost, gas.StorageRebate} {
+		n, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			return nil, fmt.Errorf("sui: invalid gas cost %q", v)
+		}
+		costs[i] = n
+	}
+	result.computation, result.storage, result.rebate = costs[0], costs[1], costs[2]
+	return &result, nil
+}
+
+// fee returns the net gas fee: computation plus storage cost, less the
+// storage rebate, and at least zero.
+func (r *dryRunResult) fee() *big.Int {
+	fee := new(big.Int).Add(r.computation, r.storage)
+	fee.Sub(fee, r.rebate)
+	if fee.Sign() < 0 {
+		fee.SetInt64(0)
+	}
+	return fee
+}
+
+// budget returns the gas budget for the dry-run transaction: the
+// computation cost plus a safety overhead, plus the net storage cost if
+// positive.
+func (r *dryRunResult) budget(price uint64) uint64 {
+	budget := new(big.Int).SetUint64(gasSafeOverhead)
+	budget.Mul(budget, new(big.Int).SetUint64(price))
+	budget.Add(budget, r.computation)
+	if net := new(big.Int).Sub(r.storage, r.rebate); net.Sign() > 0 {
+		budget.Add(budget, net)
+	}
+	return budget.Uint64()
+}
+
+// suiSpent returns the SUI that owner spends in the transaction beyond the
+// gas fee.
+func (r *dryRunResult) suiSpent(owner string) *big.Int {
+	spent := new(big.Int)
+	for _, c := range r.BalanceChanges {
+		if c.CoinType != suiCoinType || normalizeAddress(c.Owner.AddressOwner) != normalizeAddress(owner) {
+			continue
+		}
+		if n, ok := new(big.Int).SetString(c.Amount, 10); ok {
+			spent.Sub(spent, n)
+		}
+	}
+	return spent.Sub(spent, r.fee())
+}
+
+// signAndExecute signs BCS-encoded transaction data via Privy and executes
+// it. Returns the transaction digest.
+func (h *Helper) signAndExecute(ctx context.Context, wallet *privy.Wallet, txBytes []byte) (string, error) {
+	// Compute digest: Blake2b-256(intent_prefix + tx_bytes)
+	// Intent prefix: [0x00, 0x00, 0x00] = TransactionData, V0, Sui
+	intentMessage := append([]byte{0x00, 0x00, 0x00}, txBytes...)
+	digest := blake2b.Sum256(intentMessage)
+
+	// Sign via Privy raw_sign
+	digestHex := "0x" + hex.EncodeToString(digest[:])
+	signCtx, signSpan := h.client.StartSpan(ctx, "sui.sign")
+	signResp, err := h.client.RawSign(signCtx, wallet.ID, digestHex)
+	signSpan.End(err)
+	if err != nil {
+		return "", fmt.Errorf("sui: sign transaction: %w", err)
+	}
+
+	// Format signature: flag (0x00 = Ed25519) + signature (64 bytes) + public_key (32 bytes)
+	sigBytes, err := decodeHex(signResp.Data.Signature)
+	if err != nil {
+		return "", fmt.Errorf("sui: decode signature: %w", err)
+	}
+	pubKeyBytes, err := decodeHex(wallet.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("sui: decode public key: %w", err)
+	}
+	serializedSig := make([]byte, 0, 1+len(sigBytes)+len(pubKeyBytes))
+	serializedSig = append(serializedSig, 0x00) // Ed25519 flag
+	serializedSig = append(serializedSig, sigBytes...)
+	serializedSig = append(serializedSig, pubKeyBytes...)
+	sigBase64 := base64.StdEncoding.EncodeToString(serializedSig)
+
+	txDigest, err := h.executeTransactionBlock(ctx, base64.StdEncoding.EncodeToString(txBytes), sigBase64)
+	if err != nil {
+		return "", fmt.Errorf("sui: execute transaction: %w", err)
+	}
+	return txDigest, nil
+}
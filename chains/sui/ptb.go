@@ -0,0 +1,312 @@
+package sui
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// Argument refers to a value in a programmable transaction block: the gas
+// coin, an input, or the result of an earlier command.
+type Argument struct {
+	kind   uint8
+	index  uint16
+	nested uint16
+}
+
+// Argument variants.
+const (
+	argGasCoin = iota
+	argInput
+	argResult
+	argNestedResult
+)
+
+// GasCoin is the coin that pays for gas. Coins split from it are paid out
+// of the sender's SUI balance.
+var GasCoin = Argument{kind: argGasCoin}
+
+// ObjectRef identifies a specific version of an owned object, as returned
+// by sui_getObject and suix_getCoins.
+type ObjectRef struct {
+	ObjectID string
+	Version  uint64
+	Digest   string // base58
+}
+
+// Call arguments.
+const (
+	callArgPure = iota
+	callArgObject
+)
+
+// Object arguments.
+const (
+	objectArgOwned = iota
+	objectArgShared
+)
+
+// Commands.
+const (
+	commandMoveCall = iota
+	commandTransferObjects
+	commandSplitCoins
+	commandMergeCoins
+)
+
+// TransactionBuilder builds a programmable transaction block (PTB): a list
+// of inputs and the commands that use them, executed atomically. Build it
+// with the input and command methods, then pass it to Helper.Execute.
+//
+// The methods record the first invalid input and return it from Execute,
+// so calls can be chained without checking errors.
+type TransactionBuilder struct {
+	inputs   [][]byte       // BCS-encoded CallArgs
+	objects  map[string]int // input index by object ID
+	commands []*bcsWriter   // BCS-encoded Commands
+	err      error
+}
+
+// NewTransactionBuilder returns an empty programmable transaction block.
+func NewTransactionBuilder() *TransactionBuilder {
+	return &TransactionBuilder{objects: map[string]int{}}
+}
+
+// Err returns the first error recorded while building, if any.
+func (b *TransactionBuilder) Err() error {
+	return b.err
+}
+
+func (b *TransactionBuilder) fail(err error) Argument {
+	if b.err == nil {
+		b.err = err
+	}
+	return Argument{kind: argInput}
+}
+
+func (b *TransactionBuilder) input(arg []byte) Argument {
+	b.inputs = append(b.inputs, arg)
+	return Argument{kind: argInput, index: uint16(len(b.inputs) - 1)}
+}
+
+// Pure adds a pure input: a BCS-encoded value such as an amount or an
+// address.
+func (b *TransactionBuilder) Pure(value []byte) Argument {
+	var w bcsWriter
+	w.u8(callArgPure)
+	w.bytes(value)
+	return b.input(w.Bytes())
+}
+
+// PureU64 adds a u64 input, such as an amount.
+func (b *TransactionBuilder) PureU64(v uint64) Argument {
+	return b.Pure(binary.LittleEndian.AppendUint64(nil, v))
+}
+
+// PureAddress adds an address input, such as a recipient.
+func (b *TransactionBuilder) PureAddress(address string) Argument {
+	a, err := parseAddress(address)
+	if err != nil {
+		return b.fail(err)
+	}
+	return b.Pure(a[:])
+}
+
+// Object adds an owned or immutable object input. Using the same object
+// twice returns the same input.
+func (b *TransactionBuilder) Object(ref ObjectRef) Argument {
+	id, err := parseAddress(ref.ObjectID)
+	if err != nil {
+		return b.fail(fmt.Errorf("invalid object ID: %w", err))
+	}
+	digest, err := decodeBase58(ref.Digest)
+	if err != nil || len(digest) != 32 {
+		return b.fail(fmt.Errorf("invalid digest %q of object %s", ref.Digest, ref.ObjectID))
+	}
+	key := normalizeAddress(ref.ObjectID)
+	if i, ok := b.objects[key]; ok {
+		return Argument{kind: argInput, index: uint16(i)}
+	}
+
+	var w bcsWriter
+	w.u8(callArgObject)
+	w.u8(objectArgOwned)
+	writeObjectRef(&w, id, ref.Version, digest)
+	arg := b.input(w.Bytes())
+	b.objects[key] = int(arg.index)
+	return arg
+}
+
+// SharedObject adds a shared object input, such as a pool or the clock.
+// mutable is whether any command takes it by mutable reference.
+func (b *TransactionBuilder) SharedObject(objectID string, initialSharedVersion uint64, mutable bool) Argument {
+	id, err := parseAddress(objectID)
+	if err != nil {
+		return b.fail(fmt.Errorf("invalid object ID: %w", err))
+	}
+	key := normalizeAddress(objectID)
+	if i, ok := b.objects[key]; ok {
+		return Argument{kind: argInput, index: uint16(i)}
+	}
+
+	var w bcsWriter
+	w.u8(callArgObject)
+	w.u8(objectArgShared)
+	w.address(id)
+	w.u64(initialSharedVersion)
+	w.bool(mutable)
+	arg := b.input(w.Bytes())
+	b.objects[key] = int(arg.index)
+	return arg
+}
+
+func (b *TransactionBuilder) command(w *bcsWriter) Argument {
+	b.commands = append(b.commands, w)
+	return Argument{kind: argResult, index: uint16(len(b.commands) - 1)}
+}
+
+// SplitCoins splits coins with the given amounts off coin and returns
+// them.
+func (b *TransactionBuilder) SplitCoins(coin Argument, amounts ...Argument) []Argument {
+	w := &bcsWriter{}
+	w.u8(commandSplitCoins)
+	writeArgument(w, coin)
+	writeArguments(w, amounts)
+	result := b.command(w)
+
+	coins := make([]Argument, len(amounts))
+	for i := range coins {
+		coins[i] = Argument{kind: argNestedResult, index: result.index, nested: uint16(i)}
+	}
+	return coins
+}
+
+// MergeCoins merges sources into destination.
+func (b *TransactionBuilder) MergeCoins(destination Argument, sources ...Argument) {
+	w := &bcsWriter{}
+	w.u8(commandMergeCoins)
+	writeArgument(w, destination)
+	writeArguments(w, sources)
+	b.command(w)
+}
+
+// TransferObjects transfers objects to recipient, an address argument.
+func (b *TransactionBuilder) TransferObjects(objects []Argument, recipient Argument) {
+	w := &bcsWriter{}
+	w.u8(commandTransferObjects)
+	writeArguments(w, objects)
+	writeArgument(w, recipient)
+	b.command(w)
+}
+
+// MoveCall calls a Move function, given as "package::module::function",
+// with type arguments such as "0x2::sui::SUI". It returns the result of the
+// call; use it directly for a single return value.
+func (b *TransactionBuilder) MoveCall(target string, typeArguments []string, arguments ...Argument) Argument {
+	parts := strings.Split(target, "::")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return b.fail(fmt.Errorf("invalid Move call target %q", target))
+	}
+	pkg, err := parseAddress(parts[0])
+	if err != nil {
+		return b.fail(fmt.Errorf("invalid Move call target %q: %w", target, err))
+	}
+
+	w := &bcsWriter{}
+	w.u8(commandMoveCall)
+	w.address(pkg)
+	w.str(parts[1])
+	w.str(parts[2])
+	w.uleb128(uint64(len(typeArguments)))
+	for _, t := range typeArguments {
+		if err := w.typeTag(t); err != nil {
+			return b.fail(err)
+		}
+	}
+	writeArguments(w, arguments)
+	return b.command(w)
+}
+
+// objectIDs returns the normalized IDs of the object inputs.
+func (b *TransactionBuilder) objectIDs() map[string]bool {
+	ids := make(map[string]bool, len(b.objects))
+	for id := range b.objects {
+		ids[id] = true
+	}
+	return ids
+}
+
+// gasData is the gas payment of a transaction.
+type gasData struct {
+	payment []ObjectRef
+	owner   [32]byte
+	price   uint64
+	budget  uint64
+}
+
+// encode returns the BCS-encoded TransactionData (V1) that the sender
+// signs.
+func (b *TransactionBuilder) encode(sender [32]byte, gas gasData) ([]byte, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.commands) == 0 {
+		return nil, fmt.Errorf("no commands")
+	}
+
+	var w bcsWriter
+	w.u8(0) // TransactionData::V1
+	w.u8(0) // TransactionKind::ProgrammableTransaction
+	w.uleb128(uint64(len(b.inputs)))
+	for _, in := range b.inputs {
+		w.Write(in)
+	}
+	w.uleb128(uint64(len(b.commands)))
+	for _, c := range b.commands {
+		w.Write(c.Bytes())
+	}
+	w.address(sender)
+
+	w.uleb128(uint64(len(gas.payment)))
+	for _, ref := range gas.payment {
+		id, err := parseAddress(ref.ObjectID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas coin: %w", err)
+		}
+		digest, err := decodeBase58(ref.Digest)
+		if err != nil || len(digest) != 32 {
+			return nil, fmt.Errorf("invalid digest %q of gas coin %s", ref.Digest, ref.ObjectID)
+		}
+		writeObjectRef(&w, id, ref.Version, digest)
+	}
+	w.address(gas.owner)
+	w.u64(gas.price)
+	w.u64(gas.budget)
+
+	w.u8(0) // TransactionExpiration::None
+	return w.Bytes(), nil
+}
+
+func writeObjectRef(w *bcsWriter, id [32]byte, version uint64, digest []byte) {
+	w.address(id)
+	w.u64(version)
+	w.bytes(digest)
+}
+
+func writeArgument(w *bcsWriter, a Argument) {
+	w.u8(a.kind)
+	switch a.kind {
+	case argInput, argResult:
+		w.u16(a.index)
+	case argNestedResult:
+		w.u16(a.index)
+		w.u16(a.nested)
+	}
+}
+
+func writeArguments(w *bcsWriter, args []Argument) {
+	w.uleb128(uint64(len(args)))
+	for _, a := range args {
+		writeArgument(w, a)
+	}
+}
@@ -0,0 +1,99 @@
+package sui
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestTransactionBuilder_Encode(t *testing.T) {
+	digest := encodeBase58(bytes.Repeat([]byte{1}, 32))
+	sender, _ := parseAddress("0x1")
+
+	tx := NewTransactionBuilder()
+	coins := tx.SplitCoins(GasCoin, tx.PureU64(1000))
+	tx.TransferObjects(coins, tx.PureAddress("0x2"))
+	txBytes, err := tx.encode(sender, gasData{
+		payment: []ObjectRef{{ObjectID: "0x5", Version: 7, Digest: digest}},
+		owner:   sender,
+		price:   750,
+		budget:  2000,
+	})
+	if err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+
+	addr := func(b string) string { return strings.Repeat("00", 31) + b }
+	want := "00" + "00" + // V1, ProgrammableTransaction
+		"02" + // inputs
+		"00" + "08" + "e803000000000000" + // Pure(u64 1000)
+		"00" + "20" + addr("02") + // Pure(address 0x2)
+		"02" + // commands
+		"02" + "00" + "01" + "010000" + // SplitCoins(GasCoin, [Input(0)])
+		"01" + "01" + "0300000000" + "010100" + // TransferObjects([NestedResult(0, 0)], Input(1))
+		addr("01") + // sender
+		"01" + addr("05") + "0700000000000000" + "20" + strings.Repeat("01", 32) + // payment
+		addr("01") + "ee02000000000000" + "d007000000000000" + // owner, price, budget
+		"00" // no expiration
+	if got := hex.EncodeToString(txBytes); got != want {
+		t.Errorf("encode =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTransactionBuilder_MoveCall(t *testing.T) {
+	digest := encodeBase58(bytes.Repeat([]byte{2}, 32))
+
+	tx := NewTransactionBuilder()
+	coin := tx.Object(ObjectRef{ObjectID: "0xc0", Version: 3, Digest: digest})
+	pool := tx.SharedObject("0x5", 1, true)
+	out := tx.MoveCall("0xdee::pool::swap", []string{"0x2::sui::SUI"}, pool, coin)
+	tx.MergeCoins(tx.Object(ObjectRef{ObjectID: "0x00c0", Version: 3, Digest: digest}), out)
+	if err := tx.Err(); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	// The coin is added once; using it again refers to the same input.
+	if len(tx.inputs) != 2 {
+		t.Errorf("Expected 2 inputs, got %d", len(tx.inputs))
+	}
+	wantShared := "01" + "01" + strings.Repeat("00", 31) + "05" + "0100000000000000" + "01"
+	if got := hex.EncodeToString(tx.inputs[1]); got != wantShared {
+		t.Errorf("shared object input = %s, want %s", got, wantShared)
+	}
+
+	wantCall := "00" + strings.Repeat("00", 30) + "0dee" + "04706f6f6c" + "0473776170" +
+		"01" + "07" + strings.Repeat("00", 31) + "02" + "03737569" + "03535549" + "00" +
+		"02" + "010100" + "010000"
+	if got := hex.EncodeToString(tx.commands[0].Bytes()); got != wantCall {
+		t.Errorf("MoveCall = %s, want %s", got, wantCall)
+	}
+	wantMerge := "03" + "010000" + "01" + "020000"
+	if got := hex.EncodeToString(tx.commands[1].Bytes()); got != wantMerge {
+		t.Errorf("MergeCoins = %s, want %s", got, wantMerge)
+	}
+}
+
+func TestTransactionBuilder_Errors(t *testing.T) {
+	tests := map[string]func(tx *TransactionBuilder){
+		"address": func(tx *TransactionBuilder) { tx.PureAddress("alice") },
+		"digest":  func(tx *TransactionBuilder) { tx.Object(ObjectRef{ObjectID: "0x1", Digest: "0OIl"}) },
+		"target":  func(tx *TransactionBuilder) { tx.MoveCall("0x2::coin", nil) },
+		"type":    func(tx *TransactionBuilder) { tx.MoveCall("0x2::coin::zero", []string{"SUI"}) },
+	}
+	for name, build := range tests {
+		tx := NewTransactionBuilder()
+		build(tx)
+		tx.TransferObjects([]Argument{GasCoin}, tx.PureAddress("0x2"))
+		if tx.Err() == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		if _, err := tx.encode([32]byte{}, gasData{}); err == nil {
+			t.Errorf("%s: expected encode to fail", name)
+		}
+	}
+
+	if _, err := NewTransactionBuilder().encode([32]byte{}, gasData{}); err == nil {
+		t.Error("Expected an error for a transaction without commands")
+	}
+}
@@ -1,31 +1,38 @@
 // Package sui provides a high-level helper for Sui transactions
 // using Privy's raw_sign endpoint and the Sui JSON-RPC API.
 //
-// Transactions are built via the unsafe_transferSui RPC method,
-// hashed with Blake2b-256 (with intent prefix), signed via Privy,
-// and executed via sui_executeTransactionBlock.
+// Transactions are built locally as programmable transaction blocks
+// (see TransactionBuilder), encoded with BCS, hashed with Blake2b-256
+// (with intent prefix), signed via Privy, and executed via
+// sui_executeTransactionBlock.
 package sui
 
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	privy "github.com/vadimzhukck/privy-sdk-go"
-	"golang.org/x/crypto/blake2b"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
 )
 
+var _ chains.Chain = (*Helper)(nil)
+
 // Helper provides high-level Sui transaction methods using Privy wallets.
 type Helper struct {
-	client     *privy.Client
-	rpcURL     string
-	httpClient *http.Client
+	client       *privy.Client
+	rpcURL       string
+	httpClient   *http.Client
+	pollInterval time.Duration
+	gasBudget    uint64
 }
 
 // Option configures the Helper.
@@ -50,13 +57,29 @@ func WithHTTPClient(c *http.Client) Option {
 	}
 }
 
+// WithPollInterval sets how often WaitForConfirmation polls for the transaction.
+func WithPollInterval(d time.Duration) Option {
+	return func(h *Helper) {
+		h.pollInterval = d
+	}
+}
+
+// WithGasBudget sets a fixed gas budget in MIST instead of estimating one
+// with a dry run.
+func WithGasBudget(budget uint64) Option {
+	return func(h *Helper) {
+		h.gasBudget = budget
+	}
+}
+
 // NewHelper creates a new Sui helper.
 // Options are applied in order: testnet defaults, client-level chain options, then direct options.
 func NewHelper(client *privy.Client, opts ...Option) *Helper {
 	h := &Helper{
-		client:     client,
-		rpcURL:     "https://fullnode.mainnet.sui.io:443",
-		httpClient: http.DefaultClient,
+		client:       client,
+		rpcURL:       "https://fullnode.mainnet.sui.io:443",
+		httpClient:   http.DefaultClient,
+		pollInterval: chains.DefaultPollInterval,
 	}
 	if client.Testnet() {
 		WithTestnet()(h)
@@ -93,8 +116,8 @@ type jsonRPCError struct {
 	Message string `json:"message"`
 }
 
-type transferSuiResult struct {
-	TxBytes string `json:"txBytes"`
+func (e *jsonRPCError) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
 }
 
 type executeResult struct {
@@ -104,115 +127,166 @@ type executeResult struct {
 // Transfer sends native SUI from a Privy wallet to a destination address.
 // amount is in MIST (1 SUI = 1_000_000_000 MIST) as a decimal string.
 // Returns the transaction digest.
-func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (string, error) {
-	// Get wallet info from Privy
-	wallet, err := h.client.Wallets().Get(ctx, walletID)
-	if err != nil {
-		return "", fmt.Errorf("sui: get wallet: %w", err)
-	}
+func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "sui.transfer", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
 
-	// Get a SUI coin object for gas
-	coinObjectID, err := h.getGasCoin(ctx, wallet.Address)
+	tx, err := transferTx(destination, amount)
 	if err != nil {
-		return "", fmt.Errorf("sui: get gas coin: %w", err)
+		return "", err
 	}
+	return h.execute(ctx, walletID, tx)
+}
 
-	// Build unsigned transaction via unsafe_transferSui
-	txBytes, err := h.unsafeTransferSui(ctx, wallet.Address, coinObjectID, destination, amount)
+// transferTx builds a transaction that splits amount off the gas coin and
+// transfers it to destination.
+func transferTx(destination string, amount string) (*TransactionBuilder, error) {
+	value, err := strconv.ParseUint(amount, 10, 64)
 	if err != nil {
-		return "", fmt.Errorf("sui: build transaction: %w", err)
+		return nil, fmt.Errorf("sui: invalid amount %q", amount)
 	}
-
-	// Decode tx bytes from base64
-	txBytesRaw, err := base64.StdEncoding.DecodeString(txBytes)
-	if err != nil {
-		return "", fmt.Errorf("sui: decode tx bytes: %w", err)
+	tx := NewTransactionBuilder()
+	coins := tx.SplitCoins(GasCoin, tx.PureU64(value))
+	tx.TransferObjects(coins, tx.PureAddress(destination))
+	if err := tx.Err(); err != nil {
+		return nil, fmt.Errorf("sui: invalid destination: %w", err)
 	}
+	return tx, nil
+}
 
-	// Compute digest: Blake2b-256(intent_prefix + tx_bytes)
-	// Intent prefix: [0x00, 0x00, 0x00] = TransactionData, V0, Sui
-	intentMessage := append([]byte{0x00, 0x00, 0x00}, txBytesRaw...)
-	digest := blake2b.Sum256(intentMessage)
+// TransferObject transfers an object owned by a Privy wallet, such as a
+// coin or an NFT, to destination. Returns the transaction digest.
+func (h *Helper) TransferObject(ctx context.Context, walletID string, objectID string, destination string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "sui.transfer_object", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
 
-	// Sign via Privy raw_sign
-	digestHex := "0x" + hex.EncodeToString(digest[:])
-	signResp, err := h.client.RawSign(ctx, walletID, digestHex)
+	ref, err := h.objectRef(ctx, objectID)
 	if err != nil {
-		return "", fmt.Errorf("sui: sign transaction: %w", err)
+		return "", fmt.Errorf("sui: get object: %w", err)
 	}
+	tx := NewTransactionBuilder()
+	tx.TransferObjects([]Argument{tx.Object(*ref)}, tx.PureAddress(destination))
+	return h.execute(ctx, walletID, tx)
+}
 
-	// Format signature: flag (0x00 = Ed25519) + signature (64 bytes) + public_key (32 bytes)
-	sigBytes, err := decodeHex(signResp.Data.Signature)
+// objectRef returns the reference to the current version of an object.
+func (h *Helper) objectRef(ctx context.Context, objectID string) (*ObjectRef, error) {
+	resp, err := h.callRPC(ctx, "sui_getObject", []any{objectID, map[string]bool{}})
 	if err != nil {
-		return "", fmt.Errorf("sui: decode signature: %w", err)
+		return nil, err
 	}
-
-	pubKeyBytes, err := decodeHex(wallet.PublicKey)
-	if err != nil {
-		return "", fmt.Errorf("sui: decode public key: %w", err)
+	var result struct {
+		Data *struct {
+			ObjectID string `json:"objectId"`
+			Version  string `json:"version"`
+			Digest   string `json:"digest"`
+		} `json:"data"`
+		Error *struct {
+			Code string `json:"code"`
+		} `json:"error"`
 	}
-
-	// Build serialized signature: scheme_flag + sig + pubkey
-	serializedSig := make([]byte, 0, 1+len(sigBytes)+len(pubKeyBytes))
-	serializedSig = append(serializedSig, 0x00) // Ed25519 flag
-	serializedSig = append(serializedSig, sigBytes...)
-	serializedSig = append(serializedSig, pubKeyBytes...)
-	sigBase64 := base64.StdEncoding.EncodeToString(serializedSig)
-
-	// Execute transaction
-	txDigest, err := h.executeTransactionBlock(ctx, txBytes, sigBase64)
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		code := "not found"
+		if result.Error != nil {
+			code = result.Error.Code
+		}
+		return nil, fmt.Errorf("object %s: %s", objectID, code)
+	}
+	version, err := strconv.ParseUint(result.Data.Version, 10, 64)
 	if err != nil {
-		return "", fmt.Errorf("sui: execute transaction: %w", err)
+		return nil, fmt.Errorf("object %s: invalid version %q", objectID, result.Data.Version)
 	}
+	return &ObjectRef{ObjectID: result.Data.ObjectID, Version: version, Digest: result.Data.Digest}, nil
+}
+
+// Balance returns the SUI balance of address in MIST.
+func (h *Helper) Balance(ctx context.Context, address string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "sui.balance")
+	defer func() { span.End(err) }()
 
-	return txDigest, nil
+	return h.balanceOf(ctx, address, suiCoinType)
 }
 
-// TransferObject transfers a Sui object to a destination address.
-func (h *Helper) TransferObject(ctx context.Context, walletID string, objectID string, destination string) (string, error) {
-	return "", fmt.Errorf("sui: TransferObject not yet implemented")
+// ValidateAddress checks that address is 0x followed by 64 hex digits.
+func (h *Helper) ValidateAddress(address string) error {
+	if len(address) != 66 || !strings.HasPrefix(address, "0x") {
+		return fmt.Errorf("%w: %q is not a 0x-prefixed 32-byte address", chains.ErrInvalidAddress, address)
+	}
+	if _, err := hex.DecodeString(address[2:]); err != nil {
+		return fmt.Errorf("%w: %q is not hex", chains.ErrInvalidAddress, address)
+	}
+	return nil
 }
 
-// getGasCoin gets the first available SUI coin object for gas.
-func (h *Helper) getGasCoin(ctx context.Context, owner string) (string, error) {
-	resp, err := h.callRPC(ctx, "suix_getCoins", []any{owner, "0x2::sui::SUI", nil, 1})
+// EstimateFee dry-runs the transfer and returns the net gas fee in MIST:
+// computation plus storage cost, less the storage rebate.
+func (h *Helper) EstimateFee(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "sui.estimate_fee", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("sui: get wallet: %w", err)
 	}
-
-	var result struct {
-		Data []struct {
-			CoinObjectID string `json:"coinObjectId"`
-		} `json:"data"`
+	tx, err := transferTx(destination, amount)
+	if err != nil {
+		return "", err
 	}
-	if err := json.Unmarshal(resp, &result); err != nil {
+	txBytes, result, err := h.build(ctx, wallet.Address, tx)
+	if err != nil {
 		return "", err
 	}
-	if len(result.Data) == 0 {
-		return "", fmt.Errorf("no SUI coins found for %s", owner)
+	if result == nil {
+		if result, err = h.dryRun(ctx, txBytes); err != nil {
+			return "", err
+		}
 	}
-	return result.Data[0].CoinObjectID, nil
+	return result.fee().String(), nil
 }
 
-// unsafeTransferSui builds an unsigned transfer transaction.
-func (h *Helper) unsafeTransferSui(ctx context.Context, sender, coinObjectID, recipient, amount string) (string, error) {
-	gasBudget := "10000000" // 0.01 SUI default gas budget
-	resp, err := h.callRPC(ctx, "unsafe_transferSui", []any{
-		sender, coinObjectID, gasBudget, recipient, amount,
-	})
-	if err != nil {
-		return "", err
-	}
+// WaitForConfirmation polls until the transaction is known to the full node
+// and checks its effects. A failed execution is reported as
+// chains.ErrTransactionFailed.
+func (h *Helper) WaitForConfirmation(ctx context.Context, txHash string) (err error) {
+	ctx, span := h.client.StartSpan(ctx, "sui.wait_for_confirmation")
+	defer func() { span.End(err) }()
+
+	return chains.Poll(ctx, h.pollInterval, func(ctx context.Context) (bool, error) {
+		resp, err := h.callRPC(ctx, "sui_getTransactionBlock", []any{txHash, map[string]bool{"showEffects": true}})
+		var rpcErr *jsonRPCError
+		if errors.As(err, &rpcErr) && strings.Contains(rpcErr.Message, "Could not find") {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("sui: get transaction: %w", err)
+		}
 
-	var result transferSuiResult
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return "", err
-	}
-	return result.TxBytes, nil
+		var result struct {
+			Effects struct {
+				Status struct {
+					Status string `json:"status"`
+					Error  string `json:"error"`
+				} `json:"status"`
+			} `json:"effects"`
+		}
+		if err := json.Unmarshal(resp, &result); err != nil {
+			return false, fmt.Errorf("sui: get transaction: %w", err)
+		}
+		if result.Effects.Status.Status == "failure" {
+			return true, fmt.Errorf("sui: %w: %s", chains.ErrTransactionFailed, result.Effects.Status.Error)
+		}
+		return true, nil
+	})
 }
 
 // executeTransactionBlock submits a signed transaction for execution.
-func (h *Helper) executeTransactionBlock(ctx context.Context, txBytes, signature string) (string, error) {
+func (h *Helper) executeTransactionBlock(ctx context.Context, txBytes, signature string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "sui.broadcast")
+	defer func() { span.End(err) }()
+
 	options := map[string]bool{
 		"showEffects": true,
 	}
@@ -231,7 +305,10 @@ func (h *Helper) executeTransactionBlock(ctx context.Context, txBytes, signature
 }
 
 // callRPC makes a JSON-RPC call to the Sui node.
-func (h *Helper) callRPC(ctx context.Context, method string, params []any) (json.RawMessage, error) {
+func (h *Helper) callRPC(ctx context.Context, method string, params []any) (_ json.RawMessage, err error) {
+	ctx, span := h.client.StartSpan(ctx, "sui.rpc", privy.Attr("rpc.method", method))
+	defer func() { span.End(err) }()
+
 	reqBody := &jsonRPCRequest{
 		JSONRPC: "2.0",
 		ID:      1,
@@ -267,7 +344,7 @@ func (h *Helper) callRPC(ctx context.Context, method string, params []any) (json
 	}
 
 	if rpcResp.Error != nil {
-		return nil, fmt.Errorf("RPC error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+		return nil, rpcResp.Error
 	}
 
 	return rpcResp.Result, nil
@@ -1,13 +1,27 @@
 package sui
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
+	"golang.org/x/crypto/blake2b"
 )
 
 func TestNewHelper(t *testing.T) {
@@ -43,89 +57,350 @@ func TestNewHelper_WithHTTPClient(t *testing.T) {
 	}
 }
 
-func TestTransfer_WithMockServer(t *testing.T) {
-	// Mock Sui RPC
-	suiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		var req jsonRPCRequest
+// newRPCServer returns a Sui JSON-RPC node that answers each method with the
+// result or error returned by handle.
+func newRPCServer(t *testing.T, handle func(method string, params []json.RawMessage) (result any, rpcErr any)) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
 		json.NewDecoder(r.Body).Decode(&req)
+		result, rpcErr := handle(req.Method, req.Params)
+		if rpcErr != nil {
+			json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "error": rpcErr})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestBalance(t *testing.T) {
+	node := newRPCServer(t, func(method string, params []json.RawMessage) (any, any) {
+		return map[string]any{"coinType": "0x2::sui::SUI", "coinObjectCount": 2, "totalBalance": "1500000000"}, nil
+	})
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(node.URL))
+
+	balance, err := h.Balance(context.Background(), "0x"+strings.Repeat("ab", 32))
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if balance != "1500000000" {
+		t.Errorf("Expected 1500000000, got %s", balance)
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"))
 
-		switch req.Method {
+	if err := h.ValidateAddress("0x" + strings.Repeat("ab", 32)); err != nil {
+		t.Errorf("Expected valid address, got %v", err)
+	}
+	for _, addr := range []string{"", "0x2", strings.Repeat("ab", 33), "0x" + strings.Repeat("zz", 32)} {
+		if err := h.ValidateAddress(addr); !errors.Is(err, chains.ErrInvalidAddress) {
+			t.Errorf("ValidateAddress(%q) = %v, want ErrInvalidAddress", addr, err)
+		}
+	}
+}
+
+func TestEstimateFee(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeSui})
+
+	node := newSuiNode(t, wallet.Address, 2_000_000_000)
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	fee, err := h.EstimateFee(context.Background(), wallet.ID, "0x"+strings.Repeat("cd", 32), "1000")
+	if err != nil {
+		t.Fatalf("EstimateFee failed: %v", err)
+	}
+	if fee != "1997880" {
+		t.Errorf("Expected 1997880, got %s", fee)
+	}
+	if len(node.executed) != 0 {
+		t.Error("Expected nothing to be executed")
+	}
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	polls := 0
+	status := map[string]any{"status": "success"}
+	node := newRPCServer(t, func(method string, params []json.RawMessage) (any, any) {
+		polls++
+		if polls == 1 {
+			return nil, map[string]any{"code": -32602, "message": "Could not find the referenced transaction"}
+		}
+		return map[string]any{"digest": "abc", "effects": map[string]any{"status": status}}, nil
+	})
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(node.URL), WithPollInterval(time.Millisecond))
+
+	if err := h.WaitForConfirmation(context.Background(), "abc"); err != nil {
+		t.Fatalf("WaitForConfirmation failed: %v", err)
+	}
+	if polls != 2 {
+		t.Errorf("Expected 2 polls, got %d", polls)
+	}
+
+	status = map[string]any{"status": "failure", "error": "InsufficientGas"}
+	if err := h.WaitForConfirmation(context.Background(), "abc"); !errors.Is(err, chains.ErrTransactionFailed) {
+		t.Errorf("Expected ErrTransactionFailed, got %v", err)
+	}
+}
+
+// suiNode is a mock Sui full node serving a wallet's SUI coins, and coins
+// of any other type from tokens, two per page. Dry runs report fixed gas costs and a balance change of the
+// transferred amount plus the fee. It records the transactions it
+// dry-runs and executes.
+type suiNode struct {
+	*httptest.Server
+	coins     []map[string]any
+	tokens    []map[string]any
+	objects   map[string]map[string]any
+	dryRuns   [][]byte
+	executed  [][]byte
+	signature string
+	spent     int64 // SUI the dry-run transaction spends beyond gas
+}
+
+// suiDigest is a valid base58 object digest.
+var suiDigest = encodeBase58(bytes.Repeat([]byte{7}, 32))
+
+func newSuiNode(t *testing.T, owner string, balances ...int64) *suiNode {
+	t.Helper()
+	n := &suiNode{objects: map[string]map[string]any{}}
+	for i, b := range balances {
+		n.coins = append(n.coins, map[string]any{
+			"coinType":     "0x2::sui::SUI",
+			"coinObjectId": fmt.Sprintf("0x%064x", i+1),
+			"version":      "10",
+			"digest":       suiDigest,
+			"balance":      strconv.FormatInt(b, 10),
+		})
+	}
+	n.Server = newRPCServer(t, func(method string, params []json.RawMessage) (any, any) {
+		switch method {
+		case "suix_getReferenceGasPrice":
+			return "750", nil
 		case "suix_getCoins":
-			json.NewEncoder(w).Encode(map[string]any{
-				"jsonrpc": "2.0",
-				"id":      1,
-				"result": map[string]any{
-					"data": []map[string]any{
-						{"coinObjectId": "0xabc123"},
-					},
+			coins := n.coins
+			var coinType string
+			json.Unmarshal(params[1], &coinType)
+			if coinType != "0x2::sui::SUI" {
+				coins = n.tokens
+			}
+			start := 0
+			if len(params) > 2 {
+				var cursor string
+				if json.Unmarshal(params[2], &cursor) == nil {
+					start, _ = strconv.Atoi(cursor)
+				}
+			}
+			end := min(start+2, len(coins))
+			var next any
+			if end < len(coins) {
+				next = strconv.Itoa(end)
+			}
+			return map[string]any{"data": coins[start:end], "nextCursor": next, "hasNextPage": next != nil}, nil
+		case "sui_getObject":
+			var id string
+			json.Unmarshal(params[0], &id)
+			if obj, ok := n.objects[id]; ok {
+				return map[string]any{"data": obj}, nil
+			}
+			return map[string]any{"error": map[string]any{"code": "notExists", "object_id": id}}, nil
+		case "sui_dryRunTransactionBlock":
+			var txBytes string
+			json.Unmarshal(params[0], &txBytes)
+			raw, _ := base64.StdEncoding.DecodeString(txBytes)
+			n.dryRuns = append(n.dryRuns, raw)
+			return map[string]any{
+				"effects": map[string]any{
+					"status":  map[string]any{"status": "success"},
+					"gasUsed": map[string]any{"computationCost": "1000000", "storageCost": "1976000", "storageRebate": "978120"},
 				},
-			})
-		case "unsafe_transferSui":
-			json.NewEncoder(w).Encode(map[string]any{
-				"jsonrpc": "2.0",
-				"id":      1,
-				"result": map[string]any{
-					"txBytes": "AQAAAA==",
-				},
-			})
+				"balanceChanges": []any{map[string]any{
+					"owner":    map[string]any{"AddressOwner": owner},
+					"coinType": "0x2::sui::SUI",
+					"amount":   strconv.FormatInt(-n.spent-1997880, 10),
+				}},
+			}, nil
 		case "sui_executeTransactionBlock":
-			json.NewEncoder(w).Encode(map[string]any{
-				"jsonrpc": "2.0",
-				"id":      1,
-				"result": map[string]any{
-					"digest": "HKE7K8Bk1wYHfGjm2T5X",
-				},
-			})
-		default:
-			http.NotFound(w, r)
+			var txBytes string
+			var sigs []string
+			json.Unmarshal(params[0], &txBytes)
+			json.Unmarshal(params[1], &sigs)
+			raw, _ := base64.StdEncoding.DecodeString(txBytes)
+			n.executed = append(n.executed, raw)
+			n.signature = sigs[0]
+			return map[string]any{"digest": "HKE7K8Bk1wYHfGjm2T5X"}, nil
 		}
-	}))
-	defer suiServer.Close()
-
-	// Mock Privy API
-	privyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		switch {
-		case r.Method == "GET" && r.URL.Path == "/v1/wallets/wallet-123":
-			json.NewEncoder(w).Encode(map[string]any{
-				"id":         "wallet-123",
-				"address":    "0xsender123",
-				"chain_type": "sui",
-				"public_key": "0xabcdef0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
-			})
-		case r.Method == "POST" && r.URL.Path == "/v1/wallets/wallet-123/raw_sign":
-			json.NewEncoder(w).Encode(map[string]any{
-				"method": "raw_sign",
-				"data": map[string]any{
-					"signature": "0xaabbccddeeff00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff00112233445566778899",
-					"encoding":  "hex",
-				},
-			})
-		default:
-			http.NotFound(w, r)
-		}
-	}))
-	defer privyServer.Close()
+		return nil, map[string]any{"code": -32601, "message": "method not found"}
+	})
+	return n
+}
 
-	client := privy.NewClient("test-app-id", "test-app-secret",
-		privy.WithBaseURL(privyServer.URL+"/v1"))
+// gasPayment decodes the gas data at the end of BCS transaction data,
+// given the number of gas coins: their object IDs, the price and the
+// budget.
+func gasPayment(t *testing.T, txBytes []byte, coins int) (ids []string, price, budget uint64) {
+	t.Helper()
+	const refSize = 32 + 8 + 1 + 32
+	tail := 1 + 8 + 8 + 32 + coins*refSize
+	if len(txBytes) < tail+1 || int(txBytes[len(txBytes)-tail-1]) != coins {
+		t.Fatalf("Expected %d gas coins in %x", coins, txBytes)
+	}
+	payment := txBytes[len(txBytes)-tail:]
+	for i := 0; i < coins; i++ {
+		ids = append(ids, "0x"+hex.EncodeToString(payment[i*refSize:i*refSize+32]))
+	}
+	gas := payment[coins*refSize+32:]
+	return ids, binary.LittleEndian.Uint64(gas[:8]), binary.LittleEndian.Uint64(gas[8:16])
+}
 
-	h := NewHelper(client, WithRPCURL(suiServer.URL))
+func TestTransfer(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeSui})
 
-	digest, err := h.Transfer(context.Background(), "wallet-123", "0xrecipient456", "1000000000")
+	// 1.6 SUI across four coins.
+	node := newSuiNode(t, wallet.Address, 500_000_000, 300_000_000, 800_000_000, 1_000_000)
+	node.spent = 1_000_000_000
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	digest, err := h.Transfer(context.Background(), wallet.ID, "0x"+strings.Repeat("cd", 32), "1000000000")
 	if err != nil {
 		t.Fatalf("Transfer failed: %v", err)
 	}
 	if digest != "HKE7K8Bk1wYHfGjm2T5X" {
 		t.Errorf("Expected digest HKE7K8Bk1wYHfGjm2T5X, got %s", digest)
 	}
+
+	// The dry run pays with every coin and as much as they hold.
+	if len(node.dryRuns) != 1 || len(node.executed) != 1 {
+		t.Fatalf("Expected one dry run and one execution, got %d and %d", len(node.dryRuns), len(node.executed))
+	}
+	if _, _, budget := gasPayment(t, node.dryRuns[0], 4); budget != 1_601_000_000 {
+		t.Errorf("Expected a dry-run budget of 1601000000, got %d", budget)
+	}
+
+	// The budget is the computation cost plus 1000 × gas price plus net
+	// storage. The two largest coins cover it and the 1 SUI transferred.
+	ids, price, budget := gasPayment(t, node.executed[0], 2)
+	if price != 750 {
+		t.Errorf("Expected gas price 750, got %d", price)
+	}
+	if budget != 1_000_000+750_000+997_880 {
+		t.Errorf("Expected budget 2747880, got %d", budget)
+	}
+	if want := []string{node.coins[2]["coinObjectId"].(string), node.coins[0]["coinObjectId"].(string)}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Expected gas coins %v, got %v", want, ids)
+	}
+
+	// The serialized signature is flag ‖ Ed25519 signature ‖ public key
+	// over Blake2b-256 of the intent-prefixed transaction.
+	sig, _ := base64.StdEncoding.DecodeString(node.signature)
+	pubKey, _ := decodeHex(wallet.PublicKey)
+	hash := blake2b.Sum256(append([]byte{0, 0, 0}, node.executed[0]...))
+	if len(sig) != 97 || sig[0] != 0 || !bytes.Equal(sig[65:], pubKey) || !ed25519.Verify(pubKey, hash[:], sig[1:65]) {
+		t.Errorf("Invalid serialized signature %x", sig)
+	}
 }
 
-func TestTransferObject_NotImplemented(t *testing.T) {
-	client := privy.NewClient("test-app-id", "test-app-secret")
-	h := NewHelper(client)
+func TestTransfer_InsufficientBalance(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeSui})
+
+	node := newSuiNode(t, wallet.Address, 500_000_000)
+	node.spent = 1_000_000_000
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	_, err := h.Transfer(context.Background(), wallet.ID, "0x"+strings.Repeat("cd", 32), "1000000000")
+	if err == nil || !strings.Contains(err.Error(), "insufficient SUI") {
+		t.Errorf("Expected an insufficient balance error, got %v", err)
+	}
+	if len(node.executed) != 0 {
+		t.Error("Expected nothing to be executed")
+	}
+}
+
+func TestTransfer_FixedGasBudget(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeSui})
+
+	// The largest coin covers the budget but not the amount as well.
+	node := newSuiNode(t, wallet.Address, 600_000_000, 500_000_000, 100_000_000)
+	h := NewHelper(client, WithRPCURL(node.URL), WithGasBudget(5_000_000))
 
-	_, err := h.TransferObject(context.Background(), "wallet-id", "0xobject...", "0xdest...")
-	if err == nil {
-		t.Error("Expected error for unimplemented TransferObject")
+	if _, err := h.Transfer(context.Background(), wallet.ID, "0x"+strings.Repeat("cd", 32), "1000000000"); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+	ids, _, budget := gasPayment(t, node.executed[0], 3)
+	for i, id := range ids {
+		if id != node.coins[i]["coinObjectId"] {
+			t.Errorf("Expected gas coin %d to be %s, got %s", i, node.coins[i]["coinObjectId"], id)
+		}
+	}
+	if budget != 5_000_000 {
+		t.Errorf("Expected budget 5000000, got %d", budget)
+	}
+}
+
+func TestTransferObject(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeSui})
+
+	node := newSuiNode(t, wallet.Address, 700_000_000, 300_000_000)
+	nft := "0x" + strings.Repeat("ab", 32)
+	node.objects[nft] = map[string]any{"objectId": nft, "version": "42", "digest": suiDigest}
+	h := NewHelper(client, WithRPCURL(node.URL), WithGasBudget(5_000_000))
+
+	if _, err := h.TransferObject(context.Background(), wallet.ID, nft, "0x"+strings.Repeat("cd", 32)); err != nil {
+		t.Fatalf("TransferObject failed: %v", err)
+	}
+	if len(node.dryRuns) != 0 {
+		t.Error("Expected no dry run with a fixed gas budget")
+	}
+	txBytes := node.executed[0]
+	if _, _, budget := gasPayment(t, txBytes, 2); budget != 5_000_000 {
+		t.Errorf("Expected budget 5000000, got %d", budget)
+	}
+
+	// The object is an owned-object input transferred to the recipient.
+	id, _ := parseAddress(nft)
+	input := append([]byte{1, 0}, id[:]...)
+	input = binary.LittleEndian.AppendUint64(input, 42)
+	if !bytes.Contains(txBytes, input) {
+		t.Errorf("Expected the object input %x in %x", input, txBytes)
+	}
+
+	if _, err := h.TransferObject(context.Background(), wallet.ID, "0x"+strings.Repeat("ef", 32), "0x"+strings.Repeat("cd", 32)); err == nil || !strings.Contains(err.Error(), "notExists") {
+		t.Errorf("Expected an error for a missing object, got %v", err)
+	}
+}
+
+func TestTransferObject_ExcludedFromGas(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeSui})
+
+	// Transferring the largest SUI coin leaves the other to pay for gas.
+	node := newSuiNode(t, wallet.Address, 900_000_000, 100_000_000)
+	coinID := node.coins[0]["coinObjectId"].(string)
+	node.objects[coinID] = map[string]any{"objectId": coinID, "version": "10", "digest": suiDigest}
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	if _, err := h.TransferObject(context.Background(), wallet.ID, coinID, "0x"+strings.Repeat("cd", 32)); err != nil {
+		t.Fatalf("TransferObject failed: %v", err)
+	}
+	ids, _, _ := gasPayment(t, node.executed[0], 1)
+	if ids[0] != node.coins[1]["coinObjectId"] {
+		t.Errorf("Expected gas paid with %s, got %s", node.coins[1]["coinObjectId"], ids[0])
 	}
 }
@@ -0,0 +1,107 @@
+package ton
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// address is a TON account address: a workchain and a 256-bit account ID,
+// plus the flags carried by the user-friendly form.
+type address struct {
+	Workchain int8
+	Hash      [32]byte
+
+	// Bounceable asks for transfers to bounce back if the destination
+	// does not exist or fails. Raw addresses are treated as bounceable.
+	Bounceable bool
+	TestOnly   bool
+}
+
+// User-friendly address flags.
+const (
+	flagBounceable    = 0x11
+	flagNonBounceable = 0x51
+	flagTestOnly      = 0x80
+)
+
+// parseAddress parses a raw ("0:<64 hex>") or user-friendly (48 base64 or
+// base64url characters) address.
+func parseAddress(s string) (*address, error) {
+	if wc, hash, ok := strings.Cut(s, ":"); ok {
+		n, err := strconv.ParseInt(wc, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid workchain %q", wc)
+		}
+		b, err := hex.DecodeString(hash)
+		if err != nil || len(b) != 32 {
+			return nil, fmt.Errorf("invalid account ID %q", hash)
+		}
+		addr := &address{Workchain: int8(n), Bounceable: true}
+		copy(addr.Hash[:], b)
+		return addr, nil
+	}
+
+	if len(s) != 48 {
+		return nil, fmt.Errorf("user-friendly address must be 48 characters, got %d", len(s))
+	}
+	enc := base64.StdEncoding
+	if strings.ContainsAny(s, "-_") {
+		enc = base64.URLEncoding
+	}
+	b, err := enc.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64: %w", err)
+	}
+	if flags := b[0] &^ flagTestOnly; flags != flagBounceable && flags != flagNonBounceable {
+		return nil, fmt.Errorf("invalid address flags 0x%02x", b[0])
+	}
+	if crc := crc16(b[:34]); b[34] != byte(crc>>8) || b[35] != byte(crc) {
+		return nil, fmt.Errorf("bad checksum")
+	}
+	addr := &address{
+		Workchain:  int8(b[1]),
+		Bounceable: b[0]&^flagTestOnly == flagBounceable,
+		TestOnly:   b[0]&flagTestOnly != 0,
+	}
+	copy(addr.Hash[:], b[2:34])
+	return addr, nil
+}
+
+// String returns the user-friendly base64url form of the address.
+func (a *address) String() string {
+	flags := byte(flagNonBounceable)
+	if a.Bounceable {
+		flags = flagBounceable
+	}
+	if a.TestOnly {
+		flags |= flagTestOnly
+	}
+	b := append([]byte{flags, byte(a.Workchain)}, a.Hash[:]...)
+	crc := crc16(b)
+	b = append(b, byte(crc>>8), byte(crc))
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+// equal reports whether a and b name the same account, ignoring flags.
+func (a *address) equal(b *address) bool {
+	return a.Workchain == b.Workchain && a.Hash == b.Hash
+}
+
+// crc16 computes CRC-16/XMODEM, the checksum of user-friendly addresses.
+func crc16(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
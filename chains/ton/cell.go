@@ -0,0 +1,390 @@
+package ton
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"math/big"
+)
+
+// Cells hold at most 1023 data bits and four references.
+const (
+	maxCellBits = 1023
+	maxCellRefs = 4
+)
+
+// cell is an ordinary TON cell: up to 1023 bits of data and up to four
+// references to other cells.
+type cell struct {
+	data []byte // bits are packed from the most significant bit
+	bits int
+	refs []*cell
+}
+
+// descriptors returns the two cell descriptor bytes d1 and d2.
+func (c *cell) descriptors() (byte, byte) {
+	return byte(len(c.refs)), byte(c.bits/8 + (c.bits+7)/8)
+}
+
+// paddedData returns the data bytes with the completion tag appended when
+// the bit length is not a multiple of eight.
+func (c *cell) paddedData() []byte {
+	out := make([]byte, (c.bits+7)/8)
+	copy(out, c.data)
+	if c.bits%8 != 0 {
+		out[c.bits/8] |= 0x80 >> (c.bits % 8)
+	}
+	return out
+}
+
+// depth is the length of the longest reference chain below the cell.
+func (c *cell) depth() uint16 {
+	var d uint16
+	for _, ref := range c.refs {
+		if rd := ref.depth() + 1; rd > d {
+			d = rd
+		}
+	}
+	return d
+}
+
+// hash returns the representation hash of the cell, which is what TON
+// signs and what addresses are derived from.
+func (c *cell) hash() []byte {
+	d1, d2 := c.descriptors()
+	repr := append([]byte{d1, d2}, c.paddedData()...)
+	for _, ref := range c.refs {
+		repr = binary.BigEndian.AppendUint16(repr, ref.depth())
+	}
+	for _, ref := range c.refs {
+		repr = append(repr, ref.hash()...)
+	}
+	sum := sha256.Sum256(repr)
+	return sum[:]
+}
+
+// builder accumulates bits and references for a new cell.
+type builder struct {
+	cell
+	err error
+}
+
+func newBuilder() *builder {
+	return &builder{}
+}
+
+func (b *builder) storeBit(bit bool) *builder {
+	if b.err != nil {
+		return b
+	}
+	if b.bits >= maxCellBits {
+		b.err = fmt.Errorf("cell overflow: more than %d bits", maxCellBits)
+		return b
+	}
+	if b.bits%8 == 0 {
+		b.data = append(b.data, 0)
+	}
+	if bit {
+		b.data[b.bits/8] |= 0x80 >> (b.bits % 8)
+	}
+	b.bits++
+	return b
+}
+
+// storeUint stores the low n bits of v, most significant first.
+func (b *builder) storeUint(v uint64, n int) *builder {
+	for i := n - 1; i >= 0; i-- {
+		b.storeBit(v>>uint(i)&1 == 1)
+	}
+	return b
+}
+
+// storeInt stores v as an n-bit two's complement integer.
+func (b *builder) storeInt(v int64, n int) *builder {
+	return b.storeUint(uint64(v), n)
+}
+
+func (b *builder) storeBytes(p []byte) *builder {
+	for _, c := range p {
+		b.storeUint(uint64(c), 8)
+	}
+	return b
+}
+
+// storeBigUint stores v as an n-bit unsigned integer.
+func (b *builder) storeBigUint(v *big.Int, n int) *builder {
+	if b.err == nil && (v.Sign() < 0 || v.BitLen() > n) {
+		b.err = fmt.Errorf("value %s does not fit in %d bits", v, n)
+		return b
+	}
+	for i := n - 1; i >= 0; i-- {
+		b.storeBit(v.Bit(i) == 1)
+	}
+	return b
+}
+
+// storeCoins stores v as VarUInteger 16: a 4-bit byte length followed by
+// the value.
+func (b *builder) storeCoins(v *big.Int) *builder {
+	n := (v.BitLen() + 7) / 8
+	if b.err == nil && (v.Sign() < 0 || n > 15) {
+		b.err = fmt.Errorf("invalid coin amount %s", v)
+		return b
+	}
+	b.storeUint(uint64(n), 4)
+	return b.storeBigUint(v, n*8)
+}
+
+// storeAddress stores a MsgAddressInt (addr_std), or addr_none for nil.
+func (b *builder) storeAddress(addr *address) *builder {
+	if addr == nil {
+		return b.storeUint(0, 2)
+	}
+	b.storeUint(0b100, 3) // addr_std$10, no anycast
+	b.storeInt(int64(addr.Workchain), 8)
+	return b.storeBytes(addr.Hash[:])
+}
+
+// storeMaybeRef stores Maybe ^Cell.
+func (b *builder) storeMaybeRef(c *cell) *builder {
+	if c == nil {
+		return b.storeBit(false)
+	}
+	return b.storeBit(true).storeRef(c)
+}
+
+func (b *builder) storeRef(c *cell) *builder {
+	if b.err != nil {
+		return b
+	}
+	if len(b.refs) >= maxCellRefs {
+		b.err = fmt.Errorf("cell overflow: more than %d references", maxCellRefs)
+		return b
+	}
+	b.refs = append(b.refs, c)
+	return b
+}
+
+// storeCell appends the bits and references of c.
+func (b *builder) storeCell(c *cell) *builder {
+	for i := 0; i < c.bits; i++ {
+		b.storeBit(c.data[i/8]&(0x80>>(i%8)) != 0)
+	}
+	for _, ref := range c.refs {
+		b.storeRef(ref)
+	}
+	return b
+}
+
+func (b *builder) endCell() (*cell, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	c := b.cell
+	return &c, nil
+}
+
+// bocMagic prefixes serialized_boc.
+var bocMagic = []byte{0xb5, 0xee, 0x9c, 0x72}
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// serializeBOC encodes the cell tree rooted at root as a bag of cells with a
+// CRC32-C checksum.
+func serializeBOC(root *cell) []byte {
+	// Order cells so that every cell comes before the cells it references,
+	// storing identical subtrees once.
+	var order []*cell
+	index := make(map[string]int)
+	visited := make(map[string]bool)
+	var visit func(c *cell)
+	visit = func(c *cell) {
+		key := string(c.hash())
+		if visited[key] {
+			return
+		}
+		visited[key] = true
+		for _, ref := range c.refs {
+			visit(ref)
+		}
+		order = append(order, c)
+	}
+	visit(root)
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	for i, c := range order {
+		index[string(c.hash())] = i
+	}
+
+	sizeBytes := byteLen(uint64(len(order)))
+	var cells bytes.Buffer
+	for _, c := range order {
+		d1, d2 := c.descriptors()
+		cells.WriteByte(d1)
+		cells.WriteByte(d2)
+		cells.Write(c.paddedData())
+		for _, ref := range c.refs {
+			cells.Write(uintBytes(uint64(index[string(ref.hash())]), sizeBytes))
+		}
+	}
+	offBytes := byteLen(uint64(cells.Len()))
+
+	var buf bytes.Buffer
+	buf.Write(bocMagic)
+	buf.WriteByte(0x40 | byte(sizeBytes)) // has_crc32c
+	buf.WriteByte(byte(offBytes))
+	buf.Write(uintBytes(uint64(len(order)), sizeBytes)) // cells
+	buf.Write(uintBytes(1, sizeBytes))                  // roots
+	buf.Write(uintBytes(0, sizeBytes))                  // absent
+	buf.Write(uintBytes(uint64(cells.Len()), offBytes))
+	buf.Write(uintBytes(0, sizeBytes)) // root index
+	buf.Write(cells.Bytes())
+	buf.Write(binary.LittleEndian.AppendUint32(nil, crc32.Checksum(buf.Bytes(), crc32c)))
+	return buf.Bytes()
+}
+
+// parseBOC decodes a single-root bag of cells.
+func parseBOC(data []byte) (*cell, error) {
+	if len(data) < 6 || !bytes.Equal(data[:4], bocMagic) {
+		return nil, fmt.Errorf("boc: bad magic")
+	}
+	flags := data[4]
+	hasIdx, hasCRC := flags&0x80 != 0, flags&0x40 != 0
+	sizeBytes, offBytes := int(flags&0x07), int(data[5])
+	if sizeBytes == 0 || sizeBytes > 4 || offBytes == 0 || offBytes > 8 {
+		return nil, fmt.Errorf("boc: invalid header")
+	}
+	if hasCRC {
+		body, sum := data[:len(data)-4], binary.LittleEndian.Uint32(data[len(data)-4:])
+		if crc32.Checksum(body, crc32c) != sum {
+			return nil, fmt.Errorf("boc: bad checksum")
+		}
+		data = body
+	}
+
+	r := &bocReader{data: data, pos: 6}
+	cellCount := int(r.uint(sizeBytes))
+	rootCount := int(r.uint(sizeBytes))
+	r.uint(sizeBytes) // absent
+	r.uint(offBytes)  // total cells size
+	if rootCount != 1 {
+		return nil, fmt.Errorf("boc: expected one root, got %d", rootCount)
+	}
+	rootIndex := int(r.uint(sizeBytes))
+	if hasIdx {
+		r.skip(cellCount * offBytes)
+	}
+
+	cells := make([]*cell, cellCount)
+	refIndexes := make([][]int, cellCount)
+	for i := range cells {
+		d1, d2 := r.byte(), r.byte()
+		if d1&8 != 0 {
+			return nil, fmt.Errorf("boc: exotic cells are not supported")
+		}
+		data := r.bytes((int(d2) + 1) / 2)
+		bits := len(data) * 8
+		if d2%2 == 1 && len(data) > 0 {
+			// Strip the completion tag.
+			last := data[len(data)-1]
+			tz := 0
+			for tz < 8 && last&(1<<tz) == 0 {
+				tz++
+			}
+			if tz == 8 {
+				return nil, fmt.Errorf("boc: missing completion tag")
+			}
+			bits -= tz + 1
+			data = append([]byte(nil), data...)
+			data[len(data)-1] &^= 1 << tz
+		}
+		cells[i] = &cell{data: data, bits: bits}
+		for j := 0; j < int(d1&7); j++ {
+			refIndexes[i] = append(refIndexes[i], int(r.uint(sizeBytes)))
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	for i, refs := range refIndexes {
+		for _, ref := range refs {
+			if ref <= i || ref >= cellCount {
+				return nil, fmt.Errorf("boc: invalid reference %d from cell %d", ref, i)
+			}
+			cells[i].refs = append(cells[i].refs, cells[ref])
+		}
+	}
+	if rootIndex >= cellCount {
+		return nil, fmt.Errorf("boc: invalid root index %d", rootIndex)
+	}
+	return cells[rootIndex], nil
+}
+
+type bocReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (r *bocReader) bytes(n int) []byte {
+	if r.err != nil || r.pos+n > len(r.data) {
+		r.err = fmt.Errorf("boc: truncated")
+		return make([]byte, n)
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *bocReader) skip(n int) { r.bytes(n) }
+
+func (r *bocReader) byte() byte { return r.bytes(1)[0] }
+
+func (r *bocReader) uint(n int) uint64 {
+	var v uint64
+	for _, b := range r.bytes(n) {
+		v = v<<8 | uint64(b)
+	}
+	return v
+}
+
+// byteLen returns the number of bytes needed to hold v (at least one).
+func byteLen(v uint64) int {
+	n := 1
+	for v > 0xff {
+		v >>= 8
+		n++
+	}
+	return n
+}
+
+func uintBytes(v uint64, n int) []byte {
+	out := make([]byte, n)
+	for i := n - 1; i >= 0; i-- {
+		out[i] = byte(v)
+		v >>= 8
+	}
+	return out
+}
+
+// loadAddress reads the addr_std at the start of c.
+func loadAddress(c *cell) (*address, error) {
+	bit := func(i int) uint64 {
+		return uint64(c.data[i/8]>>(7-i%8)) & 1
+	}
+	if c.bits < 267 || bit(0) != 1 || bit(1) != 0 || bit(2) != 0 {
+		return nil, fmt.Errorf("cell does not start with a standard address")
+	}
+	var wc uint64
+	for i := 3; i < 11; i++ {
+		wc = wc<<1 | bit(i)
+	}
+	addr := &address{Workchain: int8(wc)}
+	for i := 0; i < 256; i++ {
+		addr.Hash[i/8] |= byte(bit(11+i) << (7 - i%8))
+	}
+	return addr, nil
+}
@@ -0,0 +1,90 @@
+package ton
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestCellHash(t *testing.T) {
+	empty, _ := newBuilder().endCell()
+	tests := []struct {
+		name string
+		cell *cell
+		want string
+	}{
+		{"empty", empty, "96a296d224f285c67bee93c30f8a309157f0daa35dc5b87e410b78630a09cfc7"},
+		{"v4r2 code", walletV4R2Code, "feb5ff6820e2ff0d9483e7e0d62c817d846789fb4ae580c878866d959dabd5c0"},
+		{"v5r1 code", walletV5R1Code, "20834b7b72b112147e1b2fb457b84e74d1a30f04f737d4f62a668e9552d2b72f"},
+	}
+	for _, tt := range tests {
+		if got := hex.EncodeToString(tt.cell.hash()); got != tt.want {
+			t.Errorf("%s: hash = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBOCRoundTrip(t *testing.T) {
+	leaf, _ := newBuilder().storeUint(5, 3).endCell()
+	root, err := newBuilder().
+		storeUint(0xdeadbeef, 32).
+		storeBit(true).
+		storeRef(leaf).
+		storeRef(leaf).
+		endCell()
+	if err != nil {
+		t.Fatalf("endCell failed: %v", err)
+	}
+
+	boc := serializeBOC(root)
+	parsed, err := parseBOC(boc)
+	if err != nil {
+		t.Fatalf("parseBOC failed: %v", err)
+	}
+	if !bytes.Equal(parsed.hash(), root.hash()) {
+		t.Error("Round-tripped cell has a different hash")
+	}
+	if parsed.bits != 33 || parsed.refs[1].bits != 3 {
+		t.Errorf("Unexpected bit lengths %d and %d", parsed.bits, parsed.refs[1].bits)
+	}
+
+	boc[len(boc)-5] ^= 1
+	if _, err := parseBOC(boc); err == nil {
+		t.Error("Expected checksum error for corrupted BOC")
+	}
+}
+
+func TestBuilderOverflow(t *testing.T) {
+	if _, err := newBuilder().storeBytes(make([]byte, 128)).endCell(); err == nil {
+		t.Error("Expected error for more than 1023 bits")
+	}
+}
+
+func TestCommentBody(t *testing.T) {
+	if c, _ := commentBody(""); c != nil {
+		t.Error("Expected no body for an empty comment")
+	}
+
+	text := strings.Repeat("a long comment ", 20) // spans three cells
+	c, err := commentBody(text)
+	if err != nil {
+		t.Fatalf("commentBody failed: %v", err)
+	}
+	var got []byte
+	cells := 0
+	for {
+		got = append(got, c.data...)
+		cells++
+		if len(c.refs) == 0 {
+			break
+		}
+		c = c.refs[0]
+	}
+	if cells != 3 {
+		t.Errorf("Expected the comment to span 3 cells, got %d", cells)
+	}
+	if !bytes.Equal(got[:4], []byte{0, 0, 0, 0}) || string(got[4:]) != text {
+		t.Errorf("Unexpected comment payload %q", got)
+	}
+}
@@ -0,0 +1,145 @@
+package ton
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+const (
+	// opJettonTransfer is the TEP-74 transfer opcode.
+	opJettonTransfer = 0x0f8a7ea5
+
+	// jettonTransferGas is the TON attached to a jetton transfer on top of
+	// forwardTON to pay for the jetton wallets' gas. Any excess is returned
+	// to the sender.
+	jettonTransferGas = 50_000_000
+)
+
+// TransferJetton sends amount of a jetton (TEP-74 token, such as USDT) from
+// a Privy wallet to destination. jettonMaster is the jetton's master
+// contract; the sender's jetton wallet is resolved from it. amount is in the
+// jetton's smallest unit. forwardTON nanotons (may be "" or "0") are
+// forwarded to destination with a transfer notification carrying comment.
+// Excess TON is returned to the sender. Returns the hash of the external
+// message.
+func (h *Helper) TransferJetton(ctx context.Context, walletID, jettonMaster, destination, amount, forwardTON, comment string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "ton.transfer_jetton", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("ton: get wallet: %w", err)
+	}
+	owner, err := parseAddress(wallet.Address)
+	if err != nil {
+		return "", fmt.Errorf("ton: invalid wallet address %q: %w", wallet.Address, err)
+	}
+	dest, err := parseAddress(destination)
+	if err != nil {
+		return "", fmt.Errorf("ton: invalid destination %q: %w", destination, err)
+	}
+	jettonAmount, err := parseAmount(amount)
+	if err != nil {
+		return "", err
+	}
+	forward := new(big.Int)
+	if forwardTON != "" {
+		if forward, err = parseAmount(forwardTON); err != nil {
+			return "", err
+		}
+	}
+
+	jettonWallet, err := h.jettonWalletAddress(ctx, jettonMaster, owner)
+	if err != nil {
+		return "", fmt.Errorf("ton: resolve jetton wallet: %w", err)
+	}
+
+	payload, err := commentBody(comment)
+	if err != nil {
+		return "", fmt.Errorf("ton: build comment: %w", err)
+	}
+	body, err := jettonTransferBody(uint64(time.Now().UnixNano()), jettonAmount, dest, owner, forward, payload)
+	if err != nil {
+		return "", fmt.Errorf("ton: build jetton transfer: %w", err)
+	}
+	attached := new(big.Int).Add(forward, big.NewInt(jettonTransferGas))
+	msg, err := internalMessage(jettonWallet, attached, body)
+	if err != nil {
+		return "", fmt.Errorf("ton: build message: %w", err)
+	}
+	return h.send(ctx, wallet, msg)
+}
+
+// jettonTransferBody builds the TEP-74 transfer message body sent to the
+// sender's jetton wallet.
+func jettonTransferBody(queryID uint64, amount *big.Int, dest, responseDest *address, forwardTON *big.Int, forwardPayload *cell) (*cell, error) {
+	return newBuilder().
+		storeUint(opJettonTransfer, 32).
+		storeUint(queryID, 64).
+		storeCoins(amount).
+		storeAddress(dest).
+		storeAddress(responseDest).
+		storeBit(false). // no custom_payload
+		storeCoins(forwardTON).
+		storeMaybeRef(forwardPayload). // Either Cell ^Cell: empty inline or a reference
+		endCell()
+}
+
+// jettonWalletAddress runs the master's get_wallet_address get-method to
+// find owner's jetton wallet.
+func (h *Helper) jettonWalletAddress(ctx context.Context, jettonMaster string, owner *address) (_ *address, err error) {
+	ctx, span := h.client.StartSpan(ctx, "ton.get_jetton_wallet")
+	defer func() { span.End(err) }()
+
+	ownerSlice, err := newBuilder().storeAddress(owner).endCell()
+	if err != nil {
+		return nil, err
+	}
+	req := map[string]any{
+		"address": jettonMaster,
+		"method":  "get_wallet_address",
+		"stack":   [][]string{{"tvm.Slice", base64.StdEncoding.EncodeToString(serializeBOC(ownerSlice))}},
+	}
+	var result struct {
+		ExitCode int                 `json:"exit_code"`
+		Stack    [][]json.RawMessage `json:"stack"`
+	}
+	if err := h.post(ctx, "/runGetMethod", req, &result); err != nil {
+		return nil, err
+	}
+	if result.ExitCode != 0 && result.ExitCode != 1 {
+		return nil, fmt.Errorf("get_wallet_address exited with code %d", result.ExitCode)
+	}
+	if len(result.Stack) != 1 || len(result.Stack[0]) != 2 {
+		return nil, fmt.Errorf("unexpected get_wallet_address result")
+	}
+
+	// Slices come back as ["cell", {"bytes": <base64 BOC>}].
+	var entry struct {
+		Bytes string `json:"bytes"`
+	}
+	if err := json.Unmarshal(result.Stack[0][1], &entry); err != nil {
+		return nil, fmt.Errorf("unexpected get_wallet_address result: %w", err)
+	}
+	boc, err := base64.StdEncoding.DecodeString(entry.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("decode get_wallet_address result: %w", err)
+	}
+	c, err := parseBOC(boc)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := loadAddress(c)
+	if err != nil {
+		return nil, err
+	}
+	// Jetton wallets always exist once resolved; bounce failed transfers.
+	addr.Bounceable = true
+	return addr, nil
+}
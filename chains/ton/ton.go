@@ -1,35 +1,61 @@
 // Package ton provides a high-level helper for TON transactions
 // using Privy's raw_sign endpoint and the TON HTTP API.
 //
-// Transactions are built using manual Cell/BOC encoding, signed via Privy,
-// and broadcast via the TON API.
+// Messages are built as TON cells for a v4r2 or v5r1 wallet contract,
+// signed via Privy, serialized as a bag of cells (BOC) and broadcast via the
+// TON API. Wallets that have not been deployed yet are deployed by the first
+// transfer. WaitForConfirmation follows messages through the toncenter v3
+// indexer.
 package ton
 
 import (
 	"bytes"
 	"context"
-	"crypto/sha256"
 	"encoding/base64"
-	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
 )
 
+var _ chains.Chain = (*Helper)(nil)
+
 // Helper provides high-level TON transaction methods using Privy wallets.
 type Helper struct {
-	client     *privy.Client
-	rpcURL     string
-	httpClient *http.Client
+	client        *privy.Client
+	rpcURL        string
+	indexerURL    string
+	httpClient    *http.Client
+	pollInterval  time.Duration
+	walletVersion WalletVersion
+	walletID      uint32 // 0 means the version's default
+	globalID      int32
+
+	// expiry maps hashes of messages sent by this helper to their
+	// valid_until, so WaitForConfirmation can tell when one was dropped.
+	mu     sync.Mutex
+	expiry map[string]time.Time
 }
 
+const (
+	// messageTTL is how long a signed message stays valid.
+	messageTTL = 5 * time.Minute
+
+	// indexerDelay is how long after valid_until WaitForConfirmation keeps
+	// looking for a message, to allow for the indexer catching up.
+	indexerDelay = time.Minute
+)
+
 // Option configures the Helper.
 type Option func(*Helper)
 
@@ -40,9 +66,38 @@ func WithRPCURL(url string) Option {
 	}
 }
 
+// WithIndexerURL sets the TON indexer (toncenter API v3) endpoint URL
+// WaitForConfirmation looks messages up in.
+func WithIndexerURL(url string) Option {
+	return func(h *Helper) {
+		h.indexerURL = url
+	}
+}
+
 // WithTestnet configures the helper for TON testnet.
 func WithTestnet() Option {
-	return WithRPCURL("https://testnet.toncenter.com/api/v2")
+	return func(h *Helper) {
+		h.rpcURL = "https://testnet.toncenter.com/api/v2"
+		h.indexerURL = "https://testnet.toncenter.com/api/v3"
+		h.globalID = testnetGlobalID
+	}
+}
+
+// WithWalletVersion sets the wallet contract the Privy wallets use.
+// The default is WalletV4R2.
+func WithWalletVersion(v WalletVersion) Option {
+	return func(h *Helper) {
+		h.walletVersion = v
+	}
+}
+
+// WithWalletID overrides the wallet (subwallet) ID signed into every
+// message. By default it is 698983191 for v4r2 and the network's default
+// ID for v5r1.
+func WithWalletID(id uint32) Option {
+	return func(h *Helper) {
+		h.walletID = id
+	}
 }
 
 // WithHTTPClient sets a custom HTTP client for API calls.
@@ -52,13 +107,24 @@ func WithHTTPClient(c *http.Client) Option {
 	}
 }
 
+// WithPollInterval sets how often WaitForConfirmation polls the indexer.
+func WithPollInterval(d time.Duration) Option {
+	return func(h *Helper) {
+		h.pollInterval = d
+	}
+}
+
 // NewHelper creates a new TON helper.
 // Options are applied in order: testnet defaults, client-level chain options, then direct options.
 func NewHelper(client *privy.Client, opts ...Option) *Helper {
 	h := &Helper{
-		client:     client,
-		rpcURL:     "https://toncenter.com/api/v2",
-		httpClient: http.DefaultClient,
+		client:       client,
+		rpcURL:       "https://toncenter.com/api/v2",
+		indexerURL:   "https://toncenter.com/api/v3",
+		httpClient:   http.DefaultClient,
+		pollInterval: chains.DefaultPollInterval,
+		globalID:     mainnetGlobalID,
+		expiry:       make(map[string]time.Time),
 	}
 	if client.Testnet() {
 		WithTestnet()(h)
@@ -74,201 +140,460 @@ func NewHelper(client *privy.Client, opts ...Option) *Helper {
 	return h
 }
 
-// walletInfo represents the response from getWalletInformation.
-type walletInfo struct {
-	OK     bool `json:"ok"`
-	Result struct {
-		Wallet       bool   `json:"wallet"`
-		Balance      string `json:"balance"`
-		Seqno        int64  `json:"seqno"`
-		AccountState string `json:"account_state"`
-	} `json:"result"`
+// walletInfoResult is the result of getWalletInformation.
+type walletInfoResult struct {
+	Wallet       bool   `json:"wallet"`
+	Balance      string `json:"balance"`
+	Seqno        int64  `json:"seqno"`
+	AccountState string `json:"account_state"`
 }
 
 // Transfer sends native TON from a Privy wallet to a destination address.
 // amount is in nanotons (1 TON = 1_000_000_000 nanotons) as a decimal string.
-// Returns the message hash.
-//
-// Note: This builds a v4r2 wallet transfer message, signs it via Privy,
-// and broadcasts via the TON API.
+// Returns the hash of the external message.
 func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (string, error) {
+	return h.TransferWithComment(ctx, walletID, destination, amount, "")
+}
+
+// TransferWithComment sends native TON like Transfer, attaching a text
+// comment to the transfer. The transfer bounces back if it fails only when
+// destination is a bounceable (EQ...) or raw address.
+func (h *Helper) TransferWithComment(ctx context.Context, walletID string, destination string, amount string, comment string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "ton.transfer", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
 	// Get wallet info from Privy
 	wallet, err := h.client.Wallets().Get(ctx, walletID)
 	if err != nil {
 		return "", fmt.Errorf("ton: get wallet: %w", err)
 	}
 
-	// Parse amount
-	amountNano, err := strconv.ParseUint(amount, 10, 64)
+	msg, err := h.buildTransferMessage(destination, amount, comment)
 	if err != nil {
-		return "", fmt.Errorf("ton: invalid amount %q: %w", amount, err)
+		return "", err
 	}
+	return h.send(ctx, wallet, msg)
+}
 
-	// Get wallet seqno
-	seqno, err := h.getSeqno(ctx, wallet.Address)
+// buildTransferMessage builds the internal message for a native transfer.
+func (h *Helper) buildTransferMessage(destination, amount, comment string) (*cell, error) {
+	dest, err := parseAddress(destination)
+	if err != nil {
+		return nil, fmt.Errorf("ton: invalid destination %q: %w", destination, err)
+	}
+	amountNano, err := parseAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	body, err := commentBody(comment)
+	if err != nil {
+		return nil, fmt.Errorf("ton: build comment: %w", err)
+	}
+	msg, err := internalMessage(dest, amountNano, body)
 	if err != nil {
-		return "", fmt.Errorf("ton: get seqno: %w", err)
+		return nil, fmt.Errorf("ton: build message: %w", err)
 	}
+	return msg, nil
+}
 
-	// Build the signing message body (v4r2 wallet format)
-	// wallet_id(32) + valid_until(32) + seqno(32) + op(8) + send_mode(8) + ref(internal_msg)
-	validUntil := time.Now().Add(5 * time.Minute).Unix()
+// outgoing holds what is needed to send messages from a wallet.
+type outgoing struct {
+	address    *address
+	config     walletConfig
+	seqno      int64
+	init       *cell // StateInit when the wallet is not deployed yet
+	validUntil time.Time
+}
 
-	// Build internal transfer message body
-	internalMsg := buildInternalMessage(destination, amountNano)
+// prepare looks up the wallet's seqno and state, and builds the StateInit
+// if the wallet contract still has to be deployed.
+func (h *Helper) prepare(ctx context.Context, wallet *privy.Wallet) (*outgoing, error) {
+	addr, err := parseAddress(wallet.Address)
+	if err != nil {
+		return nil, fmt.Errorf("ton: invalid wallet address %q: %w", wallet.Address, err)
+	}
+	info, err := h.getWalletInfo(ctx, wallet.Address)
+	if err != nil {
+		return nil, fmt.Errorf("ton: get wallet information: %w", err)
+	}
 
-	// Build signing message
-	signingMsg := buildSigningMessage(698983191, seqno, validUntil, internalMsg)
+	out := &outgoing{
+		address: addr,
+		config:  walletConfig{version: h.walletVersion, walletID: h.walletID},
+		seqno:   info.Seqno,
+	}
+	if out.config.walletID == 0 {
+		out.config.walletID = defaultV4WalletID
+		if h.walletVersion == WalletV5R1 {
+			out.config.walletID = v5WalletID(h.globalID, addr.Workchain)
+		}
+	}
+	if info.AccountState == "active" {
+		return out, nil
+	}
 
-	// Hash for signing (SHA-256 of the signing message bytes)
-	hash := sha256.Sum256(signingMsg)
+	// The wallet has no code yet, so the first message has to deploy it.
+	if out.config.publicKey, err = decodePublicKey(wallet.PublicKey); err != nil {
+		return nil, fmt.Errorf("ton: wallet %s public key: %w", wallet.ID, err)
+	}
+	derived, err := out.config.address(addr.Workchain)
+	if err != nil {
+		return nil, fmt.Errorf("ton: derive wallet address: %w", err)
+	}
+	if !derived.equal(addr) {
+		return nil, fmt.Errorf("ton: wallet address %s is not a %s wallet for its public key (expected %s)", wallet.Address, h.walletVersion, derived)
+	}
+	if out.init, err = out.config.stateInit(); err != nil {
+		return nil, fmt.Errorf("ton: build state init: %w", err)
+	}
+	return out, nil
+}
 
-	// Sign via Privy raw_sign
-	hashHex := "0x" + hex.EncodeToString(hash[:])
-	signResp, err := h.client.RawSign(ctx, walletID, hashHex)
+// signingMessage builds the cell the wallet verifies the signature over,
+// valid for messageTTL.
+func (o *outgoing) signingMessage(msg *cell) (*cell, error) {
+	o.validUntil = time.Now().Add(messageTTL).Truncate(time.Second)
+	signing, err := o.config.signingMessage(uint32(o.seqno), uint32(o.validUntil.Unix()), defaultSendMode, msg)
 	if err != nil {
-		return "", fmt.Errorf("ton: sign transaction: %w", err)
+		return nil, fmt.Errorf("ton: build signing message: %w", err)
+	}
+	return signing, nil
+}
+
+// send signs msg with the wallet's key via Privy, wraps it in an external
+// message to the wallet and broadcasts it. It returns the hex hash of the
+// external message.
+func (h *Helper) send(ctx context.Context, wallet *privy.Wallet, msg *cell) (string, error) {
+	out, err := h.prepare(ctx, wallet)
+	if err != nil {
+		return "", err
+	}
+	signing, err := out.signingMessage(msg)
+	if err != nil {
+		return "", err
 	}
 
-	// Decode signature
+	// Sign the cell hash via Privy raw_sign; Ed25519 signs the 32 bytes as is.
+	hashHex := "0x" + hex.EncodeToString(signing.hash())
+	signCtx, signSpan := h.client.StartSpan(ctx, "ton.sign")
+	signResp, err := h.client.RawSign(signCtx, wallet.ID, hashHex)
+	signSpan.End(err)
+	if err != nil {
+		return "", fmt.Errorf("ton: sign transaction: %w", err)
+	}
 	sigBytes, err := decodeHex(signResp.Data.Signature)
 	if err != nil {
 		return "", fmt.Errorf("ton: decode signature: %w", err)
 	}
 
-	// Build external message: signature + signing message
-	externalBody := append(sigBytes, signingMsg...)
-
-	// Encode as BOC and broadcast
-	boc := base64.StdEncoding.EncodeToString(externalBody)
-	msgHash, err := h.sendBoc(ctx, boc)
+	body, err := out.config.signedBody(signing, sigBytes)
 	if err != nil {
+		return "", fmt.Errorf("ton: build body: %w", err)
+	}
+	ext, err := externalMessage(out.address, out.init, body)
+	if err != nil {
+		return "", fmt.Errorf("ton: build external message: %w", err)
+	}
+	if err := h.sendBoc(ctx, serializeBOC(ext)); err != nil {
 		return "", fmt.Errorf("ton: send boc: %w", err)
 	}
 
+	msgHash := hex.EncodeToString(ext.hash())
+	h.mu.Lock()
+	for hash, validUntil := range h.expiry {
+		if time.Since(validUntil) > indexerDelay {
+			delete(h.expiry, hash)
+		}
+	}
+	h.expiry[msgHash] = out.validUntil
+	h.mu.Unlock()
+
 	return msgHash, nil
 }
 
-// buildInternalMessage builds a simple internal transfer message.
-func buildInternalMessage(destAddr string, amount uint64) []byte {
-	var buf bytes.Buffer
-
-	// Simplified internal message structure
-	// In production, use tonutils-go for proper Cell/BOC encoding
-	buf.Write([]byte(destAddr))
-	amountBytes := make([]byte, 8)
-	binary.BigEndian.PutUint64(amountBytes, amount)
-	buf.Write(amountBytes)
+// Balance returns the balance of address in nanotons.
+func (h *Helper) Balance(ctx context.Context, address string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "ton.balance")
+	defer func() { span.End(err) }()
 
-	return buf.Bytes()
+	var balance string
+	if err := h.get(ctx, "/getAddressBalance?address="+url.QueryEscape(address), &balance); err != nil {
+		return "", fmt.Errorf("ton: get balance: %w", err)
+	}
+	return balance, nil
 }
 
-// buildSigningMessage builds the v4r2 wallet signing message.
-func buildSigningMessage(walletID int64, seqno int64, validUntil int64, internalMsg []byte) []byte {
-	var buf bytes.Buffer
+// ValidateAddress checks that address is a raw or user-friendly TON address
+// with a valid checksum.
+func (h *Helper) ValidateAddress(address string) error {
+	if _, err := parseAddress(address); err != nil {
+		return fmt.Errorf("%w: %q: %v", chains.ErrInvalidAddress, address, err)
+	}
+	return nil
+}
 
-	// wallet_id (4 bytes, big-endian)
-	walletIDBytes := make([]byte, 4)
-	binary.BigEndian.PutUint32(walletIDBytes, uint32(walletID))
-	buf.Write(walletIDBytes)
+// EstimateFee asks the API to emulate the wallet's external message and
+// returns the total of the source fees in nanotons.
+func (h *Helper) EstimateFee(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "ton.estimate_fee", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
 
-	// valid_until (4 bytes, big-endian)
-	validBytes := make([]byte, 4)
-	binary.BigEndian.PutUint32(validBytes, uint32(validUntil))
-	buf.Write(validBytes)
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("ton: get wallet: %w", err)
+	}
+	msg, err := h.buildTransferMessage(destination, amount, "")
+	if err != nil {
+		return "", err
+	}
+	out, err := h.prepare(ctx, wallet)
+	if err != nil {
+		return "", err
+	}
+	signing, err := out.signingMessage(msg)
+	if err != nil {
+		return "", err
+	}
 
-	// seqno (4 bytes, big-endian)
-	seqnoBytes := make([]byte, 4)
-	binary.BigEndian.PutUint32(seqnoBytes, uint32(seqno))
-	buf.Write(seqnoBytes)
+	// The signature is not checked, so a zero signature stands in for it.
+	body, err := out.config.signedBody(signing, make([]byte, 64))
+	if err != nil {
+		return "", fmt.Errorf("ton: build body: %w", err)
+	}
+	req := map[string]any{
+		"address":       wallet.Address,
+		"body":          base64.StdEncoding.EncodeToString(serializeBOC(body)),
+		"ignore_chksig": true,
+	}
+	if out.init != nil {
+		req["init_code"] = base64.StdEncoding.EncodeToString(serializeBOC(out.init.refs[0]))
+		req["init_data"] = base64.StdEncoding.EncodeToString(serializeBOC(out.init.refs[1]))
+	}
 
-	// op code (1 byte) - 0 for simple transfer
-	buf.WriteByte(0)
+	var result struct {
+		SourceFees struct {
+			InFwdFee   int64 `json:"in_fwd_fee"`
+			StorageFee int64 `json:"storage_fee"`
+			GasFee     int64 `json:"gas_fee"`
+			FwdFee     int64 `json:"fwd_fee"`
+		} `json:"source_fees"`
+	}
+	if err := h.post(ctx, "/estimateFee", req, &result); err != nil {
+		return "", fmt.Errorf("ton: estimate fee: %w", err)
+	}
+	fees := result.SourceFees
+	return strconv.FormatInt(fees.InFwdFee+fees.StorageFee+fees.GasFee+fees.FwdFee, 10), nil
+}
 
-	// send mode (1 byte) - 3 = pay fees separately
-	buf.WriteByte(3)
+// WaitForConfirmation waits for the wallet to process an external message,
+// identified by the hash Transfer returns, and for the transfers it sends
+// to be processed. It looks the messages up in the indexer. A wallet
+// transaction that failed or skipped a transfer, or a transfer that
+// bounced, is reported as chains.ErrTransactionFailed, as is a message this
+// helper sent that was not processed before it expired. Messages sent by
+// other helpers are waited for until ctx is done.
+func (h *Helper) WaitForConfirmation(ctx context.Context, txHash string) (err error) {
+	ctx, span := h.client.StartSpan(ctx, "ton.wait_for_confirmation")
+	defer func() { span.End(err) }()
+
+	h.mu.Lock()
+	validUntil, sent := h.expiry[txHash]
+	h.mu.Unlock()
+
+	err = chains.Poll(ctx, h.pollInterval, func(ctx context.Context) (bool, error) {
+		tx, err := h.transactionByMessage(ctx, txHash)
+		if err != nil {
+			return false, fmt.Errorf("ton: get transaction: %w", err)
+		}
+		if tx == nil {
+			if sent && time.Since(validUntil) > indexerDelay {
+				return true, fmt.Errorf("ton: %w: message %s expired at %s without being processed", chains.ErrTransactionFailed, txHash, validUntil.UTC().Format(time.RFC3339))
+			}
+			return false, nil
+		}
+		if d := tx.Description; d.Aborted || !d.ComputePhase.Success || !d.Action.Success || d.Action.SkippedActions > 0 {
+			return true, fmt.Errorf("ton: %w: wallet transaction %s did not send its transfers", chains.ErrTransactionFailed, tx.Hash)
+		}
 
-	// internal message
-	buf.Write(internalMsg)
+		// A transfer bounces when the destination transaction aborts.
+		for _, out := range tx.OutMsgs {
+			if out.Destination == "" {
+				continue // external out message
+			}
+			destTx, err := h.transactionByMessage(ctx, out.Hash)
+			if err != nil {
+				return false, fmt.Errorf("ton: get transaction: %w", err)
+			}
+			if destTx == nil {
+				return false, nil
+			}
+			if destTx.Description.Aborted && out.Bounce {
+				return true, fmt.Errorf("ton: %w: transfer to %s bounced", chains.ErrTransactionFailed, out.Destination)
+			}
+		}
+		return true, nil
+	})
+	if ctx.Err() == nil {
+		h.mu.Lock()
+		delete(h.expiry, txHash)
+		h.mu.Unlock()
+	}
+	return err
+}
 
-	return buf.Bytes()
+// indexedTransaction is a transaction as returned by the indexer.
+type indexedTransaction struct {
+	Hash        string `json:"hash"`
+	Description struct {
+		Aborted      bool `json:"aborted"`
+		ComputePhase struct {
+			Success bool `json:"success"`
+		} `json:"compute_ph"`
+		Action struct {
+			Success        bool `json:"success"`
+			SkippedActions int  `json:"skipped_actions"`
+		} `json:"action"`
+	} `json:"description"`
+	OutMsgs []struct {
+		Hash        string `json:"hash"`
+		Destination string `json:"destination"`
+		Bounce      bool   `json:"bounce"`
+	} `json:"out_msgs"`
 }
 
-// getSeqno gets the wallet sequence number from the TON API.
-func (h *Helper) getSeqno(ctx context.Context, address string) (int64, error) {
-	url := fmt.Sprintf("%s/getWalletInformation?address=%s", h.rpcURL, address)
+// transactionByMessage looks up the transaction that processed the
+// message with msgHash (hex or base64) in the indexer. It returns nil if
+// the message has not been processed yet.
+func (h *Helper) transactionByMessage(ctx context.Context, msgHash string) (_ *indexedTransaction, err error) {
+	ctx, span := h.client.StartSpan(ctx, "ton.get_transaction")
+	defer func() { span.End(err) }()
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", h.indexerURL+"/transactionsByMessage?direction=in&msg_hash="+url.QueryEscape(msgHash), nil)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-
 	resp, err := h.httpClient.Do(req)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return 0, err
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case resp.StatusCode != http.StatusOK:
+		var body struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+		return nil, fmt.Errorf("indexer returned %s: %s", resp.Status, body.Error)
 	}
 
-	var info walletInfo
-	if err := json.Unmarshal(body, &info); err != nil {
-		return 0, err
+	var result struct {
+		Transactions []*indexedTransaction `json:"transactions"`
 	}
-
-	if !info.OK {
-		return 0, fmt.Errorf("failed to get wallet info")
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse indexer response: %w", err)
+	}
+	if len(result.Transactions) == 0 {
+		return nil, nil
 	}
+	return result.Transactions[0], nil
+}
 
-	return info.Result.Seqno, nil
+// get calls a TON API GET endpoint and decodes the result field.
+func (h *Helper) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", h.rpcURL+path, nil)
+	if err != nil {
+		return err
+	}
+	return h.do(req, out)
 }
 
-// sendBoc broadcasts a serialized BOC message.
-func (h *Helper) sendBoc(ctx context.Context, boc string) (string, error) {
-	reqBody := map[string]string{"boc": boc}
+// post calls a TON API POST endpoint and decodes the result field.
+func (h *Helper) post(ctx context.Context, path string, reqBody any, out any) error {
 	body, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", err
+		return err
 	}
-
-	url := h.rpcURL + "/sendBoc"
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", h.rpcURL+path, bytes.NewReader(body))
 	if err != nil {
-		return "", err
+		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
+	return h.do(req, out)
+}
 
+func (h *Helper) do(req *http.Request, out any) error {
 	resp, err := h.httpClient.Do(req)
 	if err != nil {
-		return "", err
+		return err
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	var result struct {
-		OK     bool `json:"ok"`
-		Result struct {
-			Hash string `json:"hash"`
-		} `json:"result"`
+		OK     bool            `json:"ok"`
+		Result json.RawMessage `json:"result"`
+		Error  string          `json:"error"`
 	}
 	if err := json.Unmarshal(respBody, &result); err != nil {
-		return "", err
+		return fmt.Errorf("failed to parse API response: %w", err)
 	}
-
 	if !result.OK {
-		return "", fmt.Errorf("sendBoc failed: %s", string(respBody))
+		return fmt.Errorf("API error: %s", result.Error)
 	}
+	return json.Unmarshal(result.Result, out)
+}
+
+// getWalletInfo gets the wallet seqno and account state from the TON API.
+func (h *Helper) getWalletInfo(ctx context.Context, address string) (_ *walletInfoResult, err error) {
+	ctx, span := h.client.StartSpan(ctx, "ton.get_seqno")
+	defer func() { span.End(err) }()
+
+	var info walletInfoResult
+	if err := h.get(ctx, "/getWalletInformation?address="+url.QueryEscape(address), &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// sendBoc broadcasts a serialized BOC message.
+func (h *Helper) sendBoc(ctx context.Context, boc []byte) (err error) {
+	ctx, span := h.client.StartSpan(ctx, "ton.broadcast")
+	defer func() { span.End(err) }()
+
+	var result json.RawMessage
+	return h.post(ctx, "/sendBoc", map[string]string{"boc": base64.StdEncoding.EncodeToString(boc)}, &result)
+}
+
+// parseAmount parses a non-negative decimal amount of nanotons.
+func parseAmount(amount string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(amount, 10)
+	if !ok || n.Sign() < 0 {
+		return nil, fmt.Errorf("ton: invalid amount %q", amount)
+	}
+	return n, nil
+}
 
-	// Return hash of the signing message as the tx identifier
-	hash := sha256.Sum256([]byte(boc))
-	return hex.EncodeToString(hash[:]), nil
+// decodePublicKey decodes a hex Ed25519 public key, stripping the 0x00
+// scheme prefix Privy may add.
+func decodePublicKey(s string) ([]byte, error) {
+	if s == "" {
+		return nil, fmt.Errorf("no public key")
+	}
+	b, err := decodeHex(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 33 && b[0] == 0x00 {
+		b = b[1:]
+	}
+	return b, nil
 }
 
 func decodeHex(s string) ([]byte, error) {
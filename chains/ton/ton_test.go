@@ -1,13 +1,24 @@
 package ton
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
 )
 
 func TestNewHelper(t *testing.T) {
@@ -43,74 +54,497 @@ func TestNewHelper_WithHTTPClient(t *testing.T) {
 	}
 }
 
-func TestBuildSigningMessage(t *testing.T) {
-	msg := buildSigningMessage(698983191, 1, 1700000000, []byte{0x01, 0x02})
-	// 4(walletID) + 4(validUntil) + 4(seqno) + 1(op) + 1(sendMode) + 2(internalMsg) = 16 bytes
-	if len(msg) != 16 {
-		t.Errorf("Expected signing message length 16, got %d", len(msg))
-	}
+// tonNode is a mock TON HTTP API that reports a wallet state and records the
+// messages sent to it.
+type tonNode struct {
+	*httptest.Server
+	state string
+	seqno int
+	bocs  [][]byte
+
+	// jettonWallet is returned by get_wallet_address; getMethodStack
+	// records the stack the method was called with.
+	jettonWallet   *address
+	getMethodStack [][]string
 }
 
-func TestTransfer_WithMockServer(t *testing.T) {
-	// Mock TON API
-	tonServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+func newTONNode(t *testing.T, state string, seqno int) *tonNode {
+	t.Helper()
+	n := &tonNode{state: state, seqno: seqno}
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case "/getWalletInformation":
-			json.NewEncoder(w).Encode(map[string]any{
-				"ok": true,
-				"result": map[string]any{
-					"wallet":        true,
-					"balance":       "1000000000",
-					"seqno":         5,
-					"account_state": "active",
-				},
-			})
+			json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": map[string]any{
+				"wallet": n.state == "active", "seqno": n.seqno, "account_state": n.state,
+			}})
 		case "/sendBoc":
-			json.NewEncoder(w).Encode(map[string]any{
-				"ok": true,
-				"result": map[string]any{
-					"hash": "abc123",
-				},
-			})
+			var req struct {
+				BOC string `json:"boc"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			boc, err := base64.StdEncoding.DecodeString(req.BOC)
+			if err != nil {
+				t.Errorf("boc is not base64: %v", err)
+			}
+			n.bocs = append(n.bocs, boc)
+			json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": map[string]any{"@type": "ok"}})
+		case "/runGetMethod":
+			var req struct {
+				Method string     `json:"method"`
+				Stack  [][]string `json:"stack"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Method != "get_wallet_address" || n.jettonWallet == nil {
+				json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": map[string]any{"exit_code": 11, "stack": []any{}}})
+				return
+			}
+			n.getMethodStack = req.Stack
+			slice, _ := newBuilder().storeAddress(n.jettonWallet).endCell()
+			json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": map[string]any{
+				"exit_code": 0,
+				"stack":     []any{[]any{"cell", map[string]any{"bytes": base64.StdEncoding.EncodeToString(serializeBOC(slice))}}},
+			}})
 		default:
 			http.NotFound(w, r)
 		}
 	}))
+	t.Cleanup(n.Close)
+	return n
+}
+
+// sentMessage parses the last BOC sent to the node into the external
+// message's init (nil when absent) and body cells.
+func (n *tonNode) lastMessage(t *testing.T) (init, body *cell) {
+	t.Helper()
+	if len(n.bocs) == 0 {
+		t.Fatal("no message was sent")
+	}
+	ext, err := parseBOC(n.bocs[len(n.bocs)-1])
+	if err != nil {
+		t.Fatalf("parse boc: %v", err)
+	}
+	if len(ext.refs) == 2 {
+		return ext.refs[0], ext.refs[1]
+	}
+	return nil, ext.refs[0]
+}
+
+// bitRange copies bits [from, to) of c into a new cell, with all of c's
+// references when keepRefs is set.
+func bitRange(c *cell, from, to int, keepRefs bool) *cell {
+	b := newBuilder()
+	for i := from; i < to; i++ {
+		b.storeBit(c.data[i/8]&(0x80>>(i%8)) != 0)
+	}
+	if keepRefs {
+		for _, ref := range c.refs {
+			b.storeRef(ref)
+		}
+	}
+	out, _ := b.endCell()
+	return out
+}
+
+func walletPublicKey(t *testing.T, wallet *privy.Wallet) ed25519.PublicKey {
+	t.Helper()
+	pub, err := decodePublicKey(wallet.PublicKey)
+	if err != nil {
+		t.Fatalf("decode public key: %v", err)
+	}
+	return pub
+}
+
+func TestTransfer_WithMockServer(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeTon})
+
+	node := newTONNode(t, "active", 5)
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	dest := "EQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p4q2"
+	txHash, err := h.Transfer(context.Background(), wallet.ID, dest, "1000000000")
+	if err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+
+	init, body := node.lastMessage(t)
+	if init != nil {
+		t.Error("Expected no StateInit for an active wallet")
+	}
+	ext, _ := parseBOC(node.bocs[0])
+	if txHash != hex.EncodeToString(ext.hash()) {
+		t.Errorf("Expected the external message hash, got %s", txHash)
+	}
+
+	// v4r2: signature(512) wallet_id(32) valid_until(32) seqno(32) op(8) mode(8) ^msg
+	sig := body.data[:64]
+	signing := bitRange(body, 512, body.bits, true)
+	if !ed25519.Verify(walletPublicKey(t, wallet), signing.hash(), sig) {
+		t.Error("Signature does not verify against the wallet public key")
+	}
+	if got := binary.BigEndian.Uint32(signing.data[0:4]); got != defaultV4WalletID {
+		t.Errorf("Expected wallet ID %d, got %d", defaultV4WalletID, got)
+	}
+	if got := binary.BigEndian.Uint32(signing.data[8:12]); got != 5 {
+		t.Errorf("Expected seqno 5, got %d", got)
+	}
+
+	destAddr, _ := parseAddress(dest)
+	want, _ := internalMessage(destAddr, big.NewInt(1000000000), nil)
+	if !bytes.Equal(signing.refs[0].hash(), want.hash()) {
+		t.Error("Internal message does not match the expected transfer")
+	}
+}
+
+func TestTransfer_DeploysWallet(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeTon})
+
+	node := newTONNode(t, "uninitialized", 0)
+	h := NewHelper(client, WithRPCURL(node.URL))
+	if _, err := h.Transfer(context.Background(), wallet.ID, "EQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p4q2", "1"); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+
+	init, _ := node.lastMessage(t)
+	if init == nil {
+		t.Fatal("Expected a StateInit to deploy the wallet")
+	}
+	addr, _ := parseAddress(wallet.Address)
+	if !bytes.Equal(init.hash(), addr.Hash[:]) {
+		t.Error("StateInit does not hash to the wallet address")
+	}
+
+	// The same wallet is not a v5r1 wallet, so deploying it as one must fail.
+	h = NewHelper(client, WithRPCURL(node.URL), WithWalletVersion(WalletV5R1))
+	if _, err := h.Transfer(context.Background(), wallet.ID, "EQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p4q2", "1"); err == nil {
+		t.Error("Expected an error for a wallet address that does not match the version")
+	}
+}
+
+func TestTransferWithComment_V5R1(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeTon})
+
+	node := newTONNode(t, "active", 2)
+	h := NewHelper(client, WithRPCURL(node.URL), WithWalletVersion(WalletV5R1))
+	dest := "UQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p9dz" // non-bounceable
+	if _, err := h.TransferWithComment(context.Background(), wallet.ID, dest, "500", "thanks!"); err != nil {
+		t.Fatalf("TransferWithComment failed: %v", err)
+	}
+
+	// v5r1: op(32) wallet_id(32) valid_until(32) seqno(32) maybe_actions(1) extended(1) signature(512)
+	_, body := node.lastMessage(t)
+	signing := bitRange(body, 0, body.bits-512, true)
+	sig := bitRange(body, body.bits-512, body.bits, false).data
+	if !ed25519.Verify(walletPublicKey(t, wallet), signing.hash(), sig) {
+		t.Error("Signature does not verify against the wallet public key")
+	}
+	if got := binary.BigEndian.Uint32(signing.data[0:4]); got != opV5SignedExternal {
+		t.Errorf("Expected signed external opcode, got %#x", got)
+	}
+	if got := binary.BigEndian.Uint32(signing.data[4:8]); got != v5WalletID(mainnetGlobalID, 0) {
+		t.Errorf("Expected the mainnet v5 wallet ID, got %d", got)
+	}
+
+	destAddr, _ := parseAddress(dest)
+	comment, _ := commentBody("thanks!")
+	want, _ := internalMessage(destAddr, big.NewInt(500), comment)
+	actions := signing.refs[0]
+	if !bytes.Equal(actions.refs[1].hash(), want.hash()) {
+		t.Error("Internal message does not match the expected transfer")
+	}
+	if destAddr.Bounceable {
+		t.Error("Expected UQ... address to be non-bounceable")
+	}
+}
+
+func TestTransferJetton(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeTon})
+
+	node := newTONNode(t, "active", 1)
+	node.jettonWallet, _ = parseAddress("0:" + strings.Repeat("ab", 32))
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	master := "EQCxE6mUtQJKFnGfaROTKOt1lZbDiiX1kCixRv7Nw2Id_sDs"
+	dest := "UQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p9dz"
+	if _, err := h.TransferJetton(context.Background(), wallet.ID, master, dest, "2500000", "1", "order 7"); err != nil {
+		t.Fatalf("TransferJetton failed: %v", err)
+	}
+
+	// get_wallet_address is called with the owner's address as a slice.
+	owner, _ := parseAddress(wallet.Address)
+	if len(node.getMethodStack) != 1 || node.getMethodStack[0][0] != "tvm.Slice" {
+		t.Fatalf("Unexpected get-method stack %v", node.getMethodStack)
+	}
+	boc, _ := base64.StdEncoding.DecodeString(node.getMethodStack[0][1])
+	slice, err := parseBOC(boc)
+	if err != nil {
+		t.Fatalf("parse owner slice: %v", err)
+	}
+	if got, err := loadAddress(slice); err != nil || !got.equal(owner) {
+		t.Errorf("Expected owner %s in get-method stack, got %v (%v)", owner, got, err)
+	}
+
+	_, body := node.lastMessage(t)
+	sig := body.data[:64]
+	signing := bitRange(body, 512, body.bits, true)
+	if !ed25519.Verify(walletPublicKey(t, wallet), signing.hash(), sig) {
+		t.Error("Signature does not verify against the wallet public key")
+	}
+
+	transfer := signing.refs[0].refs[0]
+	if op := binary.BigEndian.Uint32(transfer.data[0:4]); op != opJettonTransfer {
+		t.Fatalf("Expected jetton transfer opcode, got %#x", op)
+	}
+	queryID := binary.BigEndian.Uint64(transfer.data[4:12])
+	destAddr, _ := parseAddress(dest)
+	comment, _ := commentBody("order 7")
+	wantBody, _ := jettonTransferBody(queryID, big.NewInt(2500000), destAddr, owner, big.NewInt(1), comment)
+	wantMsg, _ := internalMessage(node.jettonWallet, big.NewInt(jettonTransferGas+1), wantBody)
+	if !bytes.Equal(signing.refs[0].hash(), wantMsg.hash()) {
+		t.Error("Internal message does not match the expected jetton transfer")
+	}
+}
+
+func TestTransferJetton_GetMethodFails(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeTon})
+
+	node := newTONNode(t, "active", 1)
+	h := NewHelper(client, WithRPCURL(node.URL))
+	_, err := h.TransferJetton(context.Background(), wallet.ID, "EQCxE6mUtQJKFnGfaROTKOt1lZbDiiX1kCixRv7Nw2Id_sDs", "UQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p9dz", "1", "", "")
+	if err == nil {
+		t.Fatal("Expected error when get_wallet_address fails")
+	}
+	if len(node.bocs) != 0 {
+		t.Error("Expected nothing to be sent")
+	}
+}
+
+func TestWalletAddress(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeTon})
+
+	cfg := walletConfig{version: WalletV4R2, walletID: defaultV4WalletID, publicKey: walletPublicKey(t, wallet)}
+	derived, err := cfg.address(0)
+	if err != nil {
+		t.Fatalf("address failed: %v", err)
+	}
+	addr, _ := parseAddress(wallet.Address)
+	if !derived.equal(addr) {
+		t.Errorf("Derived %s, privytest wallet is %s", derived, wallet.Address)
+	}
+
+	if id := v5WalletID(mainnetGlobalID, 0); id != 2147483409 {
+		t.Errorf("Expected mainnet v5 wallet ID 2147483409, got %d", id)
+	}
+	if id := v5WalletID(testnetGlobalID, 0); id != 2147483645 {
+		t.Errorf("Expected testnet v5 wallet ID 2147483645, got %d", id)
+	}
+}
+
+func TestAddressString(t *testing.T) {
+	for _, s := range []string{
+		"EQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p4q2",
+		"UQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p9dz",
+	} {
+		addr, err := parseAddress(s)
+		if err != nil {
+			t.Fatalf("parseAddress(%q) failed: %v", s, err)
+		}
+		if got := addr.String(); got != s {
+			t.Errorf("String() = %s, want %s", got, s)
+		}
+	}
+}
+
+func TestBalance(t *testing.T) {
+	tonServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/getAddressBalance" || r.URL.Query().Get("address") == "" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": "2500000000"})
+	}))
 	defer tonServer.Close()
 
-	// Mock Privy API
-	privyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		switch {
-		case r.Method == "GET" && r.URL.Path == "/v1/wallets/wallet-123":
-			json.NewEncoder(w).Encode(map[string]any{
-				"id":         "wallet-123",
-				"address":    "EQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p4q2",
-				"chain_type": "ton",
-			})
-		case r.Method == "POST" && r.URL.Path == "/v1/wallets/wallet-123/raw_sign":
-			json.NewEncoder(w).Encode(map[string]any{
-				"method": "raw_sign",
-				"data": map[string]any{
-					"signature": "0xaabbccddeeff00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff00112233445566778899",
-					"encoding":  "hex",
-				},
-			})
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(tonServer.URL))
+	balance, err := h.Balance(context.Background(), "EQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p4q2")
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if balance != "2500000000" {
+		t.Errorf("Expected 2500000000, got %s", balance)
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"))
+
+	valid := []string{
+		"EQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p4q2",
+		"0:ed1691307050047117b998b561d8de82d31fbf84910ced6eb5fc92e7485ef8a7",
+		"-1:ed1691307050047117b998b561d8de82d31fbf84910ced6eb5fc92e7485ef8a7",
+	}
+	for _, addr := range valid {
+		if err := h.ValidateAddress(addr); err != nil {
+			t.Errorf("ValidateAddress(%q) = %v, want nil", addr, err)
+		}
+	}
+	invalid := []string{
+		"",
+		"EQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p4q3", // bad checksum
+		"0:ed16",
+		"EQDest...",
+	}
+	for _, addr := range invalid {
+		if err := h.ValidateAddress(addr); !errors.Is(err, chains.ErrInvalidAddress) {
+			t.Errorf("ValidateAddress(%q) = %v, want ErrInvalidAddress", addr, err)
+		}
+	}
+}
+
+func TestEstimateFee(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeTon})
+
+	var estimateReq map[string]any
+	tonServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/getWalletInformation":
+			json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": map[string]any{"wallet": true, "seqno": 3}})
+		case "/estimateFee":
+			json.NewDecoder(r.Body).Decode(&estimateReq)
+			json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": map[string]any{
+				"source_fees": map[string]any{"in_fwd_fee": 1000, "storage_fee": 20, "gas_fee": 3000000, "fwd_fee": 400000},
+			}})
 		default:
 			http.NotFound(w, r)
 		}
 	}))
-	defer privyServer.Close()
-
-	client := privy.NewClient("test-app-id", "test-app-secret",
-		privy.WithBaseURL(privyServer.URL+"/v1"))
+	defer tonServer.Close()
 
 	h := NewHelper(client, WithRPCURL(tonServer.URL))
+	fee, err := h.EstimateFee(context.Background(), wallet.ID, "EQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p4q2", "1000000000")
+	if err != nil {
+		t.Fatalf("EstimateFee failed: %v", err)
+	}
+	if fee != "3401020" {
+		t.Errorf("Expected 3401020, got %s", fee)
+	}
+	if estimateReq["address"] != wallet.Address || estimateReq["ignore_chksig"] != true {
+		t.Errorf("unexpected estimateFee request %v", estimateReq)
+	}
+}
 
-	txHash, err := h.Transfer(context.Background(), "wallet-123", "EQDest...", "1000000000")
+// indexerTx builds an indexer transaction with one outgoing transfer to
+// dest (none when dest is empty).
+func indexerTx(hash string, aborted bool, dest, outHash string) map[string]any {
+	tx := map[string]any{
+		"hash": hash,
+		"description": map[string]any{
+			"aborted":    aborted,
+			"compute_ph": map[string]any{"success": !aborted},
+			"action":     map[string]any{"success": !aborted, "skipped_actions": 0},
+		},
+		"out_msgs": []any{},
+	}
+	if dest != "" {
+		tx["out_msgs"] = []any{map[string]any{"hash": outHash, "destination": dest, "bounce": true}}
+	}
+	return tx
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeTon})
+
+	// Transactions by inbound message hash; the wallet transaction of a
+	// sent message appears on the third lookup.
+	txs := map[string]map[string]any{
+		"transfer":         indexerTx("dest-tx", false, "", ""),
+		"bounced-message":  indexerTx("wallet-tx", false, "0:"+strings.Repeat("ab", 32), "bounced-transfer"),
+		"bounced-transfer": indexerTx("bounced-tx", true, "", ""),
+	}
+	sent, lookups := "", 0
+	node := newTONNode(t, "active", 5)
+	indexer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/transactionsByMessage" || r.URL.Query().Get("direction") != "in" {
+			http.NotFound(w, r)
+			return
+		}
+		hash := r.URL.Query().Get("msg_hash")
+		if hash == sent {
+			if lookups++; lookups >= 3 {
+				txs[hash] = indexerTx("wallet-tx", false, "0:"+strings.Repeat("ab", 32), "transfer")
+			}
+		}
+		found := []any{}
+		if tx, ok := txs[hash]; ok {
+			found = append(found, tx)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"transactions": found})
+	}))
+	defer indexer.Close()
+
+	h := NewHelper(client, WithRPCURL(node.URL), WithIndexerURL(indexer.URL), WithPollInterval(time.Millisecond))
+	hash, err := h.Transfer(context.Background(), wallet.ID, "EQDtFpEwcFAEcRe5mLVh2N6C0x-_hJEM7W61_JLnSF74p4q2", "1000")
 	if err != nil {
 		t.Fatalf("Transfer failed: %v", err)
 	}
-	if txHash == "" {
-		t.Error("Expected non-empty transaction hash")
+	sent = hash
+	if err := h.WaitForConfirmation(context.Background(), hash); err != nil {
+		t.Fatalf("WaitForConfirmation failed: %v", err)
+	}
+	if lookups != 3 {
+		t.Errorf("Expected the message to be looked up until it was processed, got %d lookups", lookups)
+	}
+	if _, ok := h.expiry[hash]; ok {
+		t.Error("Expected the message to be forgotten once awaited")
+	}
+
+	// A message sent elsewhere is looked up the same way.
+	other := NewHelper(client, WithIndexerURL(indexer.URL), WithPollInterval(time.Millisecond))
+	if err := other.WaitForConfirmation(context.Background(), hash); err != nil {
+		t.Errorf("WaitForConfirmation of another helper's message failed: %v", err)
+	}
+
+	if err := h.WaitForConfirmation(context.Background(), "bounced-message"); !errors.Is(err, chains.ErrTransactionFailed) {
+		t.Errorf("Expected ErrTransactionFailed for a bounced transfer, got %v", err)
+	}
+
+	h.expiry["dropped"] = time.Now().Add(-indexerDelay - time.Second)
+	if err := h.WaitForConfirmation(context.Background(), "dropped"); !errors.Is(err, chains.ErrTransactionFailed) {
+		t.Errorf("Expected ErrTransactionFailed for an expired message, got %v", err)
+	}
+}
+
+func TestTransactionByMessage_Status(t *testing.T) {
+	status := http.StatusNotFound
+	indexer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte("<html>proxy error</html>"))
+	}))
+	defer indexer.Close()
+
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithIndexerURL(indexer.URL))
+	tx, err := h.transactionByMessage(context.Background(), "msg")
+	if tx != nil || err != nil {
+		t.Errorf("Expected a 404 to mean not yet indexed, got %v, %v", tx, err)
+	}
+
+	status = http.StatusBadGateway
+	if _, err := h.transactionByMessage(context.Background(), "msg"); err == nil || !strings.Contains(err.Error(), "502") {
+		t.Errorf("Expected an error naming the status, got %v", err)
 	}
 }
@@ -0,0 +1,257 @@
+package ton
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+// WalletVersion selects the wallet contract the Privy wallet's address
+// belongs to. It decides the layout of signed messages and the StateInit
+// used to deploy the wallet.
+type WalletVersion int
+
+const (
+	// WalletV4R2 is the v4r2 wallet contract, the default.
+	WalletV4R2 WalletVersion = iota
+	// WalletV5R1 is the v5r1 ("W5") wallet contract.
+	WalletV5R1
+)
+
+func (v WalletVersion) String() string {
+	switch v {
+	case WalletV4R2:
+		return "v4r2"
+	case WalletV5R1:
+		return "v5r1"
+	default:
+		return fmt.Sprintf("WalletVersion(%d)", int(v))
+	}
+}
+
+const (
+	// defaultV4WalletID is the subwallet ID of v4 wallets in workchain 0.
+	defaultV4WalletID = 698983191
+
+	// Network global IDs, mixed into v5 wallet IDs.
+	mainnetGlobalID = -239
+	testnetGlobalID = -3
+
+	// sendModePayFeesSeparately | sendModeIgnoreErrors.
+	defaultSendMode = 3
+
+	// Opcodes.
+	opV5SignedExternal = 0x7369676e // "sign"
+	opV5SendMessage    = 0x0ec3c86d // action_send_msg
+)
+
+// Wallet contract code, as published by the TON core team.
+var (
+	walletV4R2Code = mustParseHexBOC("b5ee9c72410214010002d4000114ff00f4a413f4bcf2c80b010201200203020148040504f8f28308d71820d31fd31fd31f02f823bbf264ed44d0d31fd31fd3fff404d15143baf2a15151baf2a205f901541064f910f2a3f80024a4c8cb1f5240cb1f5230cbff5210f400c9ed54f80f01d30721c0009f6c519320d74a96d307d402fb00e830e021c001e30021c002e30001c0039130e30d03a4c8cb1f12cb1fcbff1011121302e6d001d0d3032171b0925f04e022d749c120925f04e002d31f218210706c7567bd22821064737472bdb0925f05e003fa403020fa4401c8ca07cbffc9d0ed44d0810140d721f404305c810108f40a6fa131b3925f07e005d33fc8258210706c7567ba923830e30d03821064737472ba925f06e30d06070201200809007801fa00f40430f8276f2230500aa121bef2e0508210706c7567831eb17080185004cb0526cf1658fa0219f400cb6917cb1f5260cb3f20c98040fb0006008a5004810108f45930ed44d0810140d720c801cf16f400c9ed540172b08e23821064737472831eb17080185005cb055003cf1623fa0213cb6acb1fcb3fc98040fb00925f03e20201200a0b0059bd242b6f6a2684080a06b90fa0218470d4080847a4937d29910ce6903e9ff9837812801b7810148987159f31840201580c0d0011b8c97ed44d0d70b1f8003db29dfb513420405035c87d010c00b23281f2fff274006040423d029be84c600201200e0f0019adce76a26840206b90eb85ffc00019af1df6a26840106b90eb858fc0006ed207fa00d4d422f90005c8ca0715cbffc9d077748018c8cb05cb0222cf165005fa0214cb6b12ccccc973fb00c84014810108f451f2a7020070810108d718fa00d33fc8542047810108f451f2a782106e6f746570748018c8cb05cb025006cf165004fa0214cb6a12cb1fcb3fc973fb0002006c810108d718fa00d33f305224810108f459f2a782106473747270748018c8cb05cb025005cf165003fa0213cb6acb1f12cb3fc973fb00000af400c9ed54696225e5")
+	walletV5R1Code = mustParseHexBOC("b5ee9c7241021401000281000114ff00f4a413f4bcf2c80b01020120020d020148030402dcd020d749c120915b8f6320d70b1f2082106578746ebd21821073696e74bdb0925f03e082106578746eba8eb48020d72101d074d721fa4030fa44f828fa443058bd915be0ed44d0810141d721f4058307f40e6fa1319130e18040d721707fdb3ce03120d749810280b99130e070e2100f020120050c020120060902016e07080019adce76a2684020eb90eb85ffc00019af1df6a2684010eb90eb858fc00201480a0b0017b325fb51341c75c875c2c7e00011b262fb513435c280200019be5f0f6a2684080a0eb90fa02c0102f20e011e20d70b1f82107369676ebaf2e08a7f0f01e68ef0eda2edfb218308d722028308d723208020d721d31fd31fd31fed44d0d200d31f20d31fd3ffd70a000af90140ccf9109a28945f0adb31e1f2c087df02b35007b0f2d0845125baf2e0855036baf2e086f823bbf2d0882292f800de01a47fc8ca00cb1f01cf16c9ed542092f80fde70db3cd81003f6eda2edfb02f404216e926c218e4c0221d73930709421c700b38e2d01d72820761e436c20d749c008f2e09320d74ac002f2e09320d71d06c712c2005230b0f2d089d74cd7393001a4e86c128407bbf2e093d74ac000f2e093ed55e2d20001c000915be0ebd72c08142091709601d72c081c12e25210b1e30f20d74a111213009601fa4001fa44f828fa443058baf2e091ed44d0810141d718f405049d7fc8ca0040048307f453f2e08b8e14038307f45bf2e08c22d70a00216e01b3b0f2d090e2c85003cf1612f400c9ed54007230d72c08248e2d21f2e092d200ed44d0d2005113baf2d08f54503091319c01810140d721d70a00f2e08ee2c8ca0058cf16c9ed5493f2c08de20010935bdb31e1d74cd0b4d6c35e")
+)
+
+func mustParseHexBOC(s string) *cell {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	c, err := parseBOC(b)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// walletConfig identifies a wallet contract instance.
+type walletConfig struct {
+	version   WalletVersion
+	walletID  uint32
+	publicKey []byte // only needed to build the StateInit
+}
+
+// v5WalletID returns the default v5r1 wallet ID for a network and workchain:
+// the network global ID XORed with the client context (subwallet 0).
+func v5WalletID(globalID int32, workchain int8) uint32 {
+	clientContext := uint32(1)<<31 | uint32(uint8(workchain))<<23
+	return uint32(globalID) ^ clientContext
+}
+
+func (w walletConfig) code() (*cell, error) {
+	switch w.version {
+	case WalletV4R2:
+		return walletV4R2Code, nil
+	case WalletV5R1:
+		return walletV5R1Code, nil
+	default:
+		return nil, fmt.Errorf("unsupported wallet version %s", w.version)
+	}
+}
+
+// data builds the initial persistent data of the wallet contract.
+func (w walletConfig) data() (*cell, error) {
+	if len(w.publicKey) != 32 {
+		return nil, fmt.Errorf("expected a 32-byte Ed25519 public key, got %d bytes", len(w.publicKey))
+	}
+	b := newBuilder()
+	switch w.version {
+	case WalletV4R2:
+		b.storeUint(0, 32) // seqno
+		b.storeUint(uint64(w.walletID), 32)
+		b.storeBytes(w.publicKey)
+		b.storeBit(false) // empty plugins dict
+	case WalletV5R1:
+		b.storeBit(true)   // is_signature_allowed
+		b.storeUint(0, 32) // seqno
+		b.storeUint(uint64(w.walletID), 32)
+		b.storeBytes(w.publicKey)
+		b.storeBit(false) // empty extensions dict
+	default:
+		return nil, fmt.Errorf("unsupported wallet version %s", w.version)
+	}
+	return b.endCell()
+}
+
+// stateInit builds the StateInit that deploys the wallet.
+func (w walletConfig) stateInit() (*cell, error) {
+	code, err := w.code()
+	if err != nil {
+		return nil, err
+	}
+	data, err := w.data()
+	if err != nil {
+		return nil, err
+	}
+	return newBuilder().
+		storeBit(false). // split_depth
+		storeBit(false). // special
+		storeMaybeRef(code).
+		storeMaybeRef(data).
+		storeBit(false). // library
+		endCell()
+}
+
+// address derives the wallet address in the given workchain from its
+// StateInit.
+func (w walletConfig) address(workchain int8) (*address, error) {
+	init, err := w.stateInit()
+	if err != nil {
+		return nil, err
+	}
+	addr := &address{Workchain: workchain, Bounceable: true}
+	copy(addr.Hash[:], init.hash())
+	return addr, nil
+}
+
+// signingMessage builds the cell whose hash the wallet owner signs to send
+// msg with the given send mode.
+func (w walletConfig) signingMessage(seqno, validUntil uint32, mode uint8, msg *cell) (*cell, error) {
+	b := newBuilder()
+	switch w.version {
+	case WalletV4R2:
+		b.storeUint(uint64(w.walletID), 32)
+		b.storeUint(uint64(validUntil), 32)
+		b.storeUint(uint64(seqno), 32)
+		b.storeUint(0, 8) // op: simple send
+		b.storeUint(uint64(mode), 8)
+		b.storeRef(msg)
+	case WalletV5R1:
+		empty, _ := newBuilder().endCell()
+		actions, err := newBuilder().
+			storeRef(empty). // prev: out_list_empty
+			storeUint(opV5SendMessage, 32).
+			storeUint(uint64(mode), 8).
+			storeRef(msg).
+			endCell()
+		if err != nil {
+			return nil, err
+		}
+		b.storeUint(opV5SignedExternal, 32)
+		b.storeUint(uint64(w.walletID), 32)
+		b.storeUint(uint64(validUntil), 32)
+		b.storeUint(uint64(seqno), 32)
+		b.storeMaybeRef(actions)
+		b.storeBit(false) // no extended actions
+	default:
+		return nil, fmt.Errorf("unsupported wallet version %s", w.version)
+	}
+	return b.endCell()
+}
+
+// signedBody attaches the signature where the wallet expects it: before the
+// signed data for v4, after it for v5.
+func (w walletConfig) signedBody(signing *cell, signature []byte) (*cell, error) {
+	if len(signature) != 64 {
+		return nil, fmt.Errorf("expected a 64-byte Ed25519 signature, got %d bytes", len(signature))
+	}
+	if w.version == WalletV5R1 {
+		return newBuilder().storeCell(signing).storeBytes(signature).endCell()
+	}
+	return newBuilder().storeBytes(signature).storeCell(signing).endCell()
+}
+
+// internalMessage builds a MessageRelaxed carrying amount nanotons and an
+// optional body to dest. Bounce follows the destination address flag.
+func internalMessage(dest *address, amount *big.Int, body *cell) (*cell, error) {
+	zero := new(big.Int)
+	return newBuilder().
+		storeBit(false). // int_msg_info$0
+		storeBit(true).  // ihr_disabled
+		storeBit(dest.Bounceable).
+		storeBit(false). // bounced
+		storeAddress(nil).
+		storeAddress(dest).
+		storeCoins(amount).
+		storeBit(false).  // no extra currencies
+		storeCoins(zero). // ihr_fee
+		storeCoins(zero). // fwd_fee
+		storeUint(0, 64). // created_lt
+		storeUint(0, 32). // created_at
+		storeBit(false).  // no init
+		storeMaybeRef(body).
+		endCell()
+}
+
+// externalMessage builds the inbound external message that delivers body to
+// the wallet, with init attached when the wallet still has to be deployed.
+func externalMessage(wallet *address, init *cell, body *cell) (*cell, error) {
+	b := newBuilder().
+		storeUint(0b10, 2). // ext_in_msg_info$10
+		storeAddress(nil).
+		storeAddress(wallet).
+		storeCoins(new(big.Int)) // import_fee
+	if init == nil {
+		b.storeBit(false)
+	} else {
+		b.storeBit(true).storeBit(true).storeRef(init)
+	}
+	return b.storeBit(true).storeRef(body).endCell()
+}
+
+// commentBody builds a text comment body (op 0 followed by the UTF-8
+// text), continuing in child cells when it does not fit in one.
+func commentBody(text string) (*cell, error) {
+	if text == "" {
+		return nil, nil
+	}
+	return snakeCell(append([]byte{0, 0, 0, 0}, text...))
+}
+
+// snakeCell stores data across a chain of cells, each referencing the next.
+func snakeCell(data []byte) (*cell, error) {
+	const chunk = maxCellBits / 8
+	var next *cell
+	for end := len(data); end > 0; {
+		start := (end - 1) / chunk * chunk
+		b := newBuilder().storeBytes(data[start:end])
+		if next != nil {
+			b.storeRef(next)
+		}
+		c, err := b.endCell()
+		if err != nil {
+			return nil, err
+		}
+		next, end = c, start
+	}
+	return next, nil
+}
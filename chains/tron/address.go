@@ -0,0 +1,51 @@
+package tron
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// addressPrefix is the version byte of Tron mainnet and testnet addresses.
+const addressPrefix = 0x41
+
+// decodeAddress decodes a Base58Check Tron address into its 21 bytes: the
+// 0x41 prefix followed by the 20-byte account ID.
+func decodeAddress(address string) ([]byte, error) {
+	decoded, err := base58Decode(address)
+	if err != nil || len(decoded) != 25 {
+		return nil, fmt.Errorf("%q is not a Base58Check Tron address", address)
+	}
+	if decoded[0] != addressPrefix {
+		return nil, fmt.Errorf("%q has version byte 0x%02x, want 0x41", address, decoded[0])
+	}
+	first := sha256.Sum256(decoded[:21])
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], decoded[21:]) {
+		return nil, fmt.Errorf("%q has a bad checksum", address)
+	}
+	return decoded[:21], nil
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Decode decodes a Bitcoin-alphabet Base58 string.
+func base58Decode(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, c := range s {
+		idx := strings.IndexRune(base58Alphabet, c)
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", c)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+	decoded := n.Bytes()
+	for i := 0; i < len(s) && s[i] == '1'; i++ {
+		decoded = append([]byte{0}, decoded...)
+	}
+	return decoded, nil
+}
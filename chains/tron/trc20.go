@@ -0,0 +1,265 @@
+package tron
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+// feeLimitMargin is the percentage of the estimated energy cost used as the
+// fee limit of TRC-20 transfers, leaving headroom for estimation drift.
+const feeLimitMargin = 120
+
+// transferSelector is the function selector of transfer(address,uint256),
+// the first four bytes of its Keccak-256 hash.
+var transferSelector = [4]byte{0xa9, 0x05, 0x9c, 0xbb}
+
+// triggerContractRequest is the request body for /wallet/triggersmartcontract
+// and /wallet/triggerconstantcontract.
+type triggerContractRequest struct {
+	OwnerAddress     string `json:"owner_address"`
+	ContractAddress  string `json:"contract_address"`
+	FunctionSelector string `json:"function_selector"`
+	Parameter        string `json:"parameter"`
+	FeeLimit         int64  `json:"fee_limit,omitempty"`
+	Visible          bool   `json:"visible"`
+}
+
+// triggerContractResponse is the response of both trigger endpoints. The
+// constant call also reports the energy used and the return data.
+type triggerContractResponse struct {
+	Result struct {
+		Result  bool   `json:"result"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"result"`
+	EnergyUsed     int64           `json:"energy_used"`
+	ConstantResult []string        `json:"constant_result"`
+	Transaction    tronTransaction `json:"transaction"`
+}
+
+// TransferTRC20 sends amount of a TRC-20 token (such as USDT) from a Privy
+// wallet to destination. amount is in the token's smallest unit as a decimal
+// string. Unless WithFeeLimit is set, the fee limit is derived from the
+// energy the transfer is estimated to use and the network's energy price;
+// the transfer fails rather than sending a zero fee limit when either is
+// unavailable.
+// Returns the transaction ID (hash).
+func (h *Helper) TransferTRC20(ctx context.Context, walletID string, contractAddress string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "tron.transfer_trc20", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("tron: get wallet: %w", err)
+	}
+	owner, err := decodeAddress(wallet.Address)
+	if err != nil {
+		return "", fmt.Errorf("tron: invalid wallet address: %w", err)
+	}
+	req, err := transferTRC20Request(wallet.Address, contractAddress, destination, amount)
+	if err != nil {
+		return "", err
+	}
+
+	req.FeeLimit = h.feeLimit
+	if req.FeeLimit == 0 {
+		energy, err := h.estimateEnergy(ctx, req)
+		if err != nil {
+			return "", fmt.Errorf("tron: estimate energy: %w", err)
+		}
+		if energy <= 0 {
+			return "", fmt.Errorf("tron: estimate energy: transfer reported %d energy used", energy)
+		}
+		params, err := h.chainParameters(ctx)
+		if err != nil {
+			return "", fmt.Errorf("tron: get chain parameters: %w", err)
+		}
+		fee, err := energyFee(params)
+		if err != nil {
+			return "", fmt.Errorf("tron: get chain parameters: %w", err)
+		}
+		req.FeeLimit = energy * fee * feeLimitMargin / 100
+	}
+
+	txn, err := h.triggerSmartContract(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("tron: trigger smart contract: %w", err)
+	}
+
+	contract, _ := decodeAddress(contractAddress)
+	param, _ := hex.DecodeString(req.Parameter)
+	return h.signAndBroadcast(ctx, walletID, txn, &expectedTransaction{
+		contractType: triggerSmartContractType,
+		owner:        owner,
+		to:           contract,
+		data:         append(transferSelector[:], param...),
+		feeLimit:     req.FeeLimit,
+	})
+}
+
+// EstimateTRC20Fee returns the TRX in sun that a TRC-20 transfer would burn:
+// the energy not covered by the wallet's staked energy, plus bandwidth
+// beyond its free and staked allowance.
+func (h *Helper) EstimateTRC20Fee(ctx context.Context, walletID string, contractAddress string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "tron.estimate_trc20_fee", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("tron: get wallet: %w", err)
+	}
+	req, err := transferTRC20Request(wallet.Address, contractAddress, destination, amount)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := h.triggerConstantContract(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("tron: estimate energy: %w", err)
+	}
+	resources, err := h.accountResources(ctx, wallet.Address)
+	if err != nil {
+		return "", fmt.Errorf("tron: get account resources: %w", err)
+	}
+	params, err := h.chainParameters(ctx)
+	if err != nil {
+		return "", fmt.Errorf("tron: get chain parameters: %w", err)
+	}
+
+	var fee int64
+	if missing := result.EnergyUsed - (resources.EnergyLimit - resources.EnergyUsed); missing > 0 {
+		perEnergy, err := energyFee(params)
+		if err != nil {
+			return "", fmt.Errorf("tron: get chain parameters: %w", err)
+		}
+		fee += missing * perEnergy
+	}
+	size := int64(len(result.Transaction.RawDataHex)/2 + signatureOverhead)
+	if !resources.coversBandwidth(size) {
+		fee += size * params["getTransactionFee"]
+	}
+	return strconv.FormatInt(fee, 10), nil
+}
+
+// BalanceTRC20 returns the TRC-20 token balance of address in the token's
+// smallest unit, read with the contract's balanceOf.
+func (h *Helper) BalanceTRC20(ctx context.Context, contractAddress string, address string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "tron.balance_trc20")
+	defer func() { span.End(err) }()
+
+	owner, err := decodeAddress(address)
+	if err != nil {
+		return "", fmt.Errorf("tron: invalid address: %w", err)
+	}
+	result, err := h.triggerConstantContract(ctx, &triggerContractRequest{
+		OwnerAddress:     address,
+		ContractAddress:  contractAddress,
+		FunctionSelector: "balanceOf(address)",
+		Parameter:        hex.EncodeToString(abiAddress(owner)),
+		Visible:          true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("tron: call balanceOf: %w", err)
+	}
+	if len(result.ConstantResult) == 0 {
+		return "", fmt.Errorf("tron: balanceOf returned no data")
+	}
+	balance, ok := new(big.Int).SetString(result.ConstantResult[0], 16)
+	if !ok {
+		return "", fmt.Errorf("tron: invalid balanceOf result %q", result.ConstantResult[0])
+	}
+	return balance.String(), nil
+}
+
+// transferTRC20Request builds the trigger request for
+// transfer(destination, amount) on contractAddress.
+func transferTRC20Request(from, contractAddress, destination, amount string) (*triggerContractRequest, error) {
+	to, err := decodeAddress(destination)
+	if err != nil {
+		return nil, fmt.Errorf("tron: invalid destination: %w", err)
+	}
+	if _, err := decodeAddress(contractAddress); err != nil {
+		return nil, fmt.Errorf("tron: invalid contract address: %w", err)
+	}
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok || value.Sign() < 0 || value.BitLen() > 256 {
+		return nil, fmt.Errorf("tron: invalid amount %q", amount)
+	}
+	return &triggerContractRequest{
+		OwnerAddress:     from,
+		ContractAddress:  contractAddress,
+		FunctionSelector: "transfer(address,uint256)",
+		Parameter:        hex.EncodeToString(append(abiAddress(to), abiUint256(value)...)),
+		Visible:          true,
+	}, nil
+}
+
+// abiAddress ABI-encodes a 21-byte Tron address as an EVM address word,
+// dropping the 0x41 prefix.
+func abiAddress(addr []byte) []byte {
+	word := make([]byte, 32)
+	copy(word[12:], addr[1:])
+	return word
+}
+
+// abiUint256 ABI-encodes v as a uint256 word.
+func abiUint256(v *big.Int) []byte {
+	return v.FillBytes(make([]byte, 32))
+}
+
+// estimateEnergy simulates req and returns the energy it uses.
+func (h *Helper) estimateEnergy(ctx context.Context, req *triggerContractRequest) (int64, error) {
+	result, err := h.triggerConstantContract(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	return result.EnergyUsed, nil
+}
+
+// triggerConstantContract executes a contract call without broadcasting it.
+// A reverted call is returned as an error.
+func (h *Helper) triggerConstantContract(ctx context.Context, req *triggerContractRequest) (_ *triggerContractResponse, err error) {
+	ctx, span := h.client.StartSpan(ctx, "tron.trigger_constant_contract")
+	defer func() { span.End(err) }()
+
+	var result triggerContractResponse
+	if err := h.post(ctx, "/wallet/triggerconstantcontract", req, &result); err != nil {
+		return nil, err
+	}
+	if err := result.err(); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// triggerSmartContract builds an unsigned contract call transaction.
+func (h *Helper) triggerSmartContract(ctx context.Context, req *triggerContractRequest) (_ *tronTransaction, err error) {
+	ctx, span := h.client.StartSpan(ctx, "tron.create_transaction")
+	defer func() { span.End(err) }()
+
+	var result triggerContractResponse
+	if err := h.post(ctx, "/wallet/triggersmartcontract", req, &result); err != nil {
+		return nil, err
+	}
+	if err := result.err(); err != nil {
+		return nil, err
+	}
+	return &result.Transaction, nil
+}
+
+// err reports a failed trigger call. The node hex-encodes the message.
+func (r *triggerContractResponse) err() error {
+	if r.Result.Result {
+		return nil
+	}
+	msg := r.Result.Message
+	if b, err := hex.DecodeString(msg); err == nil {
+		msg = string(b)
+	}
+	return fmt.Errorf("contract call failed: %s %s", r.Result.Code, msg)
+}
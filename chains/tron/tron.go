@@ -8,21 +8,28 @@ package tron
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
 )
 
+var _ chains.Chain = (*Helper)(nil)
+
 // Helper provides high-level Tron transaction methods using Privy wallets.
 type Helper struct {
-	client     *privy.Client
-	rpcURL     string
-	httpClient *http.Client
+	client       *privy.Client
+	rpcURL       string
+	httpClient   *http.Client
+	pollInterval time.Duration
+	feeLimit     int64 // 0 means estimate per transfer
 }
 
 // Option configures the Helper.
@@ -47,13 +54,29 @@ func WithHTTPClient(c *http.Client) Option {
 	}
 }
 
+// WithPollInterval sets how often WaitForConfirmation polls the transaction info.
+func WithPollInterval(d time.Duration) Option {
+	return func(h *Helper) {
+		h.pollInterval = d
+	}
+}
+
+// WithFeeLimit sets a fixed fee limit in sun for TRC-20 transfers instead of
+// estimating one from the energy each transfer uses.
+func WithFeeLimit(sun int64) Option {
+	return func(h *Helper) {
+		h.feeLimit = sun
+	}
+}
+
 // NewHelper creates a new Tron helper.
 // Options are applied in order: testnet defaults, client-level chain options, then direct options.
 func NewHelper(client *privy.Client, opts ...Option) *Helper {
 	h := &Helper{
-		client:     client,
-		rpcURL:     "https://api.trongrid.io",
-		httpClient: http.DefaultClient,
+		client:       client,
+		rpcURL:       "https://api.trongrid.io",
+		httpClient:   http.DefaultClient,
+		pollInterval: chains.DefaultPollInterval,
 	}
 	if client.Testnet() {
 		WithTestnet()(h)
@@ -105,7 +128,10 @@ type broadcastResponse struct {
 // Transfer sends native TRX from a Privy wallet to a destination address.
 // amount is in sun (1 TRX = 1_000_000 sun) as a decimal string.
 // Returns the transaction ID (hash).
-func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (string, error) {
+func (h *Helper) Transfer(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "tron.transfer", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
 	// Get wallet address from Privy
 	wallet, err := h.client.Wallets().Get(ctx, walletID)
 	if err != nil {
@@ -118,24 +144,50 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 		return "", fmt.Errorf("tron: invalid amount %q: %w", amount, err)
 	}
 
+	owner, err := decodeAddress(wallet.Address)
+	if err != nil {
+		return "", fmt.Errorf("tron: invalid wallet address: %w", err)
+	}
+	to, err := decodeAddress(destination)
+	if err != nil {
+		return "", fmt.Errorf("tron: invalid destination: %w", err)
+	}
+
 	// Step 1: Create unsigned transaction via Tron HTTP API
 	txn, err := h.createTransaction(ctx, wallet.Address, destination, amountInt)
 	if err != nil {
 		return "", fmt.Errorf("tron: create transaction: %w", err)
 	}
 
+	return h.signAndBroadcast(ctx, walletID, txn, &expectedTransaction{
+		contractType: transferContractType,
+		owner:        owner,
+		to:           to,
+		amount:       amountInt,
+	})
+}
+
+// signAndBroadcast checks that txn matches want, signs it via Privy and
+// broadcasts it, returning its ID.
+func (h *Helper) signAndBroadcast(ctx context.Context, walletID string, txn *tronTransaction, want *expectedTransaction) (string, error) {
 	if txn.TxID == "" {
 		return "", fmt.Errorf("tron: empty transaction ID from API")
 	}
+	// The node builds the transaction, so never sign more than was asked for.
+	if err := verifyTransaction(txn, want, time.Now()); err != nil {
+		return "", err
+	}
 
-	// Step 2: Sign the transaction hash (txID is SHA-256 of raw_data)
+	// Sign the transaction hash (txID is SHA-256 of raw_data)
 	hashHex := "0x" + txn.TxID
-	signResp, err := h.client.RawSign(ctx, walletID, hashHex)
+	signCtx, signSpan := h.client.StartSpan(ctx, "tron.sign")
+	signResp, err := h.client.RawSign(signCtx, walletID, hashHex)
+	signSpan.End(err)
 	if err != nil {
 		return "", fmt.Errorf("tron: sign transaction: %w", err)
 	}
 
-	// Step 3: Broadcast the signed transaction
+	// Broadcast the signed transaction
 	sig := strings.TrimPrefix(signResp.Data.Signature, "0x")
 	err = h.broadcastTransaction(ctx, txn, sig)
 	if err != nil {
@@ -145,13 +197,11 @@ func (h *Helper) Transfer(ctx context.Context, walletID string, destination stri
 	return txn.TxID, nil
 }
 
-// TransferTRC20 sends a TRC-20 token from a Privy wallet to a destination.
-func (h *Helper) TransferTRC20(ctx context.Context, walletID string, contractAddress string, destination string, amount string) (string, error) {
-	return "", fmt.Errorf("tron: TransferTRC20 not yet implemented")
-}
-
 // createTransaction calls the Tron API to build an unsigned transfer transaction.
-func (h *Helper) createTransaction(ctx context.Context, from, to string, amount int64) (*tronTransaction, error) {
+func (h *Helper) createTransaction(ctx context.Context, from, to string, amount int64) (_ *tronTransaction, err error) {
+	ctx, span := h.client.StartSpan(ctx, "tron.create_transaction")
+	defer func() { span.End(err) }()
+
 	reqBody := &createTransactionRequest{
 		OwnerAddress: from,
 		ToAddress:    to,
@@ -159,43 +209,18 @@ func (h *Helper) createTransaction(ctx context.Context, from, to string, amount
 		Visible:      true,
 	}
 
-	body, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, err
-	}
-
-	url := h.rpcURL + "/wallet/createtransaction"
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := h.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("tron API returned status %d: %s", resp.StatusCode, string(respBody))
-	}
-
 	var txn tronTransaction
-	if err := json.Unmarshal(respBody, &txn); err != nil {
-		return nil, fmt.Errorf("failed to parse transaction response: %w", err)
+	if err := h.post(ctx, "/wallet/createtransaction", reqBody, &txn); err != nil {
+		return nil, err
 	}
-
 	return &txn, nil
 }
 
 // broadcastTransaction submits a signed transaction to the Tron network.
-func (h *Helper) broadcastTransaction(ctx context.Context, txn *tronTransaction, signature string) error {
+func (h *Helper) broadcastTransaction(ctx context.Context, txn *tronTransaction, signature string) (err error) {
+	ctx, span := h.client.StartSpan(ctx, "tron.broadcast")
+	defer func() { span.End(err) }()
+
 	reqBody := &broadcastRequest{
 		Visible:    txn.Visible,
 		TxID:       txn.TxID,
@@ -204,13 +229,26 @@ func (h *Helper) broadcastTransaction(ctx context.Context, txn *tronTransaction,
 		Signature:  []string{signature},
 	}
 
+	var result broadcastResponse
+	if err := h.post(ctx, "/wallet/broadcasttransaction", reqBody, &result); err != nil {
+		return err
+	}
+
+	if !result.Result {
+		return fmt.Errorf("broadcast failed: %s - %s", result.Code, result.Message)
+	}
+
+	return nil
+}
+
+// post sends a JSON request to a Tron HTTP API endpoint and decodes the response.
+func (h *Helper) post(ctx context.Context, path string, reqBody any, out any) error {
 	body, err := json.Marshal(reqBody)
 	if err != nil {
 		return err
 	}
 
-	url := h.rpcURL + "/wallet/broadcasttransaction"
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", h.rpcURL+path, bytes.NewReader(body))
 	if err != nil {
 		return err
 	}
@@ -231,18 +269,186 @@ func (h *Helper) broadcastTransaction(ctx context.Context, txn *tronTransaction,
 		return fmt.Errorf("tron API returned status %d: %s", resp.StatusCode, string(respBody))
 	}
 
-	var result broadcastResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
-		return fmt.Errorf("failed to parse broadcast response: %w", err)
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", path, err)
+	}
+	return nil
+}
+
+// account is the subset of /wallet/getaccount used by the helper. The API
+// returns an empty object for accounts that have not been activated.
+type account struct {
+	Address string `json:"address"`
+	Balance int64  `json:"balance"`
+}
+
+func (h *Helper) getAccount(ctx context.Context, address string) (*account, error) {
+	var acct account
+	if err := h.post(ctx, "/wallet/getaccount", map[string]any{"address": address, "visible": true}, &acct); err != nil {
+		return nil, err
 	}
+	return &acct, nil
+}
 
-	if !result.Result {
-		return fmt.Errorf("broadcast failed: %s - %s", result.Code, result.Message)
+// Balance returns the TRX balance of address in sun.
+func (h *Helper) Balance(ctx context.Context, address string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "tron.balance")
+	defer func() { span.End(err) }()
+
+	acct, err := h.getAccount(ctx, address)
+	if err != nil {
+		return "", fmt.Errorf("tron: get account: %w", err)
 	}
+	return strconv.FormatInt(acct.Balance, 10), nil
+}
 
+// ValidateAddress checks that address is a Base58Check-encoded Tron address
+// (version byte 0x41).
+func (h *Helper) ValidateAddress(address string) error {
+	if _, err := decodeAddress(address); err != nil {
+		return fmt.Errorf("%w: %v", chains.ErrInvalidAddress, err)
+	}
 	return nil
 }
 
+// signatureOverhead is the number of bytes a signature adds to a serialized
+// transaction: 65 signature bytes plus the protobuf tag and length.
+const signatureOverhead = 67
+
+// EstimateFee returns the TRX in sun that a transfer would burn. Bandwidth
+// is paid from the wallet's free and staked allowance first; any shortfall is
+// burnt at the network's transaction fee. Sending to an account that has not
+// been activated yet adds the account creation fee.
+func (h *Helper) EstimateFee(ctx context.Context, walletID string, destination string, amount string) (_ string, err error) {
+	ctx, span := h.client.StartSpan(ctx, "tron.estimate_fee", privy.Attr(privy.AttrWalletID, walletID))
+	defer func() { span.End(err) }()
+
+	amountInt, err := strconv.ParseInt(amount, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("tron: invalid amount %q: %w", amount, err)
+	}
+	wallet, err := h.client.Wallets().Get(ctx, walletID)
+	if err != nil {
+		return "", fmt.Errorf("tron: get wallet: %w", err)
+	}
+
+	txn, err := h.createTransaction(ctx, wallet.Address, destination, amountInt)
+	if err != nil {
+		return "", fmt.Errorf("tron: create transaction: %w", err)
+	}
+	size := int64(len(txn.RawDataHex)/2 + signatureOverhead)
+
+	resources, err := h.accountResources(ctx, wallet.Address)
+	if err != nil {
+		return "", fmt.Errorf("tron: get account resources: %w", err)
+	}
+	params, err := h.chainParameters(ctx)
+	if err != nil {
+		return "", fmt.Errorf("tron: get chain parameters: %w", err)
+	}
+
+	var fee int64
+	dest, err := h.getAccount(ctx, destination)
+	if err != nil {
+		return "", fmt.Errorf("tron: get destination account: %w", err)
+	}
+	if dest.Address == "" {
+		// Activating an account is paid with staked bandwidth or burnt, and
+		// always costs the system contract fee.
+		if resources.NetLimit-resources.NetUsed < size {
+			fee += params["getCreateAccountFee"]
+		}
+		fee += params["getCreateNewAccountFeeInSystemContract"]
+	} else if !resources.coversBandwidth(size) {
+		fee += size * params["getTransactionFee"]
+	}
+	return strconv.FormatInt(fee, 10), nil
+}
+
+// accountResources is the subset of /wallet/getaccountresource used by the
+// helper.
+type accountResources struct {
+	FreeNetLimit int64 `json:"freeNetLimit"`
+	FreeNetUsed  int64 `json:"freeNetUsed"`
+	NetLimit     int64 `json:"NetLimit"`
+	NetUsed      int64 `json:"NetUsed"`
+	EnergyLimit  int64 `json:"EnergyLimit"`
+	EnergyUsed   int64 `json:"EnergyUsed"`
+}
+
+func (h *Helper) accountResources(ctx context.Context, address string) (*accountResources, error) {
+	var resources accountResources
+	if err := h.post(ctx, "/wallet/getaccountresource", map[string]any{"address": address, "visible": true}, &resources); err != nil {
+		return nil, err
+	}
+	return &resources, nil
+}
+
+// coversBandwidth reports whether the free or staked bandwidth left can pay
+// for a transaction of size bytes.
+func (r *accountResources) coversBandwidth(size int64) bool {
+	return r.FreeNetLimit-r.FreeNetUsed >= size || r.NetLimit-r.NetUsed >= size
+}
+
+// chainParameters returns the network's chain parameters by key.
+func (h *Helper) chainParameters(ctx context.Context) (map[string]int64, error) {
+	var resp struct {
+		ChainParameter []struct {
+			Key   string `json:"key"`
+			Value int64  `json:"value"`
+		} `json:"chainParameter"`
+	}
+	if err := h.post(ctx, "/wallet/getchainparameters", map[string]any{}, &resp); err != nil {
+		return nil, err
+	}
+	params := make(map[string]int64, len(resp.ChainParameter))
+	for _, p := range resp.ChainParameter {
+		params[p.Key] = p.Value
+	}
+	return params, nil
+}
+
+// energyFee returns the sun burned per unit of energy. A missing or
+// non-positive getEnergyFee is an error rather than a free transfer.
+func energyFee(params map[string]int64) (int64, error) {
+	fee := params["getEnergyFee"]
+	if fee <= 0 {
+		return 0, fmt.Errorf("chain parameter getEnergyFee is %d", fee)
+	}
+	return fee, nil
+}
+
+// WaitForConfirmation polls the transaction info until the transaction is in
+// a block. A failed contract execution is reported as
+// chains.ErrTransactionFailed.
+func (h *Helper) WaitForConfirmation(ctx context.Context, txHash string) (err error) {
+	ctx, span := h.client.StartSpan(ctx, "tron.wait_for_confirmation")
+	defer func() { span.End(err) }()
+
+	return chains.Poll(ctx, h.pollInterval, func(ctx context.Context) (bool, error) {
+		var info struct {
+			ID          string `json:"id"`
+			BlockNumber int64  `json:"blockNumber"`
+			Result      string `json:"result"`
+			ResMessage  string `json:"resMessage"`
+			Receipt     struct {
+				Result string `json:"result"`
+			} `json:"receipt"`
+		}
+		if err := h.post(ctx, "/wallet/gettransactioninfobyid", map[string]any{"value": txHash}, &info); err != nil {
+			return false, fmt.Errorf("tron: get transaction info: %w", err)
+		}
+		if info.ID == "" {
+			return false, nil
+		}
+		if info.Result == "FAILED" || (info.Receipt.Result != "" && info.Receipt.Result != "SUCCESS") {
+			msg, _ := hex.DecodeString(info.ResMessage)
+			return true, fmt.Errorf("tron: %w: %s %s", chains.ErrTransactionFailed, info.Receipt.Result, msg)
+		}
+		return true, nil
+	})
+}
+
 // RawSign signs a pre-computed hash using the Tron wallet's key via Privy.
 func (h *Helper) RawSign(ctx context.Context, walletID string, hash string) (*privy.RawSignResponse, error) {
 	return h.client.RawSign(ctx, walletID, hash)
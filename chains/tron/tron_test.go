@@ -2,12 +2,21 @@ package tron
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/chains"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
 )
 
 func TestNewHelper(t *testing.T) {
@@ -43,64 +52,69 @@ func TestNewHelper_WithHTTPClient(t *testing.T) {
 	}
 }
 
-func TestTransfer_WithMockServer(t *testing.T) {
-	// Mock Tron API
-	tronServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+// transferNode mocks /wallet/createtransaction, building the transaction
+// with build, and /wallet/broadcasttransaction.
+func transferNode(t *testing.T, build func(owner, to []byte, amount int64) []byte) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case "/wallet/createtransaction":
-			json.NewEncoder(w).Encode(map[string]any{
-				"visible":      true,
-				"txid":         "abc123def456",
-				"raw_data":     map[string]any{"contract": []any{}},
-				"raw_data_hex": "0a0200",
-			})
+			var req createTransactionRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			owner, _ := decodeAddress(req.OwnerAddress)
+			to, _ := decodeAddress(req.ToAddress)
+			json.NewEncoder(w).Encode(nodeTransaction(build(owner, to, req.Amount)))
 		case "/wallet/broadcasttransaction":
-			json.NewEncoder(w).Encode(map[string]any{
-				"result": true,
-				"txid":   "abc123def456",
-			})
-		default:
-			http.NotFound(w, r)
-		}
-	}))
-	defer tronServer.Close()
-
-	// Mock Privy API
-	privyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		switch {
-		case r.Method == "GET" && r.URL.Path == "/v1/wallets/wallet-123":
-			json.NewEncoder(w).Encode(map[string]any{
-				"id":         "wallet-123",
-				"address":    "TJmmqjb1DK9TTZbQXzRQ2AuA94z4jCcPMb",
-				"chain_type": "tron",
-			})
-		case r.Method == "POST" && r.URL.Path == "/v1/wallets/wallet-123/raw_sign":
-			json.NewEncoder(w).Encode(map[string]any{
-				"method": "raw_sign",
-				"data": map[string]any{
-					"signature": "0xabcdef1234567890",
-					"encoding":  "hex",
-				},
-			})
+			json.NewEncoder(w).Encode(map[string]any{"result": true})
 		default:
 			http.NotFound(w, r)
 		}
 	}))
-	defer privyServer.Close()
+	t.Cleanup(srv.Close)
+	return srv
+}
 
-	client := privy.NewClient("test-app-id", "test-app-secret",
-		privy.WithBaseURL(privyServer.URL+"/v1"))
+func TestTransfer_WithMockServer(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeTron})
 
-	h := NewHelper(client,
-		WithRPCURL(tronServer.URL),
-	)
+	var raw []byte
+	node := transferNode(t, func(owner, to []byte, amount int64) []byte {
+		raw = rawTransfer(owner, to, amount, time.Now().Add(time.Minute))
+		return raw
+	})
+	h := NewHelper(client, WithRPCURL(node.URL))
 
-	txID, err := h.Transfer(context.Background(), "wallet-123", "TF17BgPaZYbz8oxbjhriubPDsA7ArKoLX3", "1000000")
+	txID, err := h.Transfer(context.Background(), wallet.ID, "TF17BgPaZYbz8oxbjhriubPDsA7ArKoLX3", "1000000")
 	if err != nil {
 		t.Fatalf("Transfer failed: %v", err)
 	}
-	if txID != "abc123def456" {
-		t.Errorf("Expected txID abc123def456, got %s", txID)
+	sum := sha256.Sum256(raw)
+	if txID != hex.EncodeToString(sum[:]) {
+		t.Errorf("Expected txID of the raw data, got %s", txID)
+	}
+}
+
+func TestTransfer_RejectsMismatch(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeTron})
+
+	// A node that redirects the transfer to an address of its choosing.
+	attacker, _ := decodeAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	node := transferNode(t, func(owner, to []byte, amount int64) []byte {
+		return rawTransfer(owner, attacker, amount, time.Now().Add(time.Minute))
+	})
+	h := NewHelper(client, WithRPCURL(node.URL))
+
+	_, err := h.Transfer(context.Background(), wallet.ID, "TF17BgPaZYbz8oxbjhriubPDsA7ArKoLX3", "1000000")
+	var mismatch *MismatchError
+	if !errors.As(err, &mismatch) || mismatch.Field != "to_address" {
+		t.Fatalf("Expected to_address mismatch, got %v", err)
+	}
+	if signs := srv.RequestsMatching(privytest.Route("POST", "/v1/wallets/*/raw_sign")); len(signs) != 0 {
+		t.Errorf("Expected nothing to be signed, got %d raw_sign calls", len(signs))
 	}
 }
 
@@ -124,12 +138,296 @@ func TestTransfer_InvalidAmount(t *testing.T) {
 	}
 }
 
-func TestTransferTRC20_NotImplemented(t *testing.T) {
-	client := privy.NewClient("test-app-id", "test-app-secret")
-	h := NewHelper(client)
+// trc20Node mocks the Tron API endpoints used by TRC-20 transfers and
+// records the trigger requests it receives.
+func trc20Node(t *testing.T, triggers map[string]triggerContractRequest) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req triggerContractRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		switch r.URL.Path {
+		case "/wallet/triggerconstantcontract":
+			triggers[r.URL.Path] = req
+			resp := map[string]any{
+				"result":      map[string]any{"result": true},
+				"energy_used": 14650,
+				"transaction": map[string]any{"txID": "const", "raw_data_hex": strings.Repeat("00", 200)},
+			}
+			if req.FunctionSelector == "balanceOf(address)" {
+				resp["constant_result"] = []string{"00000000000000000000000000000000000000000000000000000000004c4b40"}
+			}
+			json.NewEncoder(w).Encode(resp)
+		case "/wallet/triggersmartcontract":
+			triggers[r.URL.Path] = req
+			owner, _ := decodeAddress(req.OwnerAddress)
+			contract, _ := decodeAddress(req.ContractAddress)
+			param, _ := hex.DecodeString(req.Parameter)
+			data := append([]byte{0xa9, 0x05, 0x9c, 0xbb}, param...)
+			json.NewEncoder(w).Encode(map[string]any{
+				"result":      map[string]any{"result": true},
+				"transaction": nodeTransaction(rawTrigger(owner, contract, data, req.FeeLimit, time.Now().Add(time.Minute))),
+			})
+		case "/wallet/getchainparameters":
+			json.NewEncoder(w).Encode(map[string]any{"chainParameter": []any{
+				map[string]any{"key": "getEnergyFee", "value": 420},
+				map[string]any{"key": "getTransactionFee", "value": 1000},
+			}})
+		case "/wallet/getaccountresource":
+			json.NewEncoder(w).Encode(map[string]any{"freeNetLimit": 600, "freeNetUsed": 600, "EnergyLimit": 10000, "EnergyUsed": 0})
+		case "/wallet/broadcasttransaction":
+			json.NewEncoder(w).Encode(map[string]any{"result": true})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
 
-	_, err := h.TransferTRC20(context.Background(), "wallet-id", "TContract...", "TDest...", "1000000")
-	if err == nil {
-		t.Error("Expected error for unimplemented TransferTRC20")
+func TestTransferTRC20(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeTron})
+
+	triggers := make(map[string]triggerContractRequest)
+	h := NewHelper(client, WithRPCURL(trc20Node(t, triggers).URL))
+
+	usdt := "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	if _, err := h.TransferTRC20(context.Background(), wallet.ID, usdt, "TF17BgPaZYbz8oxbjhriubPDsA7ArKoLX3", "5000000"); err != nil {
+		t.Fatalf("TransferTRC20 failed: %v", err)
+	}
+
+	req := triggers["/wallet/triggersmartcontract"]
+	if req.OwnerAddress != wallet.Address || req.ContractAddress != usdt || req.FunctionSelector != "transfer(address,uint256)" {
+		t.Errorf("Unexpected trigger request %+v", req)
+	}
+	wantParam := "00000000000000000000000037349aeb75a32f8c4c090daff376cf975f5d2eba" +
+		"00000000000000000000000000000000000000000000000000000000004c4b40"
+	if req.Parameter != wantParam {
+		t.Errorf("Parameter = %s, want %s", req.Parameter, wantParam)
+	}
+	// 14650 energy at 420 sun, plus 20%
+	if req.FeeLimit != 7383600 {
+		t.Errorf("Expected estimated fee limit 7383600, got %d", req.FeeLimit)
+	}
+
+	h = NewHelper(client, WithRPCURL(trc20Node(t, triggers).URL), WithFeeLimit(30_000_000))
+	if _, err := h.TransferTRC20(context.Background(), wallet.ID, usdt, "TF17BgPaZYbz8oxbjhriubPDsA7ArKoLX3", "5000000"); err != nil {
+		t.Fatalf("TransferTRC20 failed: %v", err)
+	}
+	if got := triggers["/wallet/triggersmartcontract"].FeeLimit; got != 30_000_000 {
+		t.Errorf("Expected fixed fee limit, got %d", got)
+	}
+}
+
+func TestTransferTRC20_InvalidInput(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeTron})
+	h := NewHelper(client, WithRPCURL("http://127.0.0.1:0"))
+
+	tests := []struct{ contract, dest, amount string }{
+		{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "TDest...", "1"},
+		{"TContract...", "TF17BgPaZYbz8oxbjhriubPDsA7ArKoLX3", "1"},
+		{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "TF17BgPaZYbz8oxbjhriubPDsA7ArKoLX3", "-1"},
+	}
+	for _, tt := range tests {
+		if _, err := h.TransferTRC20(context.Background(), wallet.ID, tt.contract, tt.dest, tt.amount); err == nil {
+			t.Errorf("Expected error for %+v", tt)
+		}
+	}
+}
+
+func TestTransferTRC20_NoEnergyFee(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeTron})
+
+	triggers := make(map[string]triggerContractRequest)
+	target, _ := url.Parse(trc20Node(t, triggers).URL)
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/wallet/getchainparameters" {
+			json.NewEncoder(w).Encode(map[string]any{"chainParameter": []any{
+				map[string]any{"key": "getTransactionFee", "value": 1000},
+			}})
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	}))
+	defer node.Close()
+
+	h := NewHelper(client, WithRPCURL(node.URL))
+	usdt := "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	if _, err := h.TransferTRC20(context.Background(), wallet.ID, usdt, "TF17BgPaZYbz8oxbjhriubPDsA7ArKoLX3", "5000000"); err == nil {
+		t.Error("Expected error without getEnergyFee")
+	}
+	if _, ok := triggers["/wallet/triggersmartcontract"]; ok {
+		t.Error("Expected no transaction to be built with a zero fee limit")
+	}
+	if _, err := h.EstimateTRC20Fee(context.Background(), wallet.ID, usdt, "TF17BgPaZYbz8oxbjhriubPDsA7ArKoLX3", "5000000"); err == nil {
+		t.Error("Expected fee estimate error without getEnergyFee")
+	}
+}
+
+func TestEstimateTRC20Fee(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeTron})
+
+	h := NewHelper(client, WithRPCURL(trc20Node(t, map[string]triggerContractRequest{}).URL))
+	fee, err := h.EstimateTRC20Fee(context.Background(), wallet.ID, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "TF17BgPaZYbz8oxbjhriubPDsA7ArKoLX3", "5000000")
+	if err != nil {
+		t.Fatalf("EstimateTRC20Fee failed: %v", err)
+	}
+	// (14650 - 10000 staked) energy at 420 sun, plus (200 + 67) bytes at 1000 sun
+	if fee != "2220000" {
+		t.Errorf("Expected fee 2220000, got %s", fee)
+	}
+}
+
+func TestBalanceTRC20(t *testing.T) {
+	triggers := make(map[string]triggerContractRequest)
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(trc20Node(t, triggers).URL))
+
+	balance, err := h.BalanceTRC20(context.Background(), "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "TF17BgPaZYbz8oxbjhriubPDsA7ArKoLX3")
+	if err != nil {
+		t.Fatalf("BalanceTRC20 failed: %v", err)
+	}
+	if balance != "5000000" {
+		t.Errorf("Expected balance 5000000, got %s", balance)
+	}
+	if got := triggers["/wallet/triggerconstantcontract"].Parameter; got != "00000000000000000000000037349aeb75a32f8c4c090daff376cf975f5d2eba" {
+		t.Errorf("Unexpected balanceOf parameter %s", got)
+	}
+}
+
+func TestBalance(t *testing.T) {
+	tronServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		json.NewDecoder(r.Body).Decode(&req)
+		if req["address"] == "TJmmqjb1DK9TTZbQXzRQ2AuA94z4jCcPMb" {
+			json.NewEncoder(w).Encode(map[string]any{"address": req["address"], "balance": 2500000})
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer tronServer.Close()
+
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(tronServer.URL))
+	for addr, want := range map[string]string{
+		"TJmmqjb1DK9TTZbQXzRQ2AuA94z4jCcPMb": "2500000",
+		"TF17BgPaZYbz8oxbjhriubPDsA7ArKoLX3": "0",
+	} {
+		balance, err := h.Balance(context.Background(), addr)
+		if err != nil {
+			t.Fatalf("Balance failed: %v", err)
+		}
+		if balance != want {
+			t.Errorf("Balance(%s) = %s, want %s", addr, balance, want)
+		}
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"))
+
+	if err := h.ValidateAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"); err != nil {
+		t.Errorf("Expected valid address, got %v", err)
+	}
+	invalid := []string{
+		"",
+		"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u", // bad checksum
+		"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", // Bitcoin address
+		"0x742d35Cc6634C0532925a3b844Bc9e7595f2bD18",
+	}
+	for _, addr := range invalid {
+		if err := h.ValidateAddress(addr); !errors.Is(err, chains.ErrInvalidAddress) {
+			t.Errorf("ValidateAddress(%q) = %v, want ErrInvalidAddress", addr, err)
+		}
+	}
+}
+
+func TestEstimateFee(t *testing.T) {
+	srv, client := privytest.NewServer()
+	defer srv.Close()
+	wallet, _ := client.Wallets().Create(context.Background(), &privy.CreateWalletRequest{ChainType: privy.ChainTypeTron})
+
+	freeNetUsed := 0
+	tronServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		json.NewDecoder(r.Body).Decode(&req)
+		switch r.URL.Path {
+		case "/wallet/createtransaction":
+			json.NewEncoder(w).Encode(map[string]any{"txid": "abc", "raw_data_hex": strings.Repeat("00", 100)})
+		case "/wallet/getaccountresource":
+			json.NewEncoder(w).Encode(map[string]any{"freeNetLimit": 600, "freeNetUsed": freeNetUsed})
+		case "/wallet/getchainparameters":
+			json.NewEncoder(w).Encode(map[string]any{"chainParameter": []any{
+				map[string]any{"key": "getTransactionFee", "value": 1000},
+				map[string]any{"key": "getCreateAccountFee", "value": 100000},
+				map[string]any{"key": "getCreateNewAccountFeeInSystemContract", "value": 1000000},
+			}})
+		case "/wallet/getaccount":
+			if req["address"] == "TNewAccount" {
+				w.Write([]byte("{}"))
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"address": req["address"]})
+		}
+	}))
+	defer tronServer.Close()
+
+	h := NewHelper(client, WithRPCURL(tronServer.URL))
+	tests := []struct {
+		name        string
+		dest        string
+		freeNetUsed int
+		want        string
+	}{
+		{"free bandwidth", "TExisting", 0, "0"},
+		{"burn bandwidth", "TExisting", 600, "167000"}, // (100 + 67) bytes at 1000 sun
+		{"new account", "TNewAccount", 0, "1100000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			freeNetUsed = tt.freeNetUsed
+			fee, err := h.EstimateFee(context.Background(), wallet.ID, tt.dest, "1000000")
+			if err != nil {
+				t.Fatalf("EstimateFee failed: %v", err)
+			}
+			if fee != tt.want {
+				t.Errorf("Expected fee %s, got %s", tt.want, fee)
+			}
+		})
+	}
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	polls := 0
+	var info map[string]any
+	tronServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls == 1 {
+			w.Write([]byte("{}"))
+			return
+		}
+		json.NewEncoder(w).Encode(info)
+	}))
+	defer tronServer.Close()
+
+	h := NewHelper(privy.NewClient("test-app-id", "test-app-secret"), WithRPCURL(tronServer.URL), WithPollInterval(time.Millisecond))
+
+	info = map[string]any{"id": "abc", "blockNumber": 100, "receipt": map[string]any{"net_usage": 267}}
+	if err := h.WaitForConfirmation(context.Background(), "abc"); err != nil {
+		t.Fatalf("WaitForConfirmation failed: %v", err)
+	}
+	if polls != 2 {
+		t.Errorf("Expected 2 polls, got %d", polls)
+	}
+
+	info = map[string]any{"id": "abc", "blockNumber": 100, "result": "FAILED", "receipt": map[string]any{"result": "OUT_OF_ENERGY"}}
+	if err := h.WaitForConfirmation(context.Background(), "abc"); !errors.Is(err, chains.ErrTransactionFailed) {
+		t.Errorf("Expected ErrTransactionFailed, got %v", err)
 	}
 }
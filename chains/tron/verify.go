@@ -0,0 +1,255 @@
+package tron
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrTransactionMismatch is returned, wrapped in a *MismatchError, when a
+// transaction built by the node does not match what the helper asked for.
+// Nothing is signed in that case.
+var ErrTransactionMismatch = errors.New("tron: transaction does not match the request")
+
+// MismatchError describes the first field of a node-built transaction that
+// differs from the request.
+type MismatchError struct {
+	Field string
+	Want  string
+	Got   string
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("tron: transaction from node does not match the request: %s is %s, want %s", e.Field, e.Got, e.Want)
+}
+
+// Unwrap returns ErrTransactionMismatch.
+func (e *MismatchError) Unwrap() error {
+	return ErrTransactionMismatch
+}
+
+// Contract types of Transaction.Contract.type.
+const (
+	transferContractType     = 1
+	triggerSmartContractType = 31
+)
+
+// contractTypeURLs are the google.protobuf.Any type URLs of the contract
+// parameter for each contract type.
+var contractTypeURLs = map[uint64]string{
+	transferContractType:     "type.googleapis.com/protocol.TransferContract",
+	triggerSmartContractType: "type.googleapis.com/protocol.TriggerSmartContract",
+}
+
+// maxExpiration is how far in the future nodes accept a transaction's
+// expiration.
+const maxExpiration = 24 * time.Hour
+
+// expectedTransaction is what the single contract of a transaction must
+// contain before the helper signs it.
+type expectedTransaction struct {
+	contractType uint64
+	owner        []byte
+	to           []byte // recipient of a transfer, or the called contract
+	amount       int64  // TRX amount of a transfer
+	data         []byte // call data of a contract call
+	feeLimit     int64
+}
+
+// verifyTransaction decodes txn.RawDataHex and checks that it carries
+// exactly the expected contract, has not expired and hashes to txn.TxID.
+func verifyTransaction(txn *tronTransaction, want *expectedTransaction, now time.Time) error {
+	raw, err := hex.DecodeString(txn.RawDataHex)
+	if err != nil {
+		return fmt.Errorf("tron: decode raw_data_hex: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, txn.TxID) {
+		return &MismatchError{Field: "txID", Want: got, Got: txn.TxID}
+	}
+
+	var (
+		expiration, feeLimit int64
+		contracts            [][]byte
+	)
+	err = walkProto(raw, func(num int, v uint64, b []byte) {
+		switch num {
+		case 8:
+			expiration = int64(v)
+		case 11:
+			contracts = append(contracts, b)
+		case 18:
+			feeLimit = int64(v)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("tron: decode raw_data: %w", err)
+	}
+
+	if len(contracts) != 1 {
+		return mismatchInt("contract count", 1, int64(len(contracts)))
+	}
+	exp := time.UnixMilli(expiration)
+	if !exp.After(now) || exp.After(now.Add(maxExpiration)) {
+		return &MismatchError{
+			Field: "expiration",
+			Want:  fmt.Sprintf("between %s and %s", now.UTC().Format(time.RFC3339), now.Add(maxExpiration).UTC().Format(time.RFC3339)),
+			Got:   exp.UTC().Format(time.RFC3339),
+		}
+	}
+	if err := verifyContract(contracts[0], want); err != nil {
+		return err
+	}
+	if feeLimit != want.feeLimit {
+		return mismatchInt("fee_limit", want.feeLimit, feeLimit)
+	}
+	return nil
+}
+
+// verifyContract checks a Transaction.Contract message.
+func verifyContract(contract []byte, want *expectedTransaction) error {
+	var (
+		contractType uint64
+		parameter    []byte
+	)
+	err := walkProto(contract, func(num int, v uint64, b []byte) {
+		switch num {
+		case 1:
+			contractType = v
+		case 2:
+			parameter = b
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("tron: decode contract: %w", err)
+	}
+	if contractType != want.contractType {
+		return mismatchInt("contract type", int64(want.contractType), int64(contractType))
+	}
+
+	// The parameter is a google.protobuf.Any: type_url (1) and value (2).
+	var typeURL string
+	var value []byte
+	err = walkProto(parameter, func(num int, _ uint64, b []byte) {
+		switch num {
+		case 1:
+			typeURL = string(b)
+		case 2:
+			value = b
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("tron: decode contract parameter: %w", err)
+	}
+	if wantURL := contractTypeURLs[want.contractType]; typeURL != wantURL {
+		return &MismatchError{Field: "parameter type_url", Want: wantURL, Got: typeURL}
+	}
+
+	// TransferContract and TriggerSmartContract share owner_address (1) and
+	// the recipient or contract address (2). Field 3 is the TRX amount or
+	// call value; TriggerSmartContract adds data (4) and TRC-10 token
+	// fields (5, 6) that must stay empty.
+	var (
+		owner, to, data []byte
+		amount          int64
+		tokenTransfer   bool
+	)
+	err = walkProto(value, func(num int, v uint64, b []byte) {
+		switch num {
+		case 1:
+			owner = b
+		case 2:
+			to = b
+		case 3:
+			amount = int64(v)
+		case 4:
+			data = b
+		case 5, 6:
+			tokenTransfer = tokenTransfer || v != 0 || len(b) > 0
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("tron: decode contract parameter: %w", err)
+	}
+	if !bytes.Equal(owner, want.owner) {
+		return mismatchBytes("owner_address", want.owner, owner)
+	}
+	if !bytes.Equal(to, want.to) {
+		field := "to_address"
+		if want.contractType == triggerSmartContractType {
+			field = "contract_address"
+		}
+		return mismatchBytes(field, want.to, to)
+	}
+	if amount != want.amount {
+		field := "amount"
+		if want.contractType == triggerSmartContractType {
+			field = "call_value"
+		}
+		return mismatchInt(field, want.amount, amount)
+	}
+	if !bytes.Equal(data, want.data) {
+		return mismatchBytes("data", want.data, data)
+	}
+	if tokenTransfer {
+		return &MismatchError{Field: "call_token_value", Want: "none", Got: "a TRC-10 token transfer"}
+	}
+	return nil
+}
+
+func mismatchInt(field string, want, got int64) error {
+	return &MismatchError{Field: field, Want: strconv.FormatInt(want, 10), Got: strconv.FormatInt(got, 10)}
+}
+
+func mismatchBytes(field string, want, got []byte) error {
+	return &MismatchError{Field: field, Want: hex.EncodeToString(want), Got: hex.EncodeToString(got)}
+}
+
+// walkProto calls fn for each field of a protobuf message with the field
+// number and its value: v for varints, b for length-delimited fields.
+// Fixed-width fields are skipped.
+func walkProto(msg []byte, fn func(num int, v uint64, b []byte)) error {
+	for len(msg) > 0 {
+		key, n := binary.Uvarint(msg)
+		if n <= 0 {
+			return fmt.Errorf("invalid field key")
+		}
+		msg = msg[n:]
+		num := int(key >> 3)
+		switch key & 7 {
+		case 0:
+			v, n := binary.Uvarint(msg)
+			if n <= 0 {
+				return fmt.Errorf("invalid varint in field %d", num)
+			}
+			msg = msg[n:]
+			fn(num, v, nil)
+		case 1:
+			if len(msg) < 8 {
+				return fmt.Errorf("truncated field %d", num)
+			}
+			msg = msg[8:]
+		case 2:
+			l, n := binary.Uvarint(msg)
+			if n <= 0 || uint64(len(msg)-n) < l {
+				return fmt.Errorf("truncated field %d", num)
+			}
+			fn(num, 0, msg[n:n+int(l)])
+			msg = msg[n+int(l):]
+		case 5:
+			if len(msg) < 4 {
+				return fmt.Errorf("truncated field %d", num)
+			}
+			msg = msg[4:]
+		default:
+			return fmt.Errorf("unsupported wire type %d in field %d", key&7, num)
+		}
+	}
+	return nil
+}
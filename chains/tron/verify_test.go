@@ -0,0 +1,155 @@
+package tron
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+)
+
+func pbVarint(num int, v uint64) []byte {
+	b := binary.AppendUvarint(nil, uint64(num)<<3)
+	return binary.AppendUvarint(b, v)
+}
+
+func pbBytes(num int, v []byte) []byte {
+	b := binary.AppendUvarint(nil, uint64(num)<<3|2)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func concat(parts ...[]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+// rawData encodes a Transaction.raw with a single contract, the way a node
+// builds it.
+func rawData(contractType uint64, typeURL string, param []byte, feeLimit int64, expiration time.Time) []byte {
+	contract := concat(
+		pbVarint(1, contractType),
+		pbBytes(2, concat(pbBytes(1, []byte(typeURL)), pbBytes(2, param))),
+	)
+	raw := concat(
+		pbBytes(1, []byte{0x12, 0x34}), // ref_block_bytes
+		pbBytes(4, []byte{1, 2, 3, 4, 5, 6, 7, 8}),
+		pbVarint(8, uint64(expiration.UnixMilli())),
+		pbBytes(11, contract),
+		pbVarint(14, uint64(expiration.Add(-time.Minute).UnixMilli())),
+	)
+	if feeLimit != 0 {
+		raw = append(raw, pbVarint(18, uint64(feeLimit))...)
+	}
+	return raw
+}
+
+func rawTransfer(owner, to []byte, amount int64, expiration time.Time) []byte {
+	param := concat(pbBytes(1, owner), pbBytes(2, to), pbVarint(3, uint64(amount)))
+	return rawData(transferContractType, "type.googleapis.com/protocol.TransferContract", param, 0, expiration)
+}
+
+func rawTrigger(owner, contract, data []byte, feeLimit int64, expiration time.Time) []byte {
+	param := concat(pbBytes(1, owner), pbBytes(2, contract), pbBytes(4, data))
+	return rawData(triggerSmartContractType, "type.googleapis.com/protocol.TriggerSmartContract", param, feeLimit, expiration)
+}
+
+// nodeTransaction wraps raw data the way the node API returns it.
+func nodeTransaction(raw []byte) map[string]any {
+	sum := sha256.Sum256(raw)
+	return map[string]any{
+		"visible":      true,
+		"txID":         hex.EncodeToString(sum[:]),
+		"raw_data":     map[string]any{"contract": []any{}},
+		"raw_data_hex": hex.EncodeToString(raw),
+	}
+}
+
+func TestVerifyTransaction(t *testing.T) {
+	owner, _ := decodeAddress("TF17BgPaZYbz8oxbjhriubPDsA7ArKoLX3")
+	to, _ := decodeAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	now := time.Now()
+	exp := now.Add(time.Minute)
+	transfer := &expectedTransaction{contractType: transferContractType, owner: owner, to: to, amount: 1000}
+	data := append(transferSelector[:], make([]byte, 64)...)
+	trigger := &expectedTransaction{contractType: triggerSmartContractType, owner: owner, to: to, data: data, feeLimit: 5000}
+
+	tests := []struct {
+		name  string
+		raw   []byte
+		want  *expectedTransaction
+		field string // "" means the transaction verifies
+	}{
+		{"transfer", rawTransfer(owner, to, 1000, exp), transfer, ""},
+		{"trigger", rawTrigger(owner, to, data, 5000, exp), trigger, ""},
+		{"amount", rawTransfer(owner, to, 1001, exp), transfer, "amount"},
+		{"recipient", rawTransfer(owner, owner, 1000, exp), transfer, "to_address"},
+		{"owner", rawTransfer(to, to, 1000, exp), transfer, "owner_address"},
+		{"expired", rawTransfer(owner, to, 1000, now.Add(-time.Second)), transfer, "expiration"},
+		{"far expiration", rawTransfer(owner, to, 1000, now.Add(48*time.Hour)), transfer, "expiration"},
+		{"contract type", rawTrigger(owner, to, data, 5000, exp), transfer, "contract type"},
+		{"fee limit", rawTrigger(owner, to, data, 1_000_000_000, exp), trigger, "fee_limit"},
+		{"call data", rawTrigger(owner, to, data[:4], 5000, exp), trigger, "data"},
+		{"two contracts", append(rawTransfer(owner, to, 1000, exp), pbBytes(11, nil)...), transfer, "contract count"},
+		{"type url", rawData(transferContractType, "type.googleapis.com/protocol.TriggerSmartContract",
+			concat(pbBytes(1, owner), pbBytes(2, to), pbVarint(3, 1000)), 0, exp), transfer, "parameter type_url"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := &tronTransaction{RawDataHex: hex.EncodeToString(tt.raw)}
+			sum := sha256.Sum256(tt.raw)
+			txn.TxID = hex.EncodeToString(sum[:])
+
+			err := verifyTransaction(txn, tt.want, now)
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("Expected transaction to verify, got %v", err)
+				}
+				return
+			}
+			var mismatch *MismatchError
+			if !errors.As(err, &mismatch) || !errors.Is(err, ErrTransactionMismatch) {
+				t.Fatalf("Expected a MismatchError, got %v", err)
+			}
+			if mismatch.Field != tt.field {
+				t.Errorf("Expected mismatch in %s, got %s", tt.field, mismatch.Field)
+			}
+		})
+	}
+}
+
+func TestVerifyTransaction_MalformedParameter(t *testing.T) {
+	owner, _ := decodeAddress("TF17BgPaZYbz8oxbjhriubPDsA7ArKoLX3")
+	to, _ := decodeAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	exp := time.Now().Add(time.Minute)
+
+	// A valid value followed by a truncated field inside the Any.
+	value := concat(pbBytes(1, owner), pbBytes(2, to), pbVarint(3, 1000))
+	parameter := concat(pbBytes(1, []byte("type.googleapis.com/protocol.TransferContract")), pbBytes(2, value), []byte{0x1a, 0x05})
+	contract := concat(pbVarint(1, transferContractType), pbBytes(2, parameter))
+	raw := concat(pbVarint(8, uint64(exp.UnixMilli())), pbBytes(11, contract))
+
+	txn := &tronTransaction{RawDataHex: hex.EncodeToString(raw)}
+	sum := sha256.Sum256(raw)
+	txn.TxID = hex.EncodeToString(sum[:])
+	want := &expectedTransaction{contractType: transferContractType, owner: owner, to: to, amount: 1000}
+	if err := verifyTransaction(txn, want, time.Now()); err == nil {
+		t.Error("Expected error for a malformed contract parameter")
+	}
+}
+
+func TestVerifyTransaction_TxID(t *testing.T) {
+	owner, _ := decodeAddress("TF17BgPaZYbz8oxbjhriubPDsA7ArKoLX3")
+	raw := rawTransfer(owner, owner, 1, time.Now().Add(time.Minute))
+	txn := &tronTransaction{TxID: hex.EncodeToString(make([]byte, 32)), RawDataHex: hex.EncodeToString(raw)}
+
+	err := verifyTransaction(txn, &expectedTransaction{contractType: transferContractType, owner: owner, to: owner, amount: 1}, time.Now())
+	var mismatch *MismatchError
+	if !errors.As(err, &mismatch) || mismatch.Field != "txID" {
+		t.Errorf("Expected txID mismatch, got %v", err)
+	}
+}
@@ -137,6 +137,24 @@ func (s *ConditionSetsService) ListItems(ctx context.Context, conditionSetID str
 	return &resp, nil
 }
 
+// IterItems returns an Iterator over all items in a condition set.
+func (s *ConditionSetsService) IterItems(ctx context.Context, conditionSetID string, opts *ListOptions, iterOpts *IteratorOptions) *Iterator[ConditionSetItem] {
+	var base ListOptions
+	if opts != nil {
+		base = *opts
+	}
+	return NewIterator(ctx, base.Cursor, func(ctx context.Context, cursor string) (*PaginatedResponse[ConditionSetItem], error) {
+		page := base
+		page.Cursor = cursor
+		return s.ListItems(ctx, conditionSetID, &page)
+	}, iterOpts)
+}
+
+// AllItems iterates over all items in a condition set, fetching pages lazily.
+func (s *ConditionSetsService) AllItems(ctx context.Context, conditionSetID string, opts *ListOptions, iterOpts *IteratorOptions) Seq2[ConditionSetItem, error] {
+	return s.IterItems(ctx, conditionSetID, opts, iterOpts).All()
+}
+
 // GetItem retrieves a specific item from a condition set.
 func (s *ConditionSetsService) GetItem(ctx context.Context, conditionSetID, itemID string) (*ConditionSetItem, error) {
 	if s == nil || s.client == nil {
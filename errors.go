@@ -0,0 +1,138 @@
+package privy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by APIError through errors.Is.
+var (
+	// ErrBadRequest is returned for 400 responses.
+	ErrBadRequest = errors.New("privy: bad request")
+
+	// ErrUnauthorized is returned for 401 responses, usually a wrong app ID or secret.
+	ErrUnauthorized = errors.New("privy: unauthorized")
+
+	// ErrForbidden is returned for 403 responses.
+	ErrForbidden = errors.New("privy: forbidden")
+
+	// ErrNotFound is returned for 404 responses.
+	ErrNotFound = errors.New("privy: not found")
+
+	// ErrConflict is returned for 409 responses, such as creating a user that already exists.
+	ErrConflict = errors.New("privy: conflict")
+
+	// ErrRateLimited is returned for 429 responses.
+	ErrRateLimited = errors.New("privy: rate limited")
+
+	// ErrPolicyViolation is returned when a wallet policy denies the request.
+	ErrPolicyViolation = errors.New("privy: policy violation")
+
+	// ErrServerError is returned for 5xx responses.
+	ErrServerError = errors.New("privy: server error")
+)
+
+// requestIDHeaders are the response headers checked, in order, for a request ID.
+var requestIDHeaders = []string{"privy-request-id", "x-request-id"}
+
+// APIError represents an error response from the Privy API.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Message    string `json:"message"`
+	Error_     string `json:"error"`
+	Code       string `json:"code"`
+
+	// RequestID identifies the request in Privy's logs. Include it in support tickets.
+	RequestID string `json:"-"`
+
+	// Header holds the response headers.
+	Header http.Header `json:"-"`
+
+	// Body is the raw response body.
+	Body []byte `json:"-"`
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = e.Error_
+	}
+	suffix := ""
+	if e.RequestID != "" {
+		suffix = fmt.Sprintf(" (request %s)", e.RequestID)
+	}
+	if msg != "" {
+		return fmt.Sprintf("privy: API error (status %d): %s%s", e.StatusCode, msg, suffix)
+	}
+	return fmt.Sprintf("privy: API error (status %d)%s", e.StatusCode, suffix)
+}
+
+// Unwrap returns the sentinel error matching the response, so callers can
+// use errors.Is(err, privy.ErrNotFound) and friends.
+func (e *APIError) Unwrap() error {
+	if e.isPolicyViolation() {
+		return ErrPolicyViolation
+	}
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return ErrBadRequest
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return ErrForbidden
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusConflict:
+		return ErrConflict
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= 500:
+		return ErrServerError
+	}
+	return nil
+}
+
+// isPolicyViolation reports whether the API rejected the request because of a
+// wallet policy. Privy reports these as 400/403 with a policy error code.
+func (e *APIError) isPolicyViolation() bool {
+	if e.StatusCode != http.StatusBadRequest && e.StatusCode != http.StatusForbidden {
+		return false
+	}
+	return strings.Contains(strings.ToLower(e.Code), "policy")
+}
+
+// newAPIError builds an APIError from a non-2xx response.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{}
+	if err := json.Unmarshal(body, apiErr); err != nil {
+		apiErr = &APIError{Message: string(body)}
+	}
+	apiErr.StatusCode = resp.StatusCode
+	apiErr.Header = resp.Header
+	apiErr.Body = body
+	for _, h := range requestIDHeaders {
+		if id := resp.Header.Get(h); id != "" {
+			apiErr.RequestID = id
+			break
+		}
+	}
+	return apiErr
+}
+
+// IsNotFound reports whether err is a 404 from the Privy API.
+func IsNotFound(err error) bool { return errors.Is(err, ErrNotFound) }
+
+// IsUnauthorized reports whether err is a 401 from the Privy API.
+func IsUnauthorized(err error) bool { return errors.Is(err, ErrUnauthorized) }
+
+// IsConflict reports whether err is a 409 from the Privy API.
+func IsConflict(err error) bool { return errors.Is(err, ErrConflict) }
+
+// IsRateLimited reports whether err is a 429 from the Privy API.
+func IsRateLimited(err error) bool { return errors.Is(err, ErrRateLimited) }
+
+// IsPolicyViolation reports whether err was caused by a wallet policy denying the request.
+func IsPolicyViolation(err error) bool { return errors.Is(err, ErrPolicyViolation) }
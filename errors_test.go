@@ -0,0 +1,106 @@
+package privy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError_Sentinels(t *testing.T) {
+	tests := []struct {
+		status int
+		code   string
+		want   error
+		is     func(error) bool
+	}{
+		{http.StatusNotFound, "user_not_found", ErrNotFound, IsNotFound},
+		{http.StatusUnauthorized, "", ErrUnauthorized, IsUnauthorized},
+		{http.StatusConflict, "user_exists", ErrConflict, IsConflict},
+		{http.StatusTooManyRequests, "", ErrRateLimited, IsRateLimited},
+		{http.StatusForbidden, "policy_violation", ErrPolicyViolation, IsPolicyViolation},
+		{http.StatusBadRequest, "invalid_params", ErrBadRequest, nil},
+		{http.StatusForbidden, "", ErrForbidden, nil},
+		{http.StatusBadGateway, "", ErrServerError, nil},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d_%s", tt.status, tt.code), func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: tt.status, Code: tt.code})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected errors.Is(%v), got false", tt.want)
+			}
+			if tt.is != nil && !tt.is(err) {
+				t.Error("expected predicate to match")
+			}
+		})
+	}
+
+	if IsNotFound(errors.New("not found")) {
+		t.Error("expected plain error not to match ErrNotFound")
+	}
+	if IsPolicyViolation(&APIError{StatusCode: http.StatusNotFound, Code: "policy_not_found"}) {
+		t.Error("expected 404 for a missing policy not to be a policy violation")
+	}
+}
+
+func TestAPIError_CarriesResponseDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("x-request-id", "req-123")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Wallet not found",
+			"code":    "wallet_not_found",
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("app-id", "app-secret", WithBaseURL(server.URL+"/v1"))
+
+	_, err := client.Wallets().Get(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.RequestID != "req-123" {
+		t.Errorf("expected request ID req-123, got %q", apiErr.RequestID)
+	}
+	if apiErr.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("expected response headers, got %v", apiErr.Header)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(apiErr.Body, &body); err != nil || body["code"] != "wallet_not_found" {
+		t.Errorf("expected raw body, got %s", apiErr.Body)
+	}
+	if got := apiErr.Error(); got != "privy: API error (status 404): Wallet not found (request req-123)" {
+		t.Errorf("unexpected error string %q", got)
+	}
+}
+
+func TestAPIError_NonJSONBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient("app-id", "app-secret", WithBaseURL(server.URL+"/v1"))
+
+	_, err := client.Wallets().Get(context.Background(), "wallet-1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Message != "upstream unavailable\n" {
+		t.Errorf("expected body as message, got %q", apiErr.Message)
+	}
+	if !errors.Is(err, ErrServerError) {
+		t.Errorf("expected ErrServerError, got %v", err)
+	}
+}
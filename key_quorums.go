@@ -12,7 +12,10 @@ type KeyQuorumsService struct {
 
 // CreateKeyQuorumRequest represents a request to create a key quorum.
 type CreateKeyQuorumRequest struct {
-	PublicKey string `json:"public_key"`
+	PublicKey              string   `json:"public_key,omitempty"`
+	PublicKeys             []string `json:"public_keys,omitempty"`             // P-256 keys of all quorum members
+	AuthorizationThreshold int      `json:"authorization_threshold,omitempty"` // Signatures required (defaults to all keys)
+	DisplayName            string   `json:"display_name,omitempty"`
 }
 
 // UpdateKeyQuorumRequest represents a request to update a key quorum.
@@ -0,0 +1,141 @@
+package privy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// APIRequest is an outgoing Privy API call as seen by middleware.
+type APIRequest struct {
+	// Operation names the call, such as "wallets.raw_sign", "users.get" or
+	// "wallets.rpc.eth_sendTransaction".
+	Operation string
+
+	Method string
+	URL    string
+
+	// Header holds the headers that will be sent, including credentials.
+	// Middleware may add headers; redact before logging.
+	Header http.Header
+
+	// Body is the JSON request body, or nil.
+	Body []byte
+
+	// Attempt is 0 for the first try and increments on each retry.
+	Attempt int
+}
+
+// APIResponse is a Privy API response as seen by middleware.
+type APIResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// RoundTripFunc sends an APIRequest. On a non-2xx status it returns both the
+// response and an *APIError.
+type RoundTripFunc func(ctx context.Context, req *APIRequest) (*APIResponse, error)
+
+// Middleware wraps a RoundTripFunc, for example to log, audit or measure calls.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware adds middleware to the client. The first middleware is the
+// outermost. Middleware runs for every attempt of every call made through the
+// client, including calls made by chain helpers.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// roundTripper returns the transport wrapped in the client's middleware.
+func (c *Client) roundTripper() RoundTripFunc {
+	rt := c.transport
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		rt = c.middleware[i](rt)
+	}
+	return rt
+}
+
+// operationName derives an operation name such as "wallets.raw_sign" from a request.
+func (c *Client) operationName(method, rawURL string, body []byte) string {
+	path := rawURL
+	for _, base := range []string{c.baseURL, c.authURL} {
+		if base != "" && strings.HasPrefix(rawURL, base) {
+			path = strings.TrimPrefix(rawURL, base)
+			break
+		}
+	}
+	if u, err := url.Parse(path); err == nil {
+		path = u.Path
+	}
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segs) == 0 || segs[0] == "" {
+		return strings.ToLower(method)
+	}
+
+	switch {
+	case segs[0] == "users" && isUserLookup(path):
+		provider, field := segs[1], strings.TrimPrefix(segs[2], segs[1]+"_")
+		return "users.get_by_" + provider + "_" + field
+	case segs[0] == "wallets" && len(segs) == 3 && segs[1] == "import":
+		return "wallets.import_" + segs[2]
+	}
+
+	// Paths alternate between collections and IDs: /wallets/{id}/rpc
+	var names []string
+	lastIsID := false
+	for i, seg := range segs {
+		if i%2 == 0 {
+			names = append(names, strings.ReplaceAll(seg, "-", "_"))
+			lastIsID = false
+		} else {
+			lastIsID = true
+		}
+	}
+	op := strings.Join(names, ".")
+
+	last := names[len(names)-1]
+	switch {
+	case last == "rpc":
+		var rpc struct {
+			Method string `json:"method"`
+		}
+		if json.Unmarshal(body, &rpc) == nil && rpc.Method != "" {
+			return op + "." + rpc.Method
+		}
+		return op
+	case lastIsID:
+		switch method {
+		case http.MethodGet:
+			return op + ".get"
+		case http.MethodPatch, http.MethodPut:
+			return op + ".update"
+		case http.MethodDelete:
+			return op + ".delete"
+		}
+	case len(names) == 1 || isCollection(last):
+		switch method {
+		case http.MethodGet:
+			return op + ".list"
+		case http.MethodPost:
+			return op + ".create"
+		case http.MethodDelete:
+			return op + ".delete"
+		}
+	}
+	return op
+}
+
+// isCollection reports whether a nested path segment lists sub-resources
+// rather than naming an action.
+func isCollection(name string) bool {
+	switch name {
+	case "rules", "items", "transactions":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package privy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOperationName(t *testing.T) {
+	client := NewClient("app-id", "app-secret")
+	base, auth := client.baseURL, client.authURL
+
+	tests := []struct {
+		method string
+		url    string
+		body   string
+		want   string
+	}{
+		{"POST", base + "/wallets/w1/raw_sign", "", "wallets.raw_sign"},
+		{"POST", base + "/wallets/w1/rpc", `{"method":"eth_sendTransaction"}`, "wallets.rpc.eth_sendTransaction"},
+		{"POST", base + "/wallets", "", "wallets.create"},
+		{"GET", base + "/wallets?limit=10", "", "wallets.list"},
+		{"GET", base + "/wallets/w1", "", "wallets.get"},
+		{"PATCH", base + "/wallets/w1", "", "wallets.update"},
+		{"GET", base + "/wallets/w1/balance", "", "wallets.balance"},
+		{"GET", base + "/wallets/w1/transactions?chain=base", "", "wallets.transactions.list"},
+		{"POST", base + "/wallets/import/initialize", "", "wallets.import_initialize"},
+		{"GET", base + "/transactions/tx1", "", "transactions.get"},
+		{"POST", base + "/policies/p1/rules", "", "policies.rules.create"},
+		{"DELETE", base + "/policies/p1/rules/r1", "", "policies.rules.delete"},
+		{"GET", base + "/condition-sets/c1/items", "", "condition_sets.items.list"},
+		{"PATCH", base + "/key-quorums/k1", "", "key_quorums.update"},
+		{"GET", auth + "/users/did:privy:1", "", "users.get"},
+		{"POST", auth + "/users/email/address", "", "users.get_by_email_address"},
+		{"POST", auth + "/users/telegram/telegram_user_id", "", "users.get_by_telegram_user_id"},
+		{"POST", auth + "/users/did:privy:1/custom_metadata", "", "users.custom_metadata"},
+	}
+	for _, tt := range tests {
+		if got := client.operationName(tt.method, tt.url, []byte(tt.body)); got != tt.want {
+			t.Errorf("operationName(%s %s) = %s, want %s", tt.method, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestWithMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-audit-id") != "audit-1" {
+			t.Errorf("expected header added by middleware, got %q", r.Header.Get("x-audit-id"))
+		}
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"message": "not found"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"method": "raw_sign"})
+	}))
+	defer server.Close()
+
+	var calls []string
+	var seenBody string
+	var seenErr error
+	record := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(ctx context.Context, req *APIRequest) (*APIResponse, error) {
+				calls = append(calls, name+">"+req.Operation)
+				resp, err := next(ctx, req)
+				calls = append(calls, name+"<")
+				return resp, err
+			}
+		}
+	}
+	audit := func(next RoundTripFunc) RoundTripFunc {
+		return func(ctx context.Context, req *APIRequest) (*APIResponse, error) {
+			req.Header.Set("x-audit-id", "audit-1")
+			seenBody = string(req.Body)
+			resp, err := next(ctx, req)
+			seenErr = err
+			if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+				t.Errorf("expected the response alongside the error, got %+v", resp)
+			}
+			return resp, err
+		}
+	}
+
+	client := NewClient("app-id", "app-secret",
+		WithBaseURL(server.URL+"/v1"),
+		WithMiddleware(record("outer"), record("inner")),
+		WithMiddleware(audit),
+	)
+
+	if _, err := client.RawSign(context.Background(), "wallet-1", "0xabcd"); err != nil {
+		t.Fatalf("RawSign failed: %v", err)
+	}
+	if strings.Join(calls, " ") != "outer>wallets.raw_sign inner>wallets.raw_sign inner< outer<" {
+		t.Errorf("unexpected middleware order: %v", calls)
+	}
+	if !strings.Contains(seenBody, `"hash":"0xabcd"`) {
+		t.Errorf("expected middleware to see the request body, got %s", seenBody)
+	}
+
+	_, err := client.Wallets().Get(context.Background(), "missing")
+	if !IsNotFound(err) || !IsNotFound(seenErr) {
+		t.Errorf("expected middleware and caller to see ErrNotFound, got %v / %v", seenErr, err)
+	}
+}
+
+func TestWithMiddleware_ShortCircuit(t *testing.T) {
+	blocked := errors.New("blocked by middleware")
+	client := NewClient("app-id", "app-secret",
+		WithBaseURL("http://127.0.0.1:0/v1"),
+		WithMiddleware(func(next RoundTripFunc) RoundTripFunc {
+			return func(ctx context.Context, req *APIRequest) (*APIResponse, error) {
+				if req.Operation == "wallets.export" {
+					return nil, blocked
+				}
+				return next(ctx, req)
+			}
+		}),
+	)
+
+	_, err := client.Wallets().Export(context.Background(), "wallet-1", "sig")
+	if !errors.Is(err, blocked) {
+		t.Errorf("expected middleware error, got %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+// Package otelprivy instruments the Privy SDK with OpenTelemetry.
+//
+// It creates one span per API call, named after the operation
+// (wallets.raw_sign, users.get, ...), child spans for chain helper phases,
+// and records latency and error metrics:
+//
+//	client := privy.NewClient(appID, appSecret, otelprivy.WithTelemetry())
+//
+// The core SDK has no OpenTelemetry dependency; this module plugs into it
+// through privy.WithTracer.
+package otelprivy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+const instrumentationName = "github.com/vadimzhukck/privy-sdk-go/otelprivy"
+
+// Metric names.
+const (
+	MetricDuration = "privy.client.operation.duration"
+	MetricErrors   = "privy.client.operation.errors"
+)
+
+// Option configures the instrumentation.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the tracer provider. Defaults to otel.GetTracerProvider().
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the meter provider. Defaults to otel.GetMeterProvider().
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithTelemetry returns a privy.ClientOption that enables OpenTelemetry instrumentation.
+func WithTelemetry(opts ...Option) privy.ClientOption {
+	return privy.WithTracer(NewTracer(opts...))
+}
+
+// Tracer implements privy.Tracer with OpenTelemetry.
+type Tracer struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+}
+
+// NewTracer returns a privy.Tracer backed by OpenTelemetry.
+func NewTracer(opts ...Option) *Tracer {
+	cfg := config{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.tracerProvider == nil {
+		cfg.tracerProvider = otel.GetTracerProvider()
+	}
+	if cfg.meterProvider == nil {
+		cfg.meterProvider = otel.GetMeterProvider()
+	}
+
+	meter := cfg.meterProvider.Meter(instrumentationName)
+	duration, err := meter.Float64Histogram(MetricDuration,
+		metric.WithDescription("Duration of Privy API calls and chain helper phases."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	errCounter, err := meter.Int64Counter(MetricErrors,
+		metric.WithDescription("Number of failed Privy API calls and chain helper phases."),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return &Tracer{
+		tracer:   cfg.tracerProvider.Tracer(instrumentationName),
+		duration: duration,
+		errors:   errCounter,
+	}
+}
+
+// Start implements privy.Tracer. API calls become client spans; chain helper
+// phases become internal spans.
+func (t *Tracer) Start(ctx context.Context, name string, attrs ...privy.Attribute) (context.Context, privy.Span) {
+	kind := trace.SpanKindInternal
+	for _, a := range attrs {
+		if a.Key == privy.AttrHTTPMethod {
+			kind = trace.SpanKindClient
+			break
+		}
+	}
+
+	ctx, span := t.tracer.Start(ctx, name,
+		trace.WithSpanKind(kind),
+		trace.WithAttributes(convert(attrs)...),
+	)
+	return ctx, &otelSpan{
+		span:   span,
+		tracer: t,
+		ctx:    ctx,
+		name:   name,
+		start:  time.Now(),
+	}
+}
+
+type otelSpan struct {
+	span   trace.Span
+	tracer *Tracer
+	ctx    context.Context
+	name   string
+	start  time.Time
+}
+
+func (s *otelSpan) SetAttributes(attrs ...privy.Attribute) {
+	s.span.SetAttributes(convert(attrs)...)
+}
+
+func (s *otelSpan) End(err error) {
+	attrs := []attribute.KeyValue{attribute.String(privy.AttrOperation, s.name)}
+	if err != nil {
+		errType := errorType(err)
+		attrs = append(attrs, attribute.String("error.type", errType))
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+		s.span.SetAttributes(attribute.String("error.type", errType))
+		if s.tracer.errors != nil {
+			s.tracer.errors.Add(s.ctx, 1, metric.WithAttributes(attrs...))
+		}
+	}
+	if s.tracer.duration != nil {
+		s.tracer.duration.Record(s.ctx, time.Since(s.start).Seconds(), metric.WithAttributes(attrs...))
+	}
+	s.span.End()
+}
+
+// errorType classifies err for the error.type attribute: the HTTP status
+// for API errors, otherwise a coarse category.
+func errorType(err error) string {
+	var apiErr *privy.APIError
+	switch {
+	case errors.As(err, &apiErr):
+		return strconv.Itoa(apiErr.StatusCode)
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	}
+	return "_OTHER"
+}
+
+// convert maps privy attributes to OpenTelemetry attributes.
+func convert(attrs []privy.Attribute) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		switch v := a.Value.(type) {
+		case string:
+			kvs = append(kvs, attribute.String(a.Key, v))
+		case int:
+			kvs = append(kvs, attribute.Int(a.Key, v))
+		case int64:
+			kvs = append(kvs, attribute.Int64(a.Key, v))
+		case uint64:
+			kvs = append(kvs, attribute.Int64(a.Key, int64(v)))
+		case float64:
+			kvs = append(kvs, attribute.Float64(a.Key, v))
+		case bool:
+			kvs = append(kvs, attribute.Bool(a.Key, v))
+		case []string:
+			kvs = append(kvs, attribute.StringSlice(a.Key, v))
+		case fmt.Stringer:
+			kvs = append(kvs, attribute.String(a.Key, v.String()))
+		default:
+			kvs = append(kvs, attribute.String(a.Key, fmt.Sprint(v)))
+		}
+	}
+	return kvs
+}
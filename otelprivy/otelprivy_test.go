@@ -0,0 +1,124 @@
+package otelprivy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+func setup(t *testing.T) (*privy.Client, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"method": "raw_sign",
+			"data":   map[string]any{"signature": "0xabc", "encoding": "hex"},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	recorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	client := privy.NewClient("app-id", "app-secret",
+		privy.WithBaseURL(server.URL+"/v1"),
+		WithTelemetry(
+			WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+			WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		),
+	)
+	return client, recorder, reader
+}
+
+func attrMap(kvs []attribute.KeyValue) map[string]string {
+	m := map[string]string{}
+	for _, kv := range kvs {
+		m[string(kv.Key)] = kv.Value.Emit()
+	}
+	return m
+}
+
+func TestTelemetry_APICallSpan(t *testing.T) {
+	client, recorder, _ := setup(t)
+
+	if _, err := client.RawSign(context.Background(), "wallet-1", "0x1234"); err != nil {
+		t.Fatalf("RawSign failed: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "wallets.raw_sign" {
+		t.Errorf("expected span wallets.raw_sign, got %s", span.Name())
+	}
+	if span.SpanKind() != trace.SpanKindClient {
+		t.Errorf("expected client span, got %v", span.SpanKind())
+	}
+	attrs := attrMap(span.Attributes())
+	if attrs[privy.AttrWalletID] != "wallet-1" || attrs[privy.AttrStatusCode] != "200" || attrs[privy.AttrRetryCount] != "0" {
+		t.Errorf("unexpected attributes %v", attrs)
+	}
+}
+
+func TestTelemetry_ChildSpansAndErrors(t *testing.T) {
+	client, recorder, reader := setup(t)
+
+	ctx, parent := client.StartSpan(context.Background(), "near.transfer")
+	_, err := client.Wallets().Get(ctx, "missing")
+	parent.End(err)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	child, root := spans[0], spans[1]
+	if child.Parent().SpanID() != root.SpanContext().SpanID() {
+		t.Error("expected API call span to be a child of the helper span")
+	}
+	if root.SpanKind() != trace.SpanKindInternal {
+		t.Errorf("expected internal span for helper phase, got %v", root.SpanKind())
+	}
+	if child.Status().Code != codes.Error || attrMap(child.Attributes())["error.type"] != "404" {
+		t.Errorf("expected error status with error.type 404, got %v %v", child.Status(), child.Attributes())
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	found := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			found[m.Name] = true
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == MetricErrors {
+				var total int64
+				for _, dp := range sum.DataPoints {
+					total += dp.Value
+				}
+				if total != 2 {
+					t.Errorf("expected 2 errors recorded, got %d", total)
+				}
+			}
+		}
+	}
+	if !found[MetricDuration] || !found[MetricErrors] {
+		t.Errorf("expected duration and error metrics, got %v", found)
+	}
+}
@@ -0,0 +1,168 @@
+package privy
+
+import (
+	"context"
+)
+
+// Seq2 is a push iterator over pairs of values. It has the same underlying
+// type as iter.Seq2 in Go 1.23, so it can be used with range-over-func:
+//
+//	for user, err := range client.Users().All(ctx, nil, nil) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(user.ID)
+//	}
+type Seq2[K, V any] func(yield func(K, V) bool)
+
+// PageFetcher fetches the page of results starting at cursor.
+// An empty cursor requests the first page.
+type PageFetcher[T any] func(ctx context.Context, cursor string) (*PaginatedResponse[T], error)
+
+// IteratorOptions controls how an Iterator walks a paginated endpoint.
+type IteratorOptions struct {
+	// MaxItems stops iteration after this many items. Zero means no limit.
+	MaxItems int
+
+	// Prefetch requests the next page in the background while the
+	// current one is being consumed.
+	Prefetch bool
+}
+
+// Iterator lazily walks every item of a paginated endpoint, fetching pages
+// on demand. Use it like bufio.Scanner:
+//
+//	it := client.Wallets().Iter(ctx, nil, nil)
+//	defer it.Close()
+//	for it.Next() {
+//	    wallet := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//	    return err
+//	}
+type Iterator[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	fetch  PageFetcher[T]
+	opts   IteratorOptions
+
+	page    []T
+	idx     int
+	cur     T
+	count   int
+	cursor  string
+	done    bool
+	err     error
+	pending chan pageResult[T]
+}
+
+type pageResult[T any] struct {
+	page *PaginatedResponse[T]
+	err  error
+}
+
+// NewIterator returns an Iterator that starts at cursor and fetches pages with fetch.
+// A nil opts uses the defaults.
+func NewIterator[T any](ctx context.Context, cursor string, fetch PageFetcher[T], opts *IteratorOptions) *Iterator[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	it := &Iterator[T]{
+		ctx:    ctx,
+		cancel: cancel,
+		fetch:  fetch,
+		cursor: cursor,
+	}
+	if opts != nil {
+		it.opts = *opts
+	}
+	return it
+}
+
+// Next advances to the next item. It returns false when the items are
+// exhausted, MaxItems is reached, or an error occurs.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil || (it.opts.MaxItems > 0 && it.count >= it.opts.MaxItems) {
+		it.Close()
+		return false
+	}
+
+	for it.idx >= len(it.page) {
+		if it.done {
+			it.Close()
+			return false
+		}
+		resp, err := it.nextPage()
+		if err != nil {
+			it.err = err
+			it.Close()
+			return false
+		}
+		if resp == nil {
+			resp = &PaginatedResponse[T]{}
+		}
+		it.page, it.idx = resp.Data, 0
+		it.cursor = resp.NextCursor
+		it.done = resp.NextCursor == ""
+		if it.opts.Prefetch && !it.done {
+			it.prefetch()
+		}
+	}
+
+	it.cur = it.page[it.idx]
+	it.idx++
+	it.count++
+	return true
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.cur
+}
+
+// Err returns the first error encountered while fetching pages.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops the iterator and cancels any in-flight prefetch.
+// It is safe to call more than once.
+func (it *Iterator[T]) Close() {
+	it.cancel()
+}
+
+// All returns the remaining items as a Seq2. Iteration stops at the first
+// error, which is yielded with the zero value of T. Breaking out of the
+// loop closes the iterator.
+func (it *Iterator[T]) All() Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer it.Close()
+		for it.Next() {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// nextPage returns the prefetched page if there is one, otherwise fetches it.
+func (it *Iterator[T]) nextPage() (*PaginatedResponse[T], error) {
+	if it.pending != nil {
+		r := <-it.pending
+		it.pending = nil
+		return r.page, r.err
+	}
+	return it.fetch(it.ctx, it.cursor)
+}
+
+// prefetch starts fetching the page at the current cursor in the background.
+func (it *Iterator[T]) prefetch() {
+	ch := make(chan pageResult[T], 1)
+	it.pending = ch
+	go func(cursor string) {
+		page, err := it.fetch(it.ctx, cursor)
+		ch <- pageResult[T]{page: page, err: err}
+	}(it.cursor)
+}
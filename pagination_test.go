@@ -0,0 +1,171 @@
+package privy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// pagedFetcher serves pages of ints: cursor "" is page 0, "1" page 1, and so on.
+func pagedFetcher(pages [][]int, calls *int32) PageFetcher[int] {
+	return func(ctx context.Context, cursor string) (*PaginatedResponse[int], error) {
+		atomic.AddInt32(calls, 1)
+		idx := 0
+		if cursor != "" {
+			idx, _ = strconv.Atoi(cursor)
+		}
+		resp := &PaginatedResponse[int]{Data: pages[idx]}
+		if idx+1 < len(pages) {
+			resp.NextCursor = strconv.Itoa(idx + 1)
+		}
+		return resp, nil
+	}
+}
+
+func collect(t *testing.T, seq Seq2[int, error]) ([]int, error) {
+	t.Helper()
+	var got []int
+	var gotErr error
+	seq(func(v int, err error) bool {
+		if err != nil {
+			gotErr = err
+			return false
+		}
+		got = append(got, v)
+		return true
+	})
+	return got, gotErr
+}
+
+func TestIterator_WalksAllPages(t *testing.T) {
+	var calls int32
+	pages := [][]int{{1, 2}, {}, {3}, {4, 5}}
+
+	for _, prefetch := range []bool{false, true} {
+		calls = 0
+		it := NewIterator(context.Background(), "", pagedFetcher(pages, &calls), &IteratorOptions{Prefetch: prefetch})
+		got, err := collect(t, it.All())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fmt.Sprint(got) != "[1 2 3 4 5]" {
+			t.Errorf("prefetch=%v: expected [1 2 3 4 5], got %v", prefetch, got)
+		}
+		if calls != 4 {
+			t.Errorf("prefetch=%v: expected 4 page fetches, got %d", prefetch, calls)
+		}
+	}
+}
+
+func TestIterator_EarlyTerminationIsLazy(t *testing.T) {
+	var calls int32
+	it := NewIterator(context.Background(), "", pagedFetcher([][]int{{1, 2}, {3, 4}, {5}}, &calls), nil)
+
+	var got []int
+	it.All()(func(v int, err error) bool {
+		got = append(got, v)
+		return len(got) < 2
+	})
+	if fmt.Sprint(got) != "[1 2]" {
+		t.Errorf("expected [1 2], got %v", got)
+	}
+	if calls != 1 {
+		t.Errorf("expected only the first page to be fetched, got %d fetches", calls)
+	}
+}
+
+func TestIterator_MaxItems(t *testing.T) {
+	var calls int32
+	it := NewIterator(context.Background(), "", pagedFetcher([][]int{{1, 2}, {3, 4}, {5}}, &calls), &IteratorOptions{MaxItems: 3})
+
+	got, err := collect(t, it.All())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(got) != "[1 2 3]" {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 page fetches, got %d", calls)
+	}
+}
+
+func TestIterator_StopsOnError(t *testing.T) {
+	boom := errors.New("boom")
+	fetch := func(ctx context.Context, cursor string) (*PaginatedResponse[int], error) {
+		if cursor == "" {
+			return &PaginatedResponse[int]{Data: []int{1}, NextCursor: "next"}, nil
+		}
+		return nil, boom
+	}
+
+	it := NewIterator(context.Background(), "", fetch, nil)
+	for it.Next() {
+		if it.Value() != 1 {
+			t.Errorf("expected 1, got %d", it.Value())
+		}
+	}
+	if !errors.Is(it.Err(), boom) {
+		t.Errorf("expected boom, got %v", it.Err())
+	}
+
+	got, err := collect(t, NewIterator(context.Background(), "", fetch, nil).All())
+	if fmt.Sprint(got) != "[1]" || !errors.Is(err, boom) {
+		t.Errorf("expected [1] and boom, got %v and %v", got, err)
+	}
+}
+
+func TestUsersService_All(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "2" {
+			t.Errorf("expected limit=2 on every page, got %q", r.URL.RawQuery)
+		}
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			json.NewEncoder(w).Encode(map[string]any{
+				"data":        []map[string]any{{"id": "did:privy:1"}, {"id": "did:privy:2"}},
+				"next_cursor": "page-2",
+			})
+		case "page-2":
+			json.NewEncoder(w).Encode(map[string]any{
+				"data": []map[string]any{{"id": "did:privy:3"}},
+			})
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("app-id", "app-secret", WithAuthURL(server.URL+"/api/v1"))
+
+	var ids []string
+	client.Users().All(context.Background(), &ListOptions{Limit: 2}, &IteratorOptions{Prefetch: true})(func(u User, err error) bool {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, u.ID)
+		return true
+	})
+	if fmt.Sprint(ids) != "[did:privy:1 did:privy:2 did:privy:3]" {
+		t.Errorf("unexpected users %v", ids)
+	}
+}
+
+func TestWalletsService_AllTransactions_RequiresOptions(t *testing.T) {
+	client := NewClient("app-id", "app-secret")
+
+	var gotErr error
+	client.Wallets().AllTransactions(context.Background(), "wallet-1", nil, nil)(func(tx Transaction, err error) bool {
+		gotErr = err
+		return false
+	})
+	if gotErr == nil {
+		t.Error("expected error for missing options")
+	}
+}
@@ -38,6 +38,14 @@ type Client struct {
 	httpClient *http.Client
 	testnet    bool
 	chainOpts  map[string][]any
+
+	authorizationSigner AuthorizationSigner
+	authorizationErr    error
+
+	retryPolicy RetryPolicy
+	rateLimiter *rateLimiter
+	middleware  []Middleware
+	tracer      Tracer
 }
 
 // ClientOption is a function that configures the Client.
@@ -194,7 +202,7 @@ func (c *Client) RawSign(ctx context.Context, walletID string, hash string) (*Ra
 	u := fmt.Sprintf("%s/wallets/%s/raw_sign", c.baseURL, walletID)
 	req := &RawSignHashRequest{Params: RawSignHashParams{Hash: hash}}
 	var resp RawSignResponse
-	if err := c.doRequest(ctx, "POST", u, req, &resp); err != nil {
+	if err := c.doRequestWithSignature(ctx, "POST", u, req, &resp, ""); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -211,7 +219,7 @@ func (c *Client) RawSignBytes(ctx context.Context, walletID string, data string,
 		Bytes: data, Encoding: encoding, HashFunction: hashFunction,
 	}}
 	var resp RawSignResponse
-	if err := c.doRequest(ctx, "POST", u, req, &resp); err != nil {
+	if err := c.doRequestWithSignature(ctx, "POST", u, req, &resp, ""); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -225,125 +233,143 @@ func (c *Client) basicAuth() string {
 
 // doRequest performs an HTTP request with authentication.
 func (c *Client) doRequest(ctx context.Context, method, url string, body interface{}, result interface{}) error {
-	var bodyReader io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return fmt.Errorf("failed to marshal request body: %w", err)
-		}
-		bodyReader = bytes.NewReader(jsonBody)
+	jsonBody, err := marshalBody(body)
+	if err != nil {
+		return err
 	}
+	return c.send(ctx, method, url, jsonBody, result, "")
+}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+// doRequestWithSignature performs an HTTP request with authorization signature.
+// If signature is empty and the client has an authorization key, the signature
+// is computed over the request automatically.
+func (c *Client) doRequestWithSignature(ctx context.Context, method, url string, body interface{}, result interface{}, signature string) error {
+	jsonBody, err := marshalBody(body)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return err
 	}
+	if signature == "" {
+		signature, err = c.authorizationSignature(ctx, method, url, jsonBody)
+		if err != nil {
+			return err
+		}
+	}
+	return c.send(ctx, method, url, jsonBody, result, signature)
+}
 
-	req.Header.Set("Authorization", c.basicAuth())
-	req.Header.Set("privy-app-id", c.appID)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
+// marshalBody encodes a request body as JSON. A nil body yields nil.
+func marshalBody(body interface{}) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+	jsonBody, err := json.Marshal(body)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
-	defer resp.Body.Close()
+	return jsonBody, nil
+}
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+// send executes an HTTP request and decodes the response into result,
+// retrying according to the client's retry policy. Every attempt waits
+// for the client's rate limiter first and passes through the middleware.
+// The whole call, retries included, is traced as one span. If ctx ends
+// while waiting to retry, the error wraps both ctx.Err() and the last error.
+func (c *Client) send(ctx context.Context, method, url string, jsonBody []byte, result interface{}, signature string) (err error) {
+	header := http.Header{}
+	header.Set("Authorization", c.basicAuth())
+	header.Set("privy-app-id", c.appID)
+	header.Set("Content-Type", "application/json")
+	if signature != "" {
+		header.Set("privy-authorization-signature", signature)
+	}
+	if key := idempotencyKeyFromContext(ctx); key != "" {
+		header.Set(idempotencyKeyHeader, key)
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var apiErr APIError
-		if err := json.Unmarshal(respBody, &apiErr); err != nil {
-			return &APIError{
-				StatusCode: resp.StatusCode,
-				Message:    string(respBody),
-			}
+	operation := c.operationName(method, url, jsonBody)
+	roundTrip := c.roundTripper()
+
+	ctx, span := c.StartSpan(ctx, operation, requestAttributes(operation, method, url, jsonBody)...)
+	var resp *APIResponse
+	retry := 0
+	defer func() {
+		if resp != nil {
+			span.SetAttributes(Attr(AttrStatusCode, resp.StatusCode))
 		}
-		apiErr.StatusCode = resp.StatusCode
-		return &apiErr
-	}
+		span.SetAttributes(Attr(AttrRetryCount, retry))
+		span.End(err)
+	}()
 
-	if result != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("failed to unmarshal response: %w", err)
+	for ; ; retry++ {
+		if err := c.rateLimiter.wait(ctx, method, url); err != nil {
+			return err
+		}
+		resp, err = roundTrip(ctx, &APIRequest{
+			Operation: operation,
+			Method:    method,
+			URL:       url,
+			Header:    header.Clone(),
+			Body:      jsonBody,
+			Attempt:   retry,
+		})
+		if err == nil {
+			return decodeResult(resp, result)
+		}
+		if !c.retryPolicy.retryable(ctx, method, retry, err) {
+			return err
 		}
-	}
 
-	return nil
+		delay := c.retryPolicy.backoff(retry)
+		if retryAfter, ok := retryAfterFromError(err); ok {
+			delay = retryAfter
+		}
+		if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+			return fmt.Errorf("%w (last error: %w)", sleepErr, err)
+		}
+	}
 }
 
-// doRequestWithSignature performs an HTTP request with authorization signature.
-func (c *Client) doRequestWithSignature(ctx context.Context, method, url string, body interface{}, result interface{}, signature string) error {
+// transport performs a single HTTP round trip. It is the innermost RoundTripFunc.
+func (c *Client) transport(ctx context.Context, apiReq *APIRequest) (*APIResponse, error) {
 	var bodyReader io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return fmt.Errorf("failed to marshal request body: %w", err)
-		}
-		bodyReader = bytes.NewReader(jsonBody)
+	if apiReq.Body != nil {
+		bodyReader = bytes.NewReader(apiReq.Body)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, apiReq.Method, apiReq.URL, bodyReader)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", c.basicAuth())
-	req.Header.Set("privy-app-id", c.appID)
-	req.Header.Set("Content-Type", "application/json")
-	if signature != "" {
-		req.Header.Set("privy-authorization-signature", signature)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	req.Header = apiReq.Header
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	apiResp := &APIResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       respBody,
+	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var apiErr APIError
-		if err := json.Unmarshal(respBody, &apiErr); err != nil {
-			return &APIError{
-				StatusCode: resp.StatusCode,
-				Message:    string(respBody),
-			}
-		}
-		apiErr.StatusCode = resp.StatusCode
-		return &apiErr
+		return apiResp, newAPIError(resp, respBody)
 	}
+	return apiResp, nil
+}
 
-	if result != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, result); err != nil {
+// decodeResult unmarshals a successful response body into result.
+func decodeResult(resp *APIResponse, result interface{}) error {
+	if result != nil && resp != nil && len(resp.Body) > 0 {
+		if err := json.Unmarshal(resp.Body, result); err != nil {
 			return fmt.Errorf("failed to unmarshal response: %w", err)
 		}
 	}
-
 	return nil
 }
-
-// APIError represents an error response from the Privy API.
-type APIError struct {
-	StatusCode int    `json:"-"`
-	Message    string `json:"message"`
-	Error_     string `json:"error"`
-	Code       string `json:"code"`
-}
-
-func (e *APIError) Error() string {
-	if e.Message != "" {
-		return fmt.Sprintf("privy: API error (status %d): %s", e.StatusCode, e.Message)
-	}
-	if e.Error_ != "" {
-		return fmt.Sprintf("privy: API error (status %d): %s", e.StatusCode, e.Error_)
-	}
-	return fmt.Sprintf("privy: API error (status %d)", e.StatusCode)
-}
@@ -1,8 +1,10 @@
-package privy
+package privytest_test
 
 import (
 	"context"
 	"testing"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
 )
 
 // ============================================
@@ -10,13 +12,13 @@ import (
 // ============================================
 
 func TestE2E_Bitcoin_RawSign(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeBitcoinSegwit,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeBitcoinSegwit,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Bitcoin wallet: %v", err)
@@ -39,13 +41,13 @@ func TestE2E_Bitcoin_RawSign(t *testing.T) {
 }
 
 func TestE2E_Bitcoin_RawSignBytes(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeBitcoinSegwit,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeBitcoinSegwit,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Bitcoin wallet: %v", err)
@@ -62,7 +64,7 @@ func TestE2E_Bitcoin_RawSignBytes(t *testing.T) {
 }
 
 func TestE2E_Bitcoin_RawSignWithNonExistentWallet(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
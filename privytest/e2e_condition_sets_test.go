@@ -1,8 +1,10 @@
-package privy
+package privytest_test
 
 import (
 	"context"
 	"testing"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
 )
 
 // ============================================
@@ -10,12 +12,12 @@ import (
 // ============================================
 
 func TestE2E_ConditionSets_Create(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	cs, err := client.ConditionSets().Create(ctx, &CreateConditionSetRequest{
+	cs, err := client.ConditionSets().Create(ctx, &privy.CreateConditionSetRequest{
 		Name: "Allowlist",
 	})
 	if err != nil {
@@ -36,12 +38,12 @@ func TestE2E_ConditionSets_Create(t *testing.T) {
 }
 
 func TestE2E_ConditionSets_CreateWithOwner(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	cs, err := client.ConditionSets().Create(ctx, &CreateConditionSetRequest{
+	cs, err := client.ConditionSets().Create(ctx, &privy.CreateConditionSetRequest{
 		Name:    "Owner Condition Set",
 		OwnerID: "owner-123",
 	})
@@ -55,12 +57,12 @@ func TestE2E_ConditionSets_CreateWithOwner(t *testing.T) {
 }
 
 func TestE2E_ConditionSets_Get(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	created, err := client.ConditionSets().Create(ctx, &CreateConditionSetRequest{
+	created, err := client.ConditionSets().Create(ctx, &privy.CreateConditionSetRequest{
 		Name: "Get Test",
 	})
 	if err != nil {
@@ -82,7 +84,7 @@ func TestE2E_ConditionSets_Get(t *testing.T) {
 }
 
 func TestE2E_ConditionSets_GetNonExistent(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
@@ -94,19 +96,19 @@ func TestE2E_ConditionSets_GetNonExistent(t *testing.T) {
 }
 
 func TestE2E_ConditionSets_Update(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	created, err := client.ConditionSets().Create(ctx, &CreateConditionSetRequest{
+	created, err := client.ConditionSets().Create(ctx, &privy.CreateConditionSetRequest{
 		Name: "Original Name",
 	})
 	if err != nil {
 		t.Fatalf("Failed to create condition set: %v", err)
 	}
 
-	updated, err := client.ConditionSets().Update(ctx, created.ID, &UpdateConditionSetRequest{
+	updated, err := client.ConditionSets().Update(ctx, created.ID, &privy.UpdateConditionSetRequest{
 		Name: "Updated Name",
 	})
 	if err != nil {
@@ -123,12 +125,12 @@ func TestE2E_ConditionSets_Update(t *testing.T) {
 }
 
 func TestE2E_ConditionSets_Delete(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	created, err := client.ConditionSets().Create(ctx, &CreateConditionSetRequest{
+	created, err := client.ConditionSets().Create(ctx, &privy.CreateConditionSetRequest{
 		Name: "Delete Test",
 	})
 	if err != nil {
@@ -148,19 +150,19 @@ func TestE2E_ConditionSets_Delete(t *testing.T) {
 }
 
 func TestE2E_ConditionSets_AddItems(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	cs, err := client.ConditionSets().Create(ctx, &CreateConditionSetRequest{
+	cs, err := client.ConditionSets().Create(ctx, &privy.CreateConditionSetRequest{
 		Name: "Items Test",
 	})
 	if err != nil {
 		t.Fatalf("Failed to create condition set: %v", err)
 	}
 
-	items, err := client.ConditionSets().AddItems(ctx, cs.ID, []ConditionSetItemInput{
+	items, err := client.ConditionSets().AddItems(ctx, cs.ID, []privy.ConditionSetItemInput{
 		{Value: "0xAddress1"},
 		{Value: "0xAddress2"},
 		{Value: "0xAddress3"},
@@ -181,12 +183,12 @@ func TestE2E_ConditionSets_AddItems(t *testing.T) {
 }
 
 func TestE2E_ConditionSets_AddManyItems(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	cs, err := client.ConditionSets().Create(ctx, &CreateConditionSetRequest{
+	cs, err := client.ConditionSets().Create(ctx, &privy.CreateConditionSetRequest{
 		Name: "Many Items Test",
 	})
 	if err != nil {
@@ -194,9 +196,9 @@ func TestE2E_ConditionSets_AddManyItems(t *testing.T) {
 	}
 
 	// Add 50 items
-	inputItems := make([]ConditionSetItemInput, 50)
+	inputItems := make([]privy.ConditionSetItemInput, 50)
 	for i := 0; i < 50; i++ {
-		inputItems[i] = ConditionSetItemInput{Value: i}
+		inputItems[i] = privy.ConditionSetItemInput{Value: i}
 	}
 
 	items, err := client.ConditionSets().AddItems(ctx, cs.ID, inputItems)
@@ -210,12 +212,12 @@ func TestE2E_ConditionSets_AddManyItems(t *testing.T) {
 }
 
 func TestE2E_ConditionSets_ListItems(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	cs, err := client.ConditionSets().Create(ctx, &CreateConditionSetRequest{
+	cs, err := client.ConditionSets().Create(ctx, &privy.CreateConditionSetRequest{
 		Name: "List Items Test",
 	})
 	if err != nil {
@@ -223,7 +225,7 @@ func TestE2E_ConditionSets_ListItems(t *testing.T) {
 	}
 
 	// Add items
-	_, err = client.ConditionSets().AddItems(ctx, cs.ID, []ConditionSetItemInput{
+	_, err = client.ConditionSets().AddItems(ctx, cs.ID, []privy.ConditionSetItemInput{
 		{Value: "item1"},
 		{Value: "item2"},
 	})
@@ -243,12 +245,12 @@ func TestE2E_ConditionSets_ListItems(t *testing.T) {
 }
 
 func TestE2E_ConditionSets_ListItemsWithPagination(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	cs, err := client.ConditionSets().Create(ctx, &CreateConditionSetRequest{
+	cs, err := client.ConditionSets().Create(ctx, &privy.CreateConditionSetRequest{
 		Name: "Pagination Test",
 	})
 	if err != nil {
@@ -256,9 +258,9 @@ func TestE2E_ConditionSets_ListItemsWithPagination(t *testing.T) {
 	}
 
 	// Add items
-	inputItems := make([]ConditionSetItemInput, 20)
+	inputItems := make([]privy.ConditionSetItemInput, 20)
 	for i := 0; i < 20; i++ {
-		inputItems[i] = ConditionSetItemInput{Value: i}
+		inputItems[i] = privy.ConditionSetItemInput{Value: i}
 	}
 	_, err = client.ConditionSets().AddItems(ctx, cs.ID, inputItems)
 	if err != nil {
@@ -266,7 +268,7 @@ func TestE2E_ConditionSets_ListItemsWithPagination(t *testing.T) {
 	}
 
 	// List with limit
-	resp, err := client.ConditionSets().ListItems(ctx, cs.ID, &ListOptions{Limit: 10})
+	resp, err := client.ConditionSets().ListItems(ctx, cs.ID, &privy.ListOptions{Limit: 10})
 	if err != nil {
 		t.Fatalf("Failed to list items: %v", err)
 	}
@@ -278,19 +280,19 @@ func TestE2E_ConditionSets_ListItemsWithPagination(t *testing.T) {
 }
 
 func TestE2E_ConditionSets_GetItem(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	cs, err := client.ConditionSets().Create(ctx, &CreateConditionSetRequest{
+	cs, err := client.ConditionSets().Create(ctx, &privy.CreateConditionSetRequest{
 		Name: "Get Item Test",
 	})
 	if err != nil {
 		t.Fatalf("Failed to create condition set: %v", err)
 	}
 
-	items, err := client.ConditionSets().AddItems(ctx, cs.ID, []ConditionSetItemInput{
+	items, err := client.ConditionSets().AddItems(ctx, cs.ID, []privy.ConditionSetItemInput{
 		{Value: "test-value"},
 	})
 	if err != nil {
@@ -309,12 +311,12 @@ func TestE2E_ConditionSets_GetItem(t *testing.T) {
 }
 
 func TestE2E_ConditionSets_GetItemNonExistent(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	cs, err := client.ConditionSets().Create(ctx, &CreateConditionSetRequest{
+	cs, err := client.ConditionSets().Create(ctx, &privy.CreateConditionSetRequest{
 		Name: "Get Non-Existent Item Test",
 	})
 	if err != nil {
@@ -328,12 +330,12 @@ func TestE2E_ConditionSets_GetItemNonExistent(t *testing.T) {
 }
 
 func TestE2E_ConditionSets_ReplaceItems(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	cs, err := client.ConditionSets().Create(ctx, &CreateConditionSetRequest{
+	cs, err := client.ConditionSets().Create(ctx, &privy.CreateConditionSetRequest{
 		Name: "Replace Items Test",
 	})
 	if err != nil {
@@ -341,7 +343,7 @@ func TestE2E_ConditionSets_ReplaceItems(t *testing.T) {
 	}
 
 	// Add initial items
-	_, err = client.ConditionSets().AddItems(ctx, cs.ID, []ConditionSetItemInput{
+	_, err = client.ConditionSets().AddItems(ctx, cs.ID, []privy.ConditionSetItemInput{
 		{Value: "old1"},
 		{Value: "old2"},
 	})
@@ -350,7 +352,7 @@ func TestE2E_ConditionSets_ReplaceItems(t *testing.T) {
 	}
 
 	// Replace all items
-	newItems, err := client.ConditionSets().ReplaceItems(ctx, cs.ID, []ConditionSetItemInput{
+	newItems, err := client.ConditionSets().ReplaceItems(ctx, cs.ID, []privy.ConditionSetItemInput{
 		{Value: "new1"},
 		{Value: "new2"},
 		{Value: "new3"},
@@ -375,19 +377,19 @@ func TestE2E_ConditionSets_ReplaceItems(t *testing.T) {
 }
 
 func TestE2E_ConditionSets_DeleteItem(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	cs, err := client.ConditionSets().Create(ctx, &CreateConditionSetRequest{
+	cs, err := client.ConditionSets().Create(ctx, &privy.CreateConditionSetRequest{
 		Name: "Delete Item Test",
 	})
 	if err != nil {
 		t.Fatalf("Failed to create condition set: %v", err)
 	}
 
-	items, err := client.ConditionSets().AddItems(ctx, cs.ID, []ConditionSetItemInput{
+	items, err := client.ConditionSets().AddItems(ctx, cs.ID, []privy.ConditionSetItemInput{
 		{Value: "delete-me"},
 	})
 	if err != nil {
@@ -407,12 +409,12 @@ func TestE2E_ConditionSets_DeleteItem(t *testing.T) {
 }
 
 func TestE2E_ConditionSets_DeleteItemNonExistent(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	cs, err := client.ConditionSets().Create(ctx, &CreateConditionSetRequest{
+	cs, err := client.ConditionSets().Create(ctx, &privy.CreateConditionSetRequest{
 		Name: "Delete Non-Existent Item Test",
 	})
 	if err != nil {
@@ -426,13 +428,13 @@ func TestE2E_ConditionSets_DeleteItemNonExistent(t *testing.T) {
 }
 
 func TestE2E_ConditionSets_ComplexWorkflow(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create condition set
-	cs, err := client.ConditionSets().Create(ctx, &CreateConditionSetRequest{
+	cs, err := client.ConditionSets().Create(ctx, &privy.CreateConditionSetRequest{
 		Name: "Workflow Test",
 	})
 	if err != nil {
@@ -440,7 +442,7 @@ func TestE2E_ConditionSets_ComplexWorkflow(t *testing.T) {
 	}
 
 	// Add items
-	items, err := client.ConditionSets().AddItems(ctx, cs.ID, []ConditionSetItemInput{
+	items, err := client.ConditionSets().AddItems(ctx, cs.ID, []privy.ConditionSetItemInput{
 		{Value: "address1"},
 		{Value: "address2"},
 		{Value: "address3"},
@@ -466,7 +468,7 @@ func TestE2E_ConditionSets_ComplexWorkflow(t *testing.T) {
 	}
 
 	// Update condition set
-	updated, err := client.ConditionSets().Update(ctx, cs.ID, &UpdateConditionSetRequest{
+	updated, err := client.ConditionSets().Update(ctx, cs.ID, &privy.UpdateConditionSetRequest{
 		Name: "Updated Workflow Test",
 	})
 	if err != nil {
@@ -1,8 +1,10 @@
-package privy
+package privytest_test
 
 import (
 	"context"
 	"testing"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
 )
 
 // ============================================
@@ -10,14 +12,14 @@ import (
 // ============================================
 
 func TestE2E_Ethereum_SignMessage(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create a wallet
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
@@ -43,13 +45,13 @@ func TestE2E_Ethereum_SignMessage(t *testing.T) {
 }
 
 func TestE2E_Ethereum_SignMessageHex(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
@@ -67,19 +69,19 @@ func TestE2E_Ethereum_SignMessageHex(t *testing.T) {
 }
 
 func TestE2E_Ethereum_SignTransaction(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
 	}
 
-	tx := &EthereumTransaction{
+	tx := &privy.EthereumTransaction{
 		To:      "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045",
 		Value:   "0x2386F26FC10000", // 0.01 ETH
 		ChainID: 1,
@@ -104,19 +106,19 @@ func TestE2E_Ethereum_SignTransaction(t *testing.T) {
 }
 
 func TestE2E_Ethereum_SignTransactionWithAllFields(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
 	}
 
-	tx := &EthereumTransaction{
+	tx := &privy.EthereumTransaction{
 		To:                   "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045",
 		From:                 wallet.Address,
 		Value:                "0x2386F26FC10000",
@@ -140,19 +142,19 @@ func TestE2E_Ethereum_SignTransactionWithAllFields(t *testing.T) {
 }
 
 func TestE2E_Ethereum_SendTransaction(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
 	}
 
-	tx := &EthereumTransaction{
+	tx := &privy.EthereumTransaction{
 		To:      "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045",
 		Value:   "0x2386F26FC10000",
 		ChainID: 11155111, // Sepolia
@@ -177,19 +179,19 @@ func TestE2E_Ethereum_SendTransaction(t *testing.T) {
 }
 
 func TestE2E_Ethereum_SendTransactionWithSponsor(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
 	}
 
-	tx := &EthereumTransaction{
+	tx := &privy.EthereumTransaction{
 		To:      "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045",
 		Value:   "0x0",
 		Data:    "0xa9059cbb", // ERC20 transfer selector
@@ -208,26 +210,26 @@ func TestE2E_Ethereum_SendTransactionWithSponsor(t *testing.T) {
 }
 
 func TestE2E_Ethereum_SignTypedData(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
 	}
 
-	typedData := &TypedData{
-		Domain: TypedDataDomain{
+	typedData := &privy.TypedData{
+		Domain: privy.TypedDataDomain{
 			Name:              "Example DApp",
 			Version:           "1",
 			ChainID:           1,
 			VerifyingContract: "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
 		},
-		Types: map[string][]TypedDataField{
+		Types: map[string][]privy.TypedDataField{
 			"EIP712Domain": {
 				{Name: "name", Type: "string"},
 				{Name: "version", Type: "string"},
@@ -263,13 +265,13 @@ func TestE2E_Ethereum_SignTypedData(t *testing.T) {
 }
 
 func TestE2E_Ethereum_SignHash(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
@@ -292,13 +294,13 @@ func TestE2E_Ethereum_SignHash(t *testing.T) {
 }
 
 func TestE2E_Ethereum_RawSign(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
@@ -321,13 +323,13 @@ func TestE2E_Ethereum_RawSign(t *testing.T) {
 }
 
 func TestE2E_Ethereum_SignUserOperation(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
@@ -364,13 +366,13 @@ func TestE2E_Ethereum_SignUserOperation(t *testing.T) {
 }
 
 func TestE2E_Ethereum_Sign7702Authorization(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
@@ -393,7 +395,7 @@ func TestE2E_Ethereum_Sign7702Authorization(t *testing.T) {
 }
 
 func TestE2E_Ethereum_SignWithNonExistentWallet(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
@@ -405,13 +407,13 @@ func TestE2E_Ethereum_SignWithNonExistentWallet(t *testing.T) {
 }
 
 func TestE2E_Ethereum_MultipleSignOperations(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
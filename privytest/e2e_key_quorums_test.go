@@ -1,8 +1,10 @@
-package privy
+package privytest_test
 
 import (
 	"context"
 	"testing"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
 )
 
 // ============================================
@@ -10,12 +12,12 @@ import (
 // ============================================
 
 func TestE2E_KeyQuorums_Create(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	kq, err := client.KeyQuorums().Create(ctx, &CreateKeyQuorumRequest{
+	kq, err := client.KeyQuorums().Create(ctx, &privy.CreateKeyQuorumRequest{
 		PublicKey: "0x04abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890ab",
 	})
 	if err != nil {
@@ -36,12 +38,12 @@ func TestE2E_KeyQuorums_Create(t *testing.T) {
 }
 
 func TestE2E_KeyQuorums_Get(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	created, err := client.KeyQuorums().Create(ctx, &CreateKeyQuorumRequest{
+	created, err := client.KeyQuorums().Create(ctx, &privy.CreateKeyQuorumRequest{
 		PublicKey: "0x04test-public-key",
 	})
 	if err != nil {
@@ -63,7 +65,7 @@ func TestE2E_KeyQuorums_Get(t *testing.T) {
 }
 
 func TestE2E_KeyQuorums_GetNonExistent(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
@@ -75,19 +77,19 @@ func TestE2E_KeyQuorums_GetNonExistent(t *testing.T) {
 }
 
 func TestE2E_KeyQuorums_Update(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	created, err := client.KeyQuorums().Create(ctx, &CreateKeyQuorumRequest{
+	created, err := client.KeyQuorums().Create(ctx, &privy.CreateKeyQuorumRequest{
 		PublicKey: "0x04original-key",
 	})
 	if err != nil {
 		t.Fatalf("Failed to create key quorum: %v", err)
 	}
 
-	updated, err := client.KeyQuorums().Update(ctx, created.ID, &UpdateKeyQuorumRequest{
+	updated, err := client.KeyQuorums().Update(ctx, created.ID, &privy.UpdateKeyQuorumRequest{
 		PublicKey: "0x04updated-key",
 	})
 	if err != nil {
@@ -100,12 +102,12 @@ func TestE2E_KeyQuorums_Update(t *testing.T) {
 }
 
 func TestE2E_KeyQuorums_UpdateNonExistent(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	_, err := client.KeyQuorums().Update(ctx, "nonexistent-kq", &UpdateKeyQuorumRequest{
+	_, err := client.KeyQuorums().Update(ctx, "nonexistent-kq", &privy.UpdateKeyQuorumRequest{
 		PublicKey: "0x04new-key",
 	})
 	if err == nil {
@@ -114,12 +116,12 @@ func TestE2E_KeyQuorums_UpdateNonExistent(t *testing.T) {
 }
 
 func TestE2E_KeyQuorums_Delete(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	created, err := client.KeyQuorums().Create(ctx, &CreateKeyQuorumRequest{
+	created, err := client.KeyQuorums().Create(ctx, &privy.CreateKeyQuorumRequest{
 		PublicKey: "0x04delete-me",
 	})
 	if err != nil {
@@ -139,7 +141,7 @@ func TestE2E_KeyQuorums_Delete(t *testing.T) {
 }
 
 func TestE2E_KeyQuorums_DeleteNonExistent(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
@@ -151,15 +153,15 @@ func TestE2E_KeyQuorums_DeleteNonExistent(t *testing.T) {
 }
 
 func TestE2E_KeyQuorums_MultipleQuorums(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create multiple key quorums
-	var quorums []*KeyQuorum
+	var quorums []*privy.KeyQuorum
 	for i := 0; i < 5; i++ {
-		kq, err := client.KeyQuorums().Create(ctx, &CreateKeyQuorumRequest{
+		kq, err := client.KeyQuorums().Create(ctx, &privy.CreateKeyQuorumRequest{
 			PublicKey: "0x04key-" + string(rune('a'+i)),
 		})
 		if err != nil {
@@ -181,13 +183,13 @@ func TestE2E_KeyQuorums_MultipleQuorums(t *testing.T) {
 }
 
 func TestE2E_KeyQuorums_Lifecycle(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create
-	kq, err := client.KeyQuorums().Create(ctx, &CreateKeyQuorumRequest{
+	kq, err := client.KeyQuorums().Create(ctx, &privy.CreateKeyQuorumRequest{
 		PublicKey: "0x04lifecycle-key",
 	})
 	if err != nil {
@@ -201,7 +203,7 @@ func TestE2E_KeyQuorums_Lifecycle(t *testing.T) {
 	}
 
 	// Update
-	updated, err := client.KeyQuorums().Update(ctx, kq.ID, &UpdateKeyQuorumRequest{
+	updated, err := client.KeyQuorums().Update(ctx, kq.ID, &privy.UpdateKeyQuorumRequest{
 		PublicKey: "0x04updated-lifecycle-key",
 	})
 	if err != nil {
@@ -1,8 +1,10 @@
-package privy
+package privytest_test
 
 import (
 	"context"
 	"testing"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
 )
 
 // ============================================
@@ -10,12 +12,12 @@ import (
 // ============================================
 
 func TestE2E_Policies_Create(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	policy, err := client.Policies().Create(ctx, &CreatePolicyRequest{
+	policy, err := client.Policies().Create(ctx, &privy.CreatePolicyRequest{
 		Name: "Transfer Limit Policy",
 	})
 	if err != nil {
@@ -36,18 +38,18 @@ func TestE2E_Policies_Create(t *testing.T) {
 }
 
 func TestE2E_Policies_CreateWithRules(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	policy, err := client.Policies().Create(ctx, &CreatePolicyRequest{
+	policy, err := client.Policies().Create(ctx, &privy.CreatePolicyRequest{
 		Name: "Complex Policy",
-		Rules: []PolicyRule{
+		Rules: []privy.PolicyRule{
 			{
 				ID:     "rule-1",
 				Action: "allow",
-				Conditions: []RuleCondition{
+				Conditions: []privy.RuleCondition{
 					{Type: "max_value", Value: "1000000000000000000"},
 				},
 			},
@@ -63,12 +65,12 @@ func TestE2E_Policies_CreateWithRules(t *testing.T) {
 }
 
 func TestE2E_Policies_Get(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	created, err := client.Policies().Create(ctx, &CreatePolicyRequest{
+	created, err := client.Policies().Create(ctx, &privy.CreatePolicyRequest{
 		Name: "Get Test Policy",
 	})
 	if err != nil {
@@ -90,7 +92,7 @@ func TestE2E_Policies_Get(t *testing.T) {
 }
 
 func TestE2E_Policies_GetNonExistent(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
@@ -102,19 +104,19 @@ func TestE2E_Policies_GetNonExistent(t *testing.T) {
 }
 
 func TestE2E_Policies_Update(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	created, err := client.Policies().Create(ctx, &CreatePolicyRequest{
+	created, err := client.Policies().Create(ctx, &privy.CreatePolicyRequest{
 		Name: "Original Name",
 	})
 	if err != nil {
 		t.Fatalf("Failed to create policy: %v", err)
 	}
 
-	updated, err := client.Policies().Update(ctx, created.ID, &UpdatePolicyRequest{
+	updated, err := client.Policies().Update(ctx, created.ID, &privy.UpdatePolicyRequest{
 		Name: "Updated Name",
 	})
 	if err != nil {
@@ -131,12 +133,12 @@ func TestE2E_Policies_Update(t *testing.T) {
 }
 
 func TestE2E_Policies_Delete(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	created, err := client.Policies().Create(ctx, &CreatePolicyRequest{
+	created, err := client.Policies().Create(ctx, &privy.CreatePolicyRequest{
 		Name: "Delete Test Policy",
 	})
 	if err != nil {
@@ -156,7 +158,7 @@ func TestE2E_Policies_Delete(t *testing.T) {
 }
 
 func TestE2E_Policies_DeleteNonExistent(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
@@ -168,21 +170,21 @@ func TestE2E_Policies_DeleteNonExistent(t *testing.T) {
 }
 
 func TestE2E_Policies_AddRule(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	policy, err := client.Policies().Create(ctx, &CreatePolicyRequest{
+	policy, err := client.Policies().Create(ctx, &privy.CreatePolicyRequest{
 		Name: "Rule Test Policy",
 	})
 	if err != nil {
 		t.Fatalf("Failed to create policy: %v", err)
 	}
 
-	rule, err := client.Policies().AddRule(ctx, policy.ID, &CreateRuleRequest{
+	rule, err := client.Policies().AddRule(ctx, policy.ID, &privy.CreateRuleRequest{
 		Action: "allow",
-		Conditions: []RuleCondition{
+		Conditions: []privy.RuleCondition{
 			{Type: "max_value", Value: "5000000000000000000"},
 		},
 	})
@@ -200,19 +202,19 @@ func TestE2E_Policies_AddRule(t *testing.T) {
 }
 
 func TestE2E_Policies_GetRule(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	policy, err := client.Policies().Create(ctx, &CreatePolicyRequest{
+	policy, err := client.Policies().Create(ctx, &privy.CreatePolicyRequest{
 		Name: "Get Rule Test Policy",
 	})
 	if err != nil {
 		t.Fatalf("Failed to create policy: %v", err)
 	}
 
-	addedRule, err := client.Policies().AddRule(ctx, policy.ID, &CreateRuleRequest{
+	addedRule, err := client.Policies().AddRule(ctx, policy.ID, &privy.CreateRuleRequest{
 		Action: "deny",
 	})
 	if err != nil {
@@ -234,26 +236,26 @@ func TestE2E_Policies_GetRule(t *testing.T) {
 }
 
 func TestE2E_Policies_UpdateRule(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	policy, err := client.Policies().Create(ctx, &CreatePolicyRequest{
+	policy, err := client.Policies().Create(ctx, &privy.CreatePolicyRequest{
 		Name: "Update Rule Test Policy",
 	})
 	if err != nil {
 		t.Fatalf("Failed to create policy: %v", err)
 	}
 
-	rule, err := client.Policies().AddRule(ctx, policy.ID, &CreateRuleRequest{
+	rule, err := client.Policies().AddRule(ctx, policy.ID, &privy.CreateRuleRequest{
 		Action: "allow",
 	})
 	if err != nil {
 		t.Fatalf("Failed to add rule: %v", err)
 	}
 
-	updated, err := client.Policies().UpdateRule(ctx, policy.ID, rule.ID, &UpdateRuleRequest{
+	updated, err := client.Policies().UpdateRule(ctx, policy.ID, rule.ID, &privy.UpdateRuleRequest{
 		Action: "deny",
 	})
 	if err != nil {
@@ -266,19 +268,19 @@ func TestE2E_Policies_UpdateRule(t *testing.T) {
 }
 
 func TestE2E_Policies_DeleteRule(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	policy, err := client.Policies().Create(ctx, &CreatePolicyRequest{
+	policy, err := client.Policies().Create(ctx, &privy.CreatePolicyRequest{
 		Name: "Delete Rule Test Policy",
 	})
 	if err != nil {
 		t.Fatalf("Failed to create policy: %v", err)
 	}
 
-	rule, err := client.Policies().AddRule(ctx, policy.ID, &CreateRuleRequest{
+	rule, err := client.Policies().AddRule(ctx, policy.ID, &privy.CreateRuleRequest{
 		Action: "allow",
 	})
 	if err != nil {
@@ -298,12 +300,12 @@ func TestE2E_Policies_DeleteRule(t *testing.T) {
 }
 
 func TestE2E_Policies_MultipleRules(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	policy, err := client.Policies().Create(ctx, &CreatePolicyRequest{
+	policy, err := client.Policies().Create(ctx, &privy.CreatePolicyRequest{
 		Name: "Multiple Rules Policy",
 	})
 	if err != nil {
@@ -313,7 +315,7 @@ func TestE2E_Policies_MultipleRules(t *testing.T) {
 	// Add multiple rules
 	actions := []string{"allow", "deny", "require_approval"}
 	for _, action := range actions {
-		_, err := client.Policies().AddRule(ctx, policy.ID, &CreateRuleRequest{
+		_, err := client.Policies().AddRule(ctx, policy.ID, &privy.CreateRuleRequest{
 			Action: action,
 		})
 		if err != nil {
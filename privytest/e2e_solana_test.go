@@ -1,23 +1,48 @@
-package privy
+package privytest_test
 
 import (
 	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
 	"testing"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
 )
 
 // ============================================
 // Solana Signing E2E Tests
 // ============================================
 
+// solanaTestTransaction returns a base64 legacy transaction with one
+// unfilled signature slot for wallet, invoking the system program.
+func solanaTestTransaction(t *testing.T, wallet *privy.Wallet) string {
+	t.Helper()
+	pub, err := hex.DecodeString(strings.TrimPrefix(wallet.PublicKey, "0x"))
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		t.Fatalf("Invalid wallet public key %q", wallet.PublicKey)
+	}
+
+	message := []byte{1, 0, 1, 2} // header, then 2 account keys
+	message = append(message, pub...)
+	message = append(message, make([]byte, 32)...) // system program
+	message = append(message, make([]byte, 32)...) // recent blockhash
+	message = append(message, 1, 1, 1, 0, 0)       // 1 instruction: program 1, accounts [0], no data
+
+	tx := append([]byte{1}, make([]byte, ed25519.SignatureSize)...)
+	return base64.StdEncoding.EncodeToString(append(tx, message...))
+}
+
 func TestE2E_Solana_SignMessage(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create a Solana wallet
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSolana,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSolana,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Solana wallet: %v", err)
@@ -39,13 +64,13 @@ func TestE2E_Solana_SignMessage(t *testing.T) {
 }
 
 func TestE2E_Solana_SignMessageBase64(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSolana,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSolana,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Solana wallet: %v", err)
@@ -63,20 +88,19 @@ func TestE2E_Solana_SignMessageBase64(t *testing.T) {
 }
 
 func TestE2E_Solana_SignTransaction(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSolana,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSolana,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Solana wallet: %v", err)
 	}
 
-	// Base64 encoded transaction (mock)
-	transaction := "AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABAAEDAENCQUdHRVJFRFNPTEFOQVRSQU5TQUNUSQlPTg=="
+	transaction := solanaTestTransaction(t, wallet)
 
 	resp, err := client.Wallets().Solana().SignTransaction(ctx, wallet.ID, transaction, "")
 	if err != nil {
@@ -90,22 +114,32 @@ func TestE2E_Solana_SignTransaction(t *testing.T) {
 	if resp.Data.SignedTransaction == "" {
 		t.Error("Expected signed transaction to be returned")
 	}
+
+	signed, err := base64.StdEncoding.DecodeString(resp.Data.SignedTransaction)
+	if err != nil {
+		t.Fatalf("Failed to decode signed transaction: %v", err)
+	}
+	pub, _ := hex.DecodeString(strings.TrimPrefix(wallet.PublicKey, "0x"))
+	sig, message := signed[1:1+ed25519.SignatureSize], signed[1+ed25519.SignatureSize:]
+	if !ed25519.Verify(pub, message, sig) {
+		t.Error("Expected signature to verify against the wallet public key")
+	}
 }
 
 func TestE2E_Solana_SignAndSendTransaction(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSolana,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSolana,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Solana wallet: %v", err)
 	}
 
-	transaction := "AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABAAEDAENCQUdHRVJFRFNPTEFOQVRSQU5TQUNUSQlPTg=="
+	transaction := solanaTestTransaction(t, wallet)
 
 	resp, err := client.Wallets().Solana().SignAndSendTransaction(ctx, wallet.ID, transaction, "")
 	if err != nil {
@@ -122,19 +156,19 @@ func TestE2E_Solana_SignAndSendTransaction(t *testing.T) {
 }
 
 func TestE2E_Solana_SignAndSendTransactionOnDevnet(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSolana,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSolana,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Solana wallet: %v", err)
 	}
 
-	transaction := "AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABAAEDAENCQUdHRVJFRFNPTEFOQVRSQU5TQUNUSQlPTg=="
+	transaction := solanaTestTransaction(t, wallet)
 
 	resp, err := client.Wallets().Solana().SignAndSendTransactionOnDevnet(ctx, wallet.ID, transaction, "")
 	if err != nil {
@@ -147,19 +181,19 @@ func TestE2E_Solana_SignAndSendTransactionOnDevnet(t *testing.T) {
 }
 
 func TestE2E_Solana_SignAndSendTransactionWithCustomCAIP2(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSolana,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSolana,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Solana wallet: %v", err)
 	}
 
-	transaction := "AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABAAEDAENCQUdHRVJFRFNPTEFOQVRSQU5TQUNUSQlPTg=="
+	transaction := solanaTestTransaction(t, wallet)
 	customCAIP2 := "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp" // Mainnet
 
 	resp, err := client.Wallets().Solana().SignAndSendTransactionWithCAIP2(ctx, wallet.ID, transaction, customCAIP2, "")
@@ -173,7 +207,7 @@ func TestE2E_Solana_SignAndSendTransactionWithCustomCAIP2(t *testing.T) {
 }
 
 func TestE2E_Solana_SignWithNonExistentWallet(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
@@ -185,13 +219,13 @@ func TestE2E_Solana_SignWithNonExistentWallet(t *testing.T) {
 }
 
 func TestE2E_Solana_MultipleSignOperations(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSolana,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSolana,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
@@ -1,24 +1,26 @@
-package privy
+package privytest_test
 
 import (
 	"context"
 	"testing"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
 )
 
 func TestE2E_Spark_GetBalance(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSpark,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSpark,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Spark wallet: %v", err)
 	}
 
-	resp, err := client.Wallets().Spark().GetBalance(ctx, wallet.ID, SparkNetworkMainnet, "")
+	resp, err := client.Wallets().Spark().GetBalance(ctx, wallet.ID, privy.SparkNetworkMainnet, "")
 	if err != nil {
 		t.Fatalf("Failed to get balance: %v", err)
 	}
@@ -33,19 +35,19 @@ func TestE2E_Spark_GetBalance(t *testing.T) {
 }
 
 func TestE2E_Spark_Transfer(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSpark,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSpark,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Spark wallet: %v", err)
 	}
 
-	resp, err := client.Wallets().Spark().Transfer(ctx, wallet.ID, "sprt1mockaddress", 1000, SparkNetworkMainnet, "")
+	resp, err := client.Wallets().Spark().Transfer(ctx, wallet.ID, "sprt1mockaddress", 1000, privy.SparkNetworkMainnet, "")
 	if err != nil {
 		t.Fatalf("Failed to transfer: %v", err)
 	}
@@ -64,19 +66,19 @@ func TestE2E_Spark_Transfer(t *testing.T) {
 }
 
 func TestE2E_Spark_TransferTokens(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSpark,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSpark,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Spark wallet: %v", err)
 	}
 
-	resp, err := client.Wallets().Spark().TransferTokens(ctx, wallet.ID, "btkn-mock-token", 100, "sprt1mockaddress", SparkNetworkMainnet, "")
+	resp, err := client.Wallets().Spark().TransferTokens(ctx, wallet.ID, "btkn-mock-token", 100, "sprt1mockaddress", privy.SparkNetworkMainnet, "")
 	if err != nil {
 		t.Fatalf("Failed to transfer tokens: %v", err)
 	}
@@ -87,19 +89,19 @@ func TestE2E_Spark_TransferTokens(t *testing.T) {
 }
 
 func TestE2E_Spark_GetStaticDepositAddress(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSpark,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSpark,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Spark wallet: %v", err)
 	}
 
-	resp, err := client.Wallets().Spark().GetStaticDepositAddress(ctx, wallet.ID, SparkNetworkMainnet, "")
+	resp, err := client.Wallets().Spark().GetStaticDepositAddress(ctx, wallet.ID, privy.SparkNetworkMainnet, "")
 	if err != nil {
 		t.Fatalf("Failed to get deposit address: %v", err)
 	}
@@ -114,19 +116,19 @@ func TestE2E_Spark_GetStaticDepositAddress(t *testing.T) {
 }
 
 func TestE2E_Spark_GetClaimStaticDepositQuote(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSpark,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSpark,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Spark wallet: %v", err)
 	}
 
-	resp, err := client.Wallets().Spark().GetClaimStaticDepositQuote(ctx, wallet.ID, SparkNetworkMainnet, "")
+	resp, err := client.Wallets().Spark().GetClaimStaticDepositQuote(ctx, wallet.ID, privy.SparkNetworkMainnet, "")
 	if err != nil {
 		t.Fatalf("Failed to get claim quote: %v", err)
 	}
@@ -137,19 +139,19 @@ func TestE2E_Spark_GetClaimStaticDepositQuote(t *testing.T) {
 }
 
 func TestE2E_Spark_ClaimStaticDeposit(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSpark,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSpark,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Spark wallet: %v", err)
 	}
 
-	resp, err := client.Wallets().Spark().ClaimStaticDeposit(ctx, wallet.ID, "btc-tx-001", 5000, "mock-ssp-sig", SparkNetworkMainnet, "")
+	resp, err := client.Wallets().Spark().ClaimStaticDeposit(ctx, wallet.ID, "btc-tx-001", 5000, "mock-ssp-sig", privy.SparkNetworkMainnet, "")
 	if err != nil {
 		t.Fatalf("Failed to claim deposit: %v", err)
 	}
@@ -160,19 +162,19 @@ func TestE2E_Spark_ClaimStaticDeposit(t *testing.T) {
 }
 
 func TestE2E_Spark_CreateLightningInvoice(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSpark,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSpark,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Spark wallet: %v", err)
 	}
 
-	resp, err := client.Wallets().Spark().CreateLightningInvoice(ctx, wallet.ID, 10000, SparkNetworkMainnet, "")
+	resp, err := client.Wallets().Spark().CreateLightningInvoice(ctx, wallet.ID, 10000, privy.SparkNetworkMainnet, "")
 	if err != nil {
 		t.Fatalf("Failed to create Lightning invoice: %v", err)
 	}
@@ -191,19 +193,19 @@ func TestE2E_Spark_CreateLightningInvoice(t *testing.T) {
 }
 
 func TestE2E_Spark_PayLightningInvoice(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSpark,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSpark,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Spark wallet: %v", err)
 	}
 
-	resp, err := client.Wallets().Spark().PayLightningInvoice(ctx, wallet.ID, "lnbc100u1pmockinvoice", 100, SparkNetworkMainnet, "")
+	resp, err := client.Wallets().Spark().PayLightningInvoice(ctx, wallet.ID, "lnbc100u1pmockinvoice", 100, privy.SparkNetworkMainnet, "")
 	if err != nil {
 		t.Fatalf("Failed to pay Lightning invoice: %v", err)
 	}
@@ -214,19 +216,19 @@ func TestE2E_Spark_PayLightningInvoice(t *testing.T) {
 }
 
 func TestE2E_Spark_SignMessage(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSpark,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSpark,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Spark wallet: %v", err)
 	}
 
-	resp, err := client.Wallets().Spark().SignMessage(ctx, wallet.ID, "Hello, Spark!", false, SparkNetworkMainnet, "")
+	resp, err := client.Wallets().Spark().SignMessage(ctx, wallet.ID, "Hello, Spark!", false, privy.SparkNetworkMainnet, "")
 	if err != nil {
 		t.Fatalf("Failed to sign message: %v", err)
 	}
@@ -241,32 +243,32 @@ func TestE2E_Spark_SignMessage(t *testing.T) {
 }
 
 func TestE2E_Spark_SignWithNonExistentWallet(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	_, err := client.Wallets().Spark().SignMessage(ctx, "nonexistent-wallet", "Hello", false, SparkNetworkMainnet, "")
+	_, err := client.Wallets().Spark().SignMessage(ctx, "nonexistent-wallet", "Hello", false, privy.SparkNetworkMainnet, "")
 	if err == nil {
 		t.Error("Expected error for non-existent wallet")
 	}
 }
 
 func TestE2E_Spark_MultipleOperations(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSpark,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSpark,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Spark wallet: %v", err)
 	}
 
 	// Get balance
-	balanceResp, err := client.Wallets().Spark().GetBalance(ctx, wallet.ID, SparkNetworkMainnet, "")
+	balanceResp, err := client.Wallets().Spark().GetBalance(ctx, wallet.ID, privy.SparkNetworkMainnet, "")
 	if err != nil {
 		t.Fatalf("Failed to get balance: %v", err)
 	}
@@ -275,7 +277,7 @@ func TestE2E_Spark_MultipleOperations(t *testing.T) {
 	}
 
 	// Get deposit address
-	addrResp, err := client.Wallets().Spark().GetStaticDepositAddress(ctx, wallet.ID, SparkNetworkMainnet, "")
+	addrResp, err := client.Wallets().Spark().GetStaticDepositAddress(ctx, wallet.ID, privy.SparkNetworkMainnet, "")
 	if err != nil {
 		t.Fatalf("Failed to get deposit address: %v", err)
 	}
@@ -284,7 +286,7 @@ func TestE2E_Spark_MultipleOperations(t *testing.T) {
 	}
 
 	// Sign message
-	sigResp, err := client.Wallets().Spark().SignMessage(ctx, wallet.ID, "test", false, SparkNetworkMainnet, "")
+	sigResp, err := client.Wallets().Spark().SignMessage(ctx, wallet.ID, "test", false, privy.SparkNetworkMainnet, "")
 	if err != nil {
 		t.Fatalf("Failed to sign message: %v", err)
 	}
@@ -293,7 +295,7 @@ func TestE2E_Spark_MultipleOperations(t *testing.T) {
 	}
 
 	// Create Lightning invoice
-	invoiceResp, err := client.Wallets().Spark().CreateLightningInvoice(ctx, wallet.ID, 5000, SparkNetworkMainnet, "")
+	invoiceResp, err := client.Wallets().Spark().CreateLightningInvoice(ctx, wallet.ID, 5000, privy.SparkNetworkMainnet, "")
 	if err != nil {
 		t.Fatalf("Failed to create invoice: %v", err)
 	}
@@ -1,8 +1,10 @@
-package privy
+package privytest_test
 
 import (
 	"context"
 	"testing"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
 )
 
 // ============================================
@@ -10,13 +12,13 @@ import (
 // ============================================
 
 func TestE2E_Starknet_RawSign(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeStarknet,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeStarknet,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Starknet wallet: %v", err)
@@ -39,13 +41,13 @@ func TestE2E_Starknet_RawSign(t *testing.T) {
 }
 
 func TestE2E_Starknet_RawSignBytes(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeStarknet,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeStarknet,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Starknet wallet: %v", err)
@@ -62,7 +64,7 @@ func TestE2E_Starknet_RawSignBytes(t *testing.T) {
 }
 
 func TestE2E_Starknet_RawSignWithNonExistentWallet(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
@@ -74,19 +76,19 @@ func TestE2E_Starknet_RawSignWithNonExistentWallet(t *testing.T) {
 }
 
 func TestE2E_Starknet_MultipleRawSignOperations(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeStarknet,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeStarknet,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Starknet wallet: %v", err)
 	}
 
-	resp1, err := client.Wallets().Starknet().RawSign(ctx, wallet.ID, "0xhash1")
+	resp1, err := client.Wallets().Starknet().RawSign(ctx, wallet.ID, "0x1111111111111111111111111111111111111111111111111111111111111111")
 	if err != nil {
 		t.Fatalf("Failed first raw sign: %v", err)
 	}
@@ -1,8 +1,10 @@
-package privy
+package privytest_test
 
 import (
 	"context"
 	"testing"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
 )
 
 // ============================================
@@ -10,13 +12,13 @@ import (
 // ============================================
 
 func TestE2E_Stellar_RawSign(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeStellar,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeStellar,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Stellar wallet: %v", err)
@@ -43,13 +45,13 @@ func TestE2E_Stellar_RawSign(t *testing.T) {
 }
 
 func TestE2E_Stellar_RawSignBytes(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeStellar,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeStellar,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Stellar wallet: %v", err)
@@ -70,7 +72,7 @@ func TestE2E_Stellar_RawSignBytes(t *testing.T) {
 }
 
 func TestE2E_Stellar_RawSignWithNonExistentWallet(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
@@ -82,13 +84,13 @@ func TestE2E_Stellar_RawSignWithNonExistentWallet(t *testing.T) {
 }
 
 func TestE2E_Stellar_RawSignViaClient(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeStellar,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeStellar,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Stellar wallet: %v", err)
@@ -1,22 +1,17 @@
-package privy
+package privytest_test
 
 import (
 	"context"
-	"net/http/httptest"
 	"testing"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+	"github.com/vadimzhukck/privy-sdk-go/privytest"
 )
 
-func setupTestServer(t *testing.T) (*Client, *httptest.Server, *MockPrivyServer) {
+func setupTestServer(t *testing.T) (*privy.Client, *privytest.Server) {
 	t.Helper()
-	mock := NewMockPrivyServer()
-	server := httptest.NewServer(mock)
-	client := NewClient(
-		"test-app-id",
-		"test-app-secret",
-		WithBaseURL(server.URL+"/v1"),
-		WithAuthURL(server.URL+"/api/v1"),
-	)
-	return client, server, mock
+	server, client := privytest.NewServer()
+	return client, server
 }
 
 // ============================================
@@ -24,16 +19,16 @@ func setupTestServer(t *testing.T) (*Client, *httptest.Server, *MockPrivyServer)
 // ============================================
 
 func TestE2E_Users_CreateAndGet(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create a user with email
-	user, err := client.Users().Create(ctx, &CreateUserRequest{
-		LinkedAccounts: []LinkedAccountInput{
+	user, err := client.Users().Create(ctx, &privy.CreateUserRequest{
+		LinkedAccounts: []privy.LinkedAccountInput{
 			{
-				Type:    LinkedAccountTypeEmail,
+				Type:    privy.LinkedAccountTypeEmail,
 				Address: "[email protected]",
 			},
 		},
@@ -50,7 +45,7 @@ func TestE2E_Users_CreateAndGet(t *testing.T) {
 		t.Fatalf("Expected 1 linked account, got %d", len(user.LinkedAccounts))
 	}
 
-	if user.LinkedAccounts[0].Type != LinkedAccountTypeEmail {
+	if user.LinkedAccounts[0].Type != privy.LinkedAccountTypeEmail {
 		t.Errorf("Expected email linked account, got %s", user.LinkedAccounts[0].Type)
 	}
 
@@ -66,15 +61,15 @@ func TestE2E_Users_CreateAndGet(t *testing.T) {
 }
 
 func TestE2E_Users_CreateWithEmbeddedWallet(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	user, err := client.Users().Create(ctx, &CreateUserRequest{
-		LinkedAccounts: []LinkedAccountInput{
+	user, err := client.Users().Create(ctx, &privy.CreateUserRequest{
+		LinkedAccounts: []privy.LinkedAccountInput{
 			{
-				Type:    LinkedAccountTypeEmail,
+				Type:    privy.LinkedAccountTypeEmail,
 				Address: "[email protected]",
 			},
 		},
@@ -91,9 +86,9 @@ func TestE2E_Users_CreateWithEmbeddedWallet(t *testing.T) {
 
 	hasWallet := false
 	for _, la := range user.LinkedAccounts {
-		if la.Type == LinkedAccountTypeWallet {
+		if la.Type == privy.LinkedAccountTypeWallet {
 			hasWallet = true
-			if la.ChainType != ChainTypeEthereum {
+			if la.ChainType != privy.ChainTypeEthereum {
 				t.Errorf("Expected Ethereum wallet, got %s", la.ChainType)
 			}
 			break
@@ -106,15 +101,15 @@ func TestE2E_Users_CreateWithEmbeddedWallet(t *testing.T) {
 }
 
 func TestE2E_Users_CreateWithPhone(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	user, err := client.Users().Create(ctx, &CreateUserRequest{
-		LinkedAccounts: []LinkedAccountInput{
+	user, err := client.Users().Create(ctx, &privy.CreateUserRequest{
+		LinkedAccounts: []privy.LinkedAccountInput{
 			{
-				Type:        LinkedAccountTypePhone,
+				Type:        privy.LinkedAccountTypePhone,
 				PhoneNumber: "+1234567890",
 			},
 		},
@@ -123,13 +118,13 @@ func TestE2E_Users_CreateWithPhone(t *testing.T) {
 		t.Fatalf("Failed to create user: %v", err)
 	}
 
-	if user.LinkedAccounts[0].Type != LinkedAccountTypePhone {
+	if user.LinkedAccounts[0].Type != privy.LinkedAccountTypePhone {
 		t.Errorf("Expected phone linked account, got %s", user.LinkedAccounts[0].Type)
 	}
 }
 
 func TestE2E_Users_CreateWithCustomMetadata(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
@@ -139,9 +134,9 @@ func TestE2E_Users_CreateWithCustomMetadata(t *testing.T) {
 		"signupDate": "2024-01-01",
 	}
 
-	user, err := client.Users().Create(ctx, &CreateUserRequest{
-		LinkedAccounts: []LinkedAccountInput{
-			{Type: LinkedAccountTypeEmail, Address: "[email protected]"},
+	user, err := client.Users().Create(ctx, &privy.CreateUserRequest{
+		LinkedAccounts: []privy.LinkedAccountInput{
+			{Type: privy.LinkedAccountTypeEmail, Address: "[email protected]"},
 		},
 		CustomMetadata: metadata,
 	})
@@ -159,16 +154,16 @@ func TestE2E_Users_CreateWithCustomMetadata(t *testing.T) {
 }
 
 func TestE2E_Users_List(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create multiple users
 	for i := 0; i < 3; i++ {
-		_, err := client.Users().Create(ctx, &CreateUserRequest{
-			LinkedAccounts: []LinkedAccountInput{
-				{Type: LinkedAccountTypeEmail, Address: "user" + string(rune('a'+i)) + "@test.com"},
+		_, err := client.Users().Create(ctx, &privy.CreateUserRequest{
+			LinkedAccounts: []privy.LinkedAccountInput{
+				{Type: privy.LinkedAccountTypeEmail, Address: "user" + string(rune('a'+i)) + "@test.com"},
 			},
 		})
 		if err != nil {
@@ -177,7 +172,7 @@ func TestE2E_Users_List(t *testing.T) {
 	}
 
 	// List users
-	resp, err := client.Users().List(ctx, &ListOptions{Limit: 10})
+	resp, err := client.Users().List(ctx, &privy.ListOptions{Limit: 10})
 	if err != nil {
 		t.Fatalf("Failed to list users: %v", err)
 	}
@@ -188,15 +183,15 @@ func TestE2E_Users_List(t *testing.T) {
 }
 
 func TestE2E_Users_Delete(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create a user
-	user, err := client.Users().Create(ctx, &CreateUserRequest{
-		LinkedAccounts: []LinkedAccountInput{
-			{Type: LinkedAccountTypeEmail, Address: "[email protected]"},
+	user, err := client.Users().Create(ctx, &privy.CreateUserRequest{
+		LinkedAccounts: []privy.LinkedAccountInput{
+			{Type: privy.LinkedAccountTypeEmail, Address: "[email protected]"},
 		},
 	})
 	if err != nil {
@@ -215,7 +210,7 @@ func TestE2E_Users_Delete(t *testing.T) {
 		t.Error("Expected error when getting deleted user")
 	}
 
-	apiErr, ok := err.(*APIError)
+	apiErr, ok := err.(*privy.APIError)
 	if !ok {
 		t.Fatalf("Expected APIError, got %T", err)
 	}
@@ -226,7 +221,7 @@ func TestE2E_Users_Delete(t *testing.T) {
 }
 
 func TestE2E_Users_GetByEmail(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
@@ -234,9 +229,9 @@ func TestE2E_Users_GetByEmail(t *testing.T) {
 	email := "[email protected]"
 
 	// Create a user
-	createdUser, err := client.Users().Create(ctx, &CreateUserRequest{
-		LinkedAccounts: []LinkedAccountInput{
-			{Type: LinkedAccountTypeEmail, Address: email},
+	createdUser, err := client.Users().Create(ctx, &privy.CreateUserRequest{
+		LinkedAccounts: []privy.LinkedAccountInput{
+			{Type: privy.LinkedAccountTypeEmail, Address: email},
 		},
 	})
 	if err != nil {
@@ -255,7 +250,7 @@ func TestE2E_Users_GetByEmail(t *testing.T) {
 }
 
 func TestE2E_Users_GetByEmail_NotFound(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
@@ -267,7 +262,7 @@ func TestE2E_Users_GetByEmail_NotFound(t *testing.T) {
 }
 
 func TestE2E_Users_GetByPhone(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
@@ -275,9 +270,9 @@ func TestE2E_Users_GetByPhone(t *testing.T) {
 	phone := "+1987654321"
 
 	// Create a user
-	createdUser, err := client.Users().Create(ctx, &CreateUserRequest{
-		LinkedAccounts: []LinkedAccountInput{
-			{Type: LinkedAccountTypePhone, PhoneNumber: phone},
+	createdUser, err := client.Users().Create(ctx, &privy.CreateUserRequest{
+		LinkedAccounts: []privy.LinkedAccountInput{
+			{Type: privy.LinkedAccountTypePhone, PhoneNumber: phone},
 		},
 	})
 	if err != nil {
@@ -296,15 +291,15 @@ func TestE2E_Users_GetByPhone(t *testing.T) {
 }
 
 func TestE2E_Users_GetByWalletAddress(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create a user with embedded wallet
-	user, err := client.Users().Create(ctx, &CreateUserRequest{
-		LinkedAccounts: []LinkedAccountInput{
-			{Type: LinkedAccountTypeEmail, Address: "[email protected]"},
+	user, err := client.Users().Create(ctx, &privy.CreateUserRequest{
+		LinkedAccounts: []privy.LinkedAccountInput{
+			{Type: privy.LinkedAccountTypeEmail, Address: "[email protected]"},
 		},
 		CreateEthereumWallet: true,
 	})
@@ -315,7 +310,7 @@ func TestE2E_Users_GetByWalletAddress(t *testing.T) {
 	// Find wallet address
 	var walletAddress string
 	for _, la := range user.LinkedAccounts {
-		if la.Type == LinkedAccountTypeWallet {
+		if la.Type == privy.LinkedAccountTypeWallet {
 			walletAddress = la.Address
 			break
 		}
@@ -337,15 +332,15 @@ func TestE2E_Users_GetByWalletAddress(t *testing.T) {
 }
 
 func TestE2E_Users_UpdateMetadata(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create a user
-	user, err := client.Users().Create(ctx, &CreateUserRequest{
-		LinkedAccounts: []LinkedAccountInput{
-			{Type: LinkedAccountTypeEmail, Address: "[email protected]"},
+	user, err := client.Users().Create(ctx, &privy.CreateUserRequest{
+		LinkedAccounts: []privy.LinkedAccountInput{
+			{Type: privy.LinkedAccountTypeEmail, Address: "[email protected]"},
 		},
 	})
 	if err != nil {
@@ -369,7 +364,7 @@ func TestE2E_Users_UpdateMetadata(t *testing.T) {
 }
 
 func TestE2E_Users_GetNonExistent(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
@@ -379,7 +374,7 @@ func TestE2E_Users_GetNonExistent(t *testing.T) {
 		t.Error("Expected error for non-existent user")
 	}
 
-	apiErr, ok := err.(*APIError)
+	apiErr, ok := err.(*privy.APIError)
 	if !ok {
 		t.Fatalf("Expected APIError, got %T", err)
 	}
@@ -390,7 +385,7 @@ func TestE2E_Users_GetNonExistent(t *testing.T) {
 }
 
 func TestE2E_Users_DeleteNonExistent(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
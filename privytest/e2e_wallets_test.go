@@ -1,8 +1,10 @@
-package privy
+package privytest_test
 
 import (
 	"context"
 	"testing"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
 )
 
 // ============================================
@@ -10,13 +12,13 @@ import (
 // ============================================
 
 func TestE2E_Wallets_CreateEthereum(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
@@ -30,39 +32,39 @@ func TestE2E_Wallets_CreateEthereum(t *testing.T) {
 		t.Error("Expected wallet address to be set")
 	}
 
-	if wallet.ChainType != ChainTypeEthereum {
+	if wallet.ChainType != privy.ChainTypeEthereum {
 		t.Errorf("Expected chain type ethereum, got %s", wallet.ChainType)
 	}
 }
 
 func TestE2E_Wallets_CreateSolana(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeSolana,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeSolana,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create Solana wallet: %v", err)
 	}
 
-	if wallet.ChainType != ChainTypeSolana {
+	if wallet.ChainType != privy.ChainTypeSolana {
 		t.Errorf("Expected chain type solana, got %s", wallet.ChainType)
 	}
 }
 
 func TestE2E_Wallets_CreateWithOwner(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create a user first
-	user, err := client.Users().Create(ctx, &CreateUserRequest{
-		LinkedAccounts: []LinkedAccountInput{
-			{Type: LinkedAccountTypeEmail, Address: "[email protected]"},
+	user, err := client.Users().Create(ctx, &privy.CreateUserRequest{
+		LinkedAccounts: []privy.LinkedAccountInput{
+			{Type: privy.LinkedAccountTypeEmail, Address: "[email protected]"},
 		},
 	})
 	if err != nil {
@@ -70,9 +72,9 @@ func TestE2E_Wallets_CreateWithOwner(t *testing.T) {
 	}
 
 	// Create wallet for the user
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
-		Owner: &WalletOwner{
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
+		Owner: &privy.WalletOwner{
 			UserID: user.ID,
 		},
 	})
@@ -86,13 +88,13 @@ func TestE2E_Wallets_CreateWithOwner(t *testing.T) {
 }
 
 func TestE2E_Wallets_CreateWithPolicy(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create a policy first
-	policy, err := client.Policies().Create(ctx, &CreatePolicyRequest{
+	policy, err := client.Policies().Create(ctx, &privy.CreatePolicyRequest{
 		Name: "Test Policy",
 	})
 	if err != nil {
@@ -100,8 +102,8 @@ func TestE2E_Wallets_CreateWithPolicy(t *testing.T) {
 	}
 
 	// Create wallet with policy
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 		PolicyIDs: []string{policy.ID},
 	})
 	if err != nil {
@@ -118,28 +120,28 @@ func TestE2E_Wallets_CreateWithPolicy(t *testing.T) {
 }
 
 func TestE2E_Wallets_CreateAllChainTypes(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
-	chainTypes := []ChainType{
-		ChainTypeEthereum,
-		ChainTypeSolana,
-		ChainTypeStellar,
-		ChainTypeCosmos,
-		ChainTypeSui,
-		ChainTypeTron,
-		ChainTypeBitcoinSegwit,
-		ChainTypeNear,
-		ChainTypeTon,
-		ChainTypeStarknet,
-		ChainTypeAptos,
+	chainTypes := []privy.ChainType{
+		privy.ChainTypeEthereum,
+		privy.ChainTypeSolana,
+		privy.ChainTypeStellar,
+		privy.ChainTypeCosmos,
+		privy.ChainTypeSui,
+		privy.ChainTypeTron,
+		privy.ChainTypeBitcoinSegwit,
+		privy.ChainTypeNear,
+		privy.ChainTypeTon,
+		privy.ChainTypeStarknet,
+		privy.ChainTypeAptos,
 	}
 
 	for _, ct := range chainTypes {
 		t.Run(string(ct), func(t *testing.T) {
-			wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
+			wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
 				ChainType: ct,
 			})
 			if err != nil {
@@ -154,14 +156,14 @@ func TestE2E_Wallets_CreateAllChainTypes(t *testing.T) {
 }
 
 func TestE2E_Wallets_Get(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create a wallet
-	created, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	created, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
@@ -183,7 +185,7 @@ func TestE2E_Wallets_Get(t *testing.T) {
 }
 
 func TestE2E_Wallets_GetNonExistent(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
@@ -195,15 +197,15 @@ func TestE2E_Wallets_GetNonExistent(t *testing.T) {
 }
 
 func TestE2E_Wallets_List(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create multiple wallets
 	for i := 0; i < 5; i++ {
-		_, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-			ChainType: ChainTypeEthereum,
+		_, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+			ChainType: privy.ChainTypeEthereum,
 		})
 		if err != nil {
 			t.Fatalf("Failed to create wallet %d: %v", i, err)
@@ -211,7 +213,7 @@ func TestE2E_Wallets_List(t *testing.T) {
 	}
 
 	// List wallets
-	resp, err := client.Wallets().List(ctx, &WalletListOptions{Limit: 10})
+	resp, err := client.Wallets().List(ctx, &privy.WalletListOptions{Limit: 10})
 	if err != nil {
 		t.Fatalf("Failed to list wallets: %v", err)
 	}
@@ -222,21 +224,21 @@ func TestE2E_Wallets_List(t *testing.T) {
 }
 
 func TestE2E_Wallets_Update(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create a wallet
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
 	}
 
 	// Create a policy
-	policy, err := client.Policies().Create(ctx, &CreatePolicyRequest{
+	policy, err := client.Policies().Create(ctx, &privy.CreatePolicyRequest{
 		Name: "New Policy",
 	})
 	if err != nil {
@@ -244,7 +246,7 @@ func TestE2E_Wallets_Update(t *testing.T) {
 	}
 
 	// Update wallet with policy
-	updated, err := client.Wallets().Update(ctx, wallet.ID, &UpdateWalletRequest{
+	updated, err := client.Wallets().Update(ctx, wallet.ID, &privy.UpdateWalletRequest{
 		PolicyIDs: []string{policy.ID},
 	})
 	if err != nil {
@@ -257,21 +259,21 @@ func TestE2E_Wallets_Update(t *testing.T) {
 }
 
 func TestE2E_Wallets_GetBalance(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create a wallet
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
 	}
 
 	// Get balance
-	balance, err := client.Wallets().GetBalance(ctx, wallet.ID, &GetBalanceOptions{
+	balance, err := client.Wallets().GetBalance(ctx, wallet.ID, &privy.GetBalanceOptions{
 		Chain: "ethereum",
 	})
 	if err != nil {
@@ -284,21 +286,21 @@ func TestE2E_Wallets_GetBalance(t *testing.T) {
 }
 
 func TestE2E_Wallets_GetTransactions(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create a wallet
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
 	}
 
 	// Get transactions with required parameters
-	txs, err := client.Wallets().GetTransactions(ctx, wallet.ID, &GetTransactionsOptions{
+	txs, err := client.Wallets().GetTransactions(ctx, wallet.ID, &privy.GetTransactionsOptions{
 		Chain: "ethereum",
 		Asset: []string{"eth"},
 		Limit: 50,
@@ -313,7 +315,7 @@ func TestE2E_Wallets_GetTransactions(t *testing.T) {
 	}
 
 	// Test with multiple assets
-	txs, err = client.Wallets().GetTransactions(ctx, wallet.ID, &GetTransactionsOptions{
+	txs, err = client.Wallets().GetTransactions(ctx, wallet.ID, &privy.GetTransactionsOptions{
 		Chain: "ethereum",
 		Asset: []string{"eth", "usdc", "usdt"},
 		Limit: 10,
@@ -328,14 +330,14 @@ func TestE2E_Wallets_GetTransactions(t *testing.T) {
 		t.Error("Expected error when options is nil")
 	}
 
-	_, err = client.Wallets().GetTransactions(ctx, wallet.ID, &GetTransactionsOptions{
+	_, err = client.Wallets().GetTransactions(ctx, wallet.ID, &privy.GetTransactionsOptions{
 		Asset: []string{"eth"},
 	})
 	if err == nil {
 		t.Error("Expected error when chain is missing")
 	}
 
-	_, err = client.Wallets().GetTransactions(ctx, wallet.ID, &GetTransactionsOptions{
+	_, err = client.Wallets().GetTransactions(ctx, wallet.ID, &privy.GetTransactionsOptions{
 		Chain: "ethereum",
 	})
 	if err == nil {
@@ -343,7 +345,7 @@ func TestE2E_Wallets_GetTransactions(t *testing.T) {
 	}
 
 	// Test limit validation
-	_, err = client.Wallets().GetTransactions(ctx, wallet.ID, &GetTransactionsOptions{
+	_, err = client.Wallets().GetTransactions(ctx, wallet.ID, &privy.GetTransactionsOptions{
 		Chain: "ethereum",
 		Asset: []string{"eth"},
 		Limit: 150, // Exceeds max of 100
@@ -353,7 +355,7 @@ func TestE2E_Wallets_GetTransactions(t *testing.T) {
 	}
 
 	// Test max assets validation
-	_, err = client.Wallets().GetTransactions(ctx, wallet.ID, &GetTransactionsOptions{
+	_, err = client.Wallets().GetTransactions(ctx, wallet.ID, &privy.GetTransactionsOptions{
 		Chain: "ethereum",
 		Asset: []string{"eth", "usdc", "usdt", "dai", "wbtc"}, // 5 assets, max is 4
 	})
@@ -363,30 +365,28 @@ func TestE2E_Wallets_GetTransactions(t *testing.T) {
 }
 
 func TestE2E_Wallets_GetTransactionByHash(t *testing.T) {
-	client, server, mockServer := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create a wallet
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
 	}
 
 	// Add a mock transaction to the mock server
-	mockTx := Transaction{
+	mockTx := privy.Transaction{
 		ID:       "tx-123",
 		WalletID: wallet.ID,
 		Hash:     "0xabc123",
 		Status:   "confirmed",
 		CAIP2:    "eip155:1",
 	}
-	mockServer.mu.Lock()
-	mockServer.transactions["tx-123"] = &mockTx
-	mockServer.mu.Unlock()
+	server.AddTransaction(&mockTx)
 
 	// Test GetTransactionByHash (will return empty result from mock server)
 	// In real usage, this would find the transaction by hash
@@ -404,14 +404,14 @@ func TestE2E_Wallets_GetTransactionByHash(t *testing.T) {
 }
 
 func TestE2E_Wallets_Export(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create a wallet
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
@@ -429,14 +429,14 @@ func TestE2E_Wallets_Export(t *testing.T) {
 }
 
 func TestE2E_Wallets_Import(t *testing.T) {
-	client, server, _ := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Initialize import
-	initResp, err := client.Wallets().InitializeImport(ctx, &ImportWalletInitRequest{
-		ChainType: ChainTypeEthereum,
+	initResp, err := client.Wallets().InitializeImport(ctx, &privy.ImportWalletInitRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to initialize import: %v", err)
@@ -451,7 +451,7 @@ func TestE2E_Wallets_Import(t *testing.T) {
 	}
 
 	// Submit import
-	wallet, err := client.Wallets().SubmitImport(ctx, &ImportWalletSubmitRequest{
+	wallet, err := client.Wallets().SubmitImport(ctx, &privy.ImportWalletSubmitRequest{
 		ImportID:            initResp.ImportID,
 		EncryptedPrivateKey: "encrypted-key-data",
 	})
@@ -465,21 +465,21 @@ func TestE2E_Wallets_Import(t *testing.T) {
 }
 
 func TestE2E_Wallets_GetTransaction(t *testing.T) {
-	client, server, mock := setupTestServer(t)
+	client, server := setupTestServer(t)
 	defer server.Close()
 
 	ctx := context.Background()
 
 	// Create a wallet
-	wallet, err := client.Wallets().Create(ctx, &CreateWalletRequest{
-		ChainType: ChainTypeEthereum,
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+		ChainType: privy.ChainTypeEthereum,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
 	}
 
 	// Send a transaction to create a transaction record
-	tx := &EthereumTransaction{
+	tx := &privy.EthereumTransaction{
 		To:      "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045",
 		Value:   "0x1000",
 		ChainID: 1,
@@ -492,13 +492,11 @@ func TestE2E_Wallets_GetTransaction(t *testing.T) {
 
 	// The mock server creates transactions with incremental IDs
 	// Find the transaction ID from the mock
-	mock.mu.RLock()
 	var txID string
-	for id := range mock.transactions {
-		txID = id
+	for _, tx := range server.Transactions() {
+		txID = tx.ID
 		break
 	}
-	mock.mu.RUnlock()
 
 	if txID == "" {
 		t.Fatal("No transaction was created")
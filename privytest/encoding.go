@@ -0,0 +1,158 @@
+package privytest
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"strings"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func base58Encode(data []byte) string {
+	n := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		out = append(out, '1')
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// base58Check appends a double-SHA-256 checksum before encoding, as Tron
+// addresses do.
+func base58Check(payload []byte) string {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	return base58Encode(append(append([]byte(nil), payload...), second[:4]...))
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+func bech32Polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+// convertBits regroups 8-bit bytes into 5-bit groups, padding the last one.
+func convertBits(data []byte) []byte {
+	var out []byte
+	acc, bits := 0, 0
+	for _, b := range data {
+		acc = acc<<8 | int(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out = append(out, byte(acc>>bits&31))
+		}
+	}
+	if bits > 0 {
+		out = append(out, byte(acc<<(5-bits)&31))
+	}
+	return out
+}
+
+// bech32Encode encodes 5-bit data with a bech32 checksum.
+func bech32Encode(hrp string, data []byte) string {
+	values := make([]byte, 0, len(hrp)*2+1+len(data)+6)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]>>5)
+	}
+	values = append(values, 0)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]&31)
+	}
+	values = append(values, data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	polymod := bech32Polymod(values) ^ 1
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, d := range data {
+		sb.WriteByte(bech32Charset[d])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(bech32Charset[(polymod>>(5*(5-i)))&31])
+	}
+	return sb.String()
+}
+
+// segwitV0Address encodes a version 0 witness program.
+func segwitV0Address(hrp string, program []byte) string {
+	return bech32Encode(hrp, append([]byte{0}, convertBits(program)...))
+}
+
+// stellarAddress encodes an Ed25519 public key as a Stellar G... strkey.
+func stellarAddress(pub []byte) string {
+	payload := append([]byte{6 << 3}, pub...)
+	crc := crc16XModem(payload)
+	payload = binary.LittleEndian.AppendUint16(payload, crc)
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(payload)
+}
+
+// tonWalletV4R2Code* describe the code cell of the TON v4r2 wallet contract.
+const (
+	tonWalletV4R2CodeHash  = "feb5ff6820e2ff0d9483e7e0d62c817d846789fb4ae580c878866d959dabd5c0"
+	tonWalletV4R2CodeDepth = 7
+)
+
+// tonWalletAddress returns the raw address of the v4r2 wallet (workchain 0,
+// default subwallet) owned by an Ed25519 public key: the hash of its
+// StateInit cell.
+func tonWalletAddress(pub []byte) string {
+	// Data cell: seqno:uint32 wallet_id:uint32 public_key:bits256 plugins:0,
+	// 321 bits padded with the completion tag.
+	data := binary.BigEndian.AppendUint32(make([]byte, 4), 698983191)
+	data = append(data, pub...)
+	data = append(data, 0x40)
+	dataHash := sha256.Sum256(append([]byte{0, 81}, data...))
+
+	// StateInit cell: bits 00110 (code and data present) and two refs.
+	codeHash, _ := hex.DecodeString(tonWalletV4R2CodeHash)
+	repr := []byte{2, 1, 0x34}
+	repr = binary.BigEndian.AppendUint16(repr, tonWalletV4R2CodeDepth)
+	repr = binary.BigEndian.AppendUint16(repr, 0)
+	repr = append(repr, codeHash...)
+	repr = append(repr, dataHash[:]...)
+	sum := sha256.Sum256(repr)
+	return "0:" + hex.EncodeToString(sum[:])
+}
+
+func crc16XModem(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
@@ -0,0 +1,133 @@
+package privytest
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+// rlpBytes encodes a byte string.
+func rlpBytes(b []byte) []byte {
+	if len(b) == 1 && b[0] < 0x80 {
+		return b
+	}
+	return append(rlpHeader(0x80, len(b)), b...)
+}
+
+// rlpInt encodes an unsigned integer with no leading zeros.
+func rlpInt(v *big.Int) []byte {
+	return rlpBytes(v.Bytes())
+}
+
+// rlpList encodes a list of already encoded items.
+func rlpList(items ...[]byte) []byte {
+	var payload []byte
+	for _, item := range items {
+		payload = append(payload, item...)
+	}
+	return append(rlpHeader(0xc0, len(payload)), payload...)
+}
+
+func rlpHeader(offset byte, n int) []byte {
+	if n < 56 {
+		return []byte{offset + byte(n)}
+	}
+	size := big.NewInt(int64(n)).Bytes()
+	return append([]byte{offset + 55 + byte(len(size))}, size...)
+}
+
+// parseQuantity parses a hex (0x-prefixed) or decimal quantity; empty is zero.
+func parseQuantity(s string) (*big.Int, error) {
+	if s == "" {
+		return new(big.Int), nil
+	}
+	v, ok := new(big.Int), false
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		if len(s) == 2 {
+			return v, nil
+		}
+		v, ok = v.SetString(s[2:], 16)
+	} else {
+		v, ok = v.SetString(s, 10)
+	}
+	if !ok || v.Sign() < 0 {
+		return nil, fmt.Errorf("invalid quantity %q", s)
+	}
+	return v, nil
+}
+
+// signEthereumTransaction signs tx and returns the raw transaction. It
+// produces an EIP-155 legacy transaction when only a gas price is set and an
+// EIP-1559 (type 2) transaction otherwise.
+func signEthereumTransaction(key *walletKey, tx *privy.EthereumTransaction, chainID int64) ([]byte, error) {
+	if key.scheme != schemeSecp256k1 {
+		return nil, fmt.Errorf("wallet cannot sign Ethereum transactions")
+	}
+	if tx.ChainID != 0 {
+		chainID = tx.ChainID
+	}
+	if chainID == 0 {
+		chainID = 1
+	}
+
+	var to []byte
+	if tx.To != "" {
+		b, err := decodeHex(tx.To)
+		if err != nil || len(b) != 20 {
+			return nil, fmt.Errorf("invalid to address %q", tx.To)
+		}
+		to = b
+	}
+	data, err := decodeHex(tx.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data: %w", err)
+	}
+
+	fields := map[string]*big.Int{}
+	for name, s := range map[string]string{
+		"value":                    tx.Value,
+		"gas_limit":                tx.GasLimit,
+		"gas_price":                tx.GasPrice,
+		"max_fee_per_gas":          tx.MaxFeePerGas,
+		"max_priority_fee_per_gas": tx.MaxPriorityFeePerGas,
+	} {
+		v, err := parseQuantity(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		fields[name] = v
+	}
+	if fields["gas_limit"].Sign() == 0 {
+		fields["gas_limit"] = big.NewInt(21000)
+	}
+	nonce := big.NewInt(tx.Nonce)
+	chain := big.NewInt(chainID)
+
+	if tx.Type == 0 && tx.GasPrice != "" && tx.MaxFeePerGas == "" {
+		unsigned := [][]byte{
+			rlpInt(nonce), rlpInt(fields["gas_price"]), rlpInt(fields["gas_limit"]),
+			rlpBytes(to), rlpInt(fields["value"]), rlpBytes(data),
+		}
+		sigHash := keccak256(rlpList(append(unsigned, rlpInt(chain), rlpInt(new(big.Int)), rlpInt(new(big.Int)))...))
+		r, s, recID := secpSign(key.secp, sigHash)
+		v := new(big.Int).Add(new(big.Int).Mul(chain, big.NewInt(2)), big.NewInt(35+int64(recID)))
+		return rlpList(append(unsigned, rlpInt(v), rlpInt(r), rlpInt(s))...), nil
+	}
+
+	unsigned := [][]byte{
+		rlpInt(chain), rlpInt(nonce), rlpInt(fields["max_priority_fee_per_gas"]), rlpInt(fields["max_fee_per_gas"]),
+		rlpInt(fields["gas_limit"]), rlpBytes(to), rlpInt(fields["value"]), rlpBytes(data), rlpList(),
+	}
+	sigHash := keccak256([]byte{0x02}, rlpList(unsigned...))
+	r, s, recID := secpSign(key.secp, sigHash)
+	signed := rlpList(append(unsigned, rlpInt(big.NewInt(int64(recID))), rlpInt(r), rlpInt(s))...)
+	return append([]byte{0x02}, signed...), nil
+}
+
+// personalSign signs message with the EIP-191 prefix and returns r‖s‖v.
+func personalSign(key *walletKey, message []byte) ([]byte, error) {
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(message))
+	return key.signHash(keccak256([]byte(prefix), message))
+}
@@ -0,0 +1,1067 @@
+package privytest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (s *Server) writeError(w http.ResponseWriter, status int, message string) {
+	s.writeJSON(w, status, map[string]string{"message": message})
+}
+
+// User handlers
+func (s *Server) handleCreateUser(w http.ResponseWriter, r *http.Request) {
+	var req privy.CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.userCounter++
+	userID := fmt.Sprintf("did:privy:user%d", s.userCounter)
+
+	linkedAccounts := make([]privy.LinkedAccount, len(req.LinkedAccounts))
+	for i, la := range req.LinkedAccounts {
+		linkedAccounts[i] = privy.LinkedAccount{
+			Type:            la.Type,
+			Address:         la.Address,
+			PhoneNumber:     la.PhoneNumber,
+			VerifiedAt:      time.Now().UnixMilli(),
+			FirstVerifiedAt: time.Now().UnixMilli(),
+		}
+	}
+
+	user := &privy.User{
+		ID:             userID,
+		CreatedAt:      time.Now().UnixMilli(),
+		LinkedAccounts: linkedAccounts,
+		CustomMetadata: req.CustomMetadata,
+	}
+
+	// Create embedded wallet if requested
+	if req.CreateEthereumWallet {
+		wallet := s.newWallet(privy.ChainTypeEthereum)
+		user.LinkedAccounts = append(user.LinkedAccounts, privy.LinkedAccount{
+			Type:      privy.LinkedAccountTypeWallet,
+			Address:   wallet.Address,
+			ChainType: privy.ChainTypeEthereum,
+		})
+	}
+
+	s.users[userID] = user
+	s.writeJSON(w, http.StatusOK, user)
+}
+
+func (s *Server) handleGetUser(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	userID := parts[len(parts)-1]
+
+	s.mu.RLock()
+	user, exists := s.users[userID]
+	s.mu.RUnlock()
+
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, user)
+}
+
+func (s *Server) handleListUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	users := make([]privy.User, 0, len(s.users))
+	for _, u := range s.users {
+		users = append(users, *u)
+	}
+	s.mu.RUnlock()
+
+	resp := privy.PaginatedResponse[privy.User]{
+		Data: users,
+	}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleDeleteUser(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	userID := parts[len(parts)-1]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.users[userID]; !exists {
+		s.writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	delete(s.users, userID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleGetUserByEmail(w http.ResponseWriter, r *http.Request) {
+	var req map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	email := req["address"]
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, user := range s.users {
+		for _, la := range user.LinkedAccounts {
+			if la.Type == privy.LinkedAccountTypeEmail && la.Address == email {
+				s.writeJSON(w, http.StatusOK, user)
+				return
+			}
+		}
+	}
+
+	s.writeError(w, http.StatusNotFound, "User not found")
+}
+
+func (s *Server) handleGetUserByPhone(w http.ResponseWriter, r *http.Request) {
+	var req map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	phone := req["number"]
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, user := range s.users {
+		for _, la := range user.LinkedAccounts {
+			if la.Type == privy.LinkedAccountTypePhone && la.PhoneNumber == phone {
+				s.writeJSON(w, http.StatusOK, user)
+				return
+			}
+		}
+	}
+
+	s.writeError(w, http.StatusNotFound, "User not found")
+}
+
+func (s *Server) handleGetUserByWallet(w http.ResponseWriter, r *http.Request) {
+	var req map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	address := req["address"]
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, user := range s.users {
+		for _, la := range user.LinkedAccounts {
+			if la.Type == privy.LinkedAccountTypeWallet && la.Address == address {
+				s.writeJSON(w, http.StatusOK, user)
+				return
+			}
+		}
+	}
+
+	s.writeError(w, http.StatusNotFound, "User not found")
+}
+
+func (s *Server) handleUpdateUserMetadata(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	userID := parts[len(parts)-2]
+
+	var req privy.UpdateUserMetadataRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, exists := s.users[userID]
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	user.CustomMetadata = req.CustomMetadata
+	s.writeJSON(w, http.StatusOK, user)
+}
+
+// Wallet handlers
+func (s *Server) handleCreateWallet(w http.ResponseWriter, r *http.Request) {
+	var req privy.CreateWalletRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wallet := s.newWallet(req.ChainType)
+	wallet.PolicyIDs = req.PolicyIDs
+
+	if req.Owner != nil && req.Owner.UserID != "" {
+		wallet.OwnerID = req.Owner.UserID
+	}
+	s.writeJSON(w, http.StatusOK, wallet)
+}
+
+func (s *Server) handleGetWallet(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	walletID := parts[len(parts)-1]
+
+	s.mu.RLock()
+	wallet, exists := s.wallets[walletID]
+	s.mu.RUnlock()
+
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Wallet not found")
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, wallet)
+}
+
+func (s *Server) handleListWallets(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	wallets := make([]privy.Wallet, 0, len(s.wallets))
+	for _, wal := range s.wallets {
+		wallets = append(wallets, *wal)
+	}
+	s.mu.RUnlock()
+
+	resp := privy.PaginatedResponse[privy.Wallet]{
+		Data: wallets,
+	}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleUpdateWallet(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	walletID := parts[len(parts)-1]
+
+	var req privy.UpdateWalletRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wallet, exists := s.wallets[walletID]
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Wallet not found")
+		return
+	}
+
+	if req.PolicyIDs != nil {
+		wallet.PolicyIDs = req.PolicyIDs
+	}
+
+	s.writeJSON(w, http.StatusOK, wallet)
+}
+
+func (s *Server) handleGetWalletBalance(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	walletID := parts[len(parts)-2]
+
+	s.mu.RLock()
+	_, exists := s.wallets[walletID]
+	s.mu.RUnlock()
+
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Wallet not found")
+		return
+	}
+
+	balance := &privy.WalletBalance{
+		Balance:  "1000000000000000000",
+		Currency: "ETH",
+		Symbol:   "ETH",
+	}
+	s.writeJSON(w, http.StatusOK, balance)
+}
+
+func (s *Server) handleGetWalletTransactions(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	walletID := parts[len(parts)-2]
+
+	s.mu.RLock()
+	_, exists := s.wallets[walletID]
+	s.mu.RUnlock()
+
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Wallet not found")
+		return
+	}
+
+	// Validate required query parameters
+	chain := r.URL.Query().Get("chain")
+	assets := r.URL.Query()["asset"]
+
+	if chain == "" {
+		s.writeError(w, http.StatusBadRequest, "chain parameter is required")
+		return
+	}
+
+	if len(assets) == 0 {
+		s.writeError(w, http.StatusBadRequest, "asset parameter is required")
+		return
+	}
+
+	resp := privy.PaginatedResponse[privy.Transaction]{
+		Data: []privy.Transaction{},
+	}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleExportWallet(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	walletID := parts[len(parts)-2]
+
+	s.mu.RLock()
+	key, exists := s.keys[walletID]
+	s.mu.RUnlock()
+
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Wallet not found")
+		return
+	}
+
+	resp := &privy.ExportWalletResponse{
+		PrivateKey: key.privateKeyHex(),
+	}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleInitializeImport(w http.ResponseWriter, r *http.Request) {
+	resp := &privy.ImportWalletInitResponse{
+		ImportID:  "import-123",
+		PublicKey: "0xpublickey",
+	}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleSubmitImport(w http.ResponseWriter, r *http.Request) {
+	var req privy.ImportWalletSubmitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wallet := s.newWallet(privy.ChainTypeEthereum)
+	s.writeJSON(w, http.StatusOK, wallet)
+}
+
+func (s *Server) handleWalletRPC(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	walletID := parts[len(parts)-2]
+
+	s.mu.RLock()
+	wallet, exists := s.wallets[walletID]
+	key := s.keys[walletID]
+	s.mu.RUnlock()
+
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Wallet not found")
+		return
+	}
+
+	var req struct {
+		privy.RPCRequest
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	resp := privy.SignatureResponse{
+		Method: req.Method,
+	}
+
+	switch req.Method {
+	case "personal_sign", "signMessage", "eth_signTransaction", "signTransaction", "secp256k1_sign", "raw_sign":
+		if err := signRPC(key, req.Method, req.Params, &resp); err != nil {
+			s.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	case "eth_sendTransaction", "signAndSendTransaction":
+		hash, err := sendRPC(key, req.Method, req.CAIP2, req.Params)
+		if err != nil {
+			s.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		s.txCounter++
+		txID := fmt.Sprintf("tx-%d", s.txCounter)
+		tx := &privy.Transaction{
+			ID:        txID,
+			WalletID:  wallet.ID,
+			ChainType: wallet.ChainType,
+			CAIP2:     req.CAIP2,
+			Hash:      hash,
+			Status:    "pending",
+			CreatedAt: time.Now().UnixMilli(),
+		}
+		s.transactions[txID] = tx
+		s.mu.Unlock()
+
+		resp.Data.Hash = tx.Hash
+		resp.Data.CAIP2 = req.CAIP2
+	case "eth_signTypedData_v4":
+		resp.Data.Signature = "0xtypeddatasig1234567890"
+		resp.Data.Encoding = "hex"
+	case "eth_signUserOperation":
+		resp.Data.Signature = "0xuserop1234567890"
+		resp.Data.Encoding = "hex"
+	case "eth_sign7702Authorization":
+		resp.Data.Signature = "0x7702sig1234567890"
+		resp.Data.Encoding = "hex"
+	case "transfer":
+		sparkResp := privy.SparkTransferResponse{Method: req.Method}
+		sparkResp.Data.ID = "spark-tx-001"
+		sparkResp.Data.Status = "TRANSFER_STATUS_SENDER_KEY_TWEAKED"
+		sparkResp.Data.TotalValue = 100
+		sparkResp.Data.TransferDirection = "OUTGOING"
+		sparkResp.Data.Encoding = "hex"
+		s.writeJSON(w, http.StatusOK, sparkResp)
+		return
+	case "getBalance":
+		sparkResp := privy.SparkBalanceResponse{Method: req.Method}
+		sparkResp.Data.Balance = "1000000"
+		sparkResp.Data.Encoding = "hex"
+		s.writeJSON(w, http.StatusOK, sparkResp)
+		return
+	case "transferTokens":
+		sparkResp := privy.SparkResponse{Method: req.Method, Data: map[string]any{"id": "token-tx-001"}}
+		s.writeJSON(w, http.StatusOK, sparkResp)
+		return
+	case "getStaticDepositAddress":
+		sparkResp := privy.SparkDepositAddressResponse{Method: req.Method}
+		sparkResp.Data.Address = "bc1pmockaddress1234567890"
+		s.writeJSON(w, http.StatusOK, sparkResp)
+		return
+	case "getClaimStaticDepositQuote":
+		sparkResp := privy.SparkResponse{Method: req.Method, Data: map[string]any{"txId": "btc-tx-001", "creditAmountSats": 5000, "sspSignature": "mock-sig"}}
+		s.writeJSON(w, http.StatusOK, sparkResp)
+		return
+	case "claimStaticDeposit":
+		sparkResp := privy.SparkResponse{Method: req.Method, Data: map[string]any{"status": "claimed"}}
+		s.writeJSON(w, http.StatusOK, sparkResp)
+		return
+	case "createLightningInvoice":
+		sparkResp := privy.SparkLightningInvoiceResponse{Method: req.Method}
+		sparkResp.Data.ID = "invoice-001"
+		sparkResp.Data.Status = "INVOICE_CREATED"
+		sparkResp.Data.Invoice.EncodedInvoice = "lnbc100u1pmockinvoice"
+		sparkResp.Data.Invoice.PaymentHash = "mockhash123"
+		sparkResp.Data.Invoice.Amount.Sats = 10000
+		sparkResp.Data.Encoding = "hex"
+		s.writeJSON(w, http.StatusOK, sparkResp)
+		return
+	case "payLightningInvoice":
+		sparkResp := privy.SparkResponse{Method: req.Method, Data: map[string]any{"status": "paid"}}
+		s.writeJSON(w, http.StatusOK, sparkResp)
+		return
+	case "signMessageWithIdentityKey":
+		sparkResp := privy.SparkSignatureResponse{Method: req.Method}
+		sparkResp.Data.Signature = "0xsparksig1234567890"
+		s.writeJSON(w, http.StatusOK, sparkResp)
+		return
+	default:
+		s.writeError(w, http.StatusBadRequest, fmt.Sprintf("Unknown RPC method: %s", req.Method))
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleWalletRawSign(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	walletID := parts[len(parts)-2]
+
+	s.mu.RLock()
+	key, exists := s.keys[walletID]
+	s.mu.RUnlock()
+
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Wallet not found")
+		return
+	}
+
+	var req struct {
+		Params struct {
+			privy.RawSignHashParams
+			privy.RawSignBytesParams
+		} `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	sig, err := rawSign(key, req.Params.RawSignHashParams, req.Params.RawSignBytesParams)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp := privy.RawSignResponse{
+		Method: "raw_sign",
+	}
+	resp.Data.Signature = "0x" + hex.EncodeToString(sig)
+	resp.Data.Encoding = "hex"
+
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleGetTransaction(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	txID := parts[len(parts)-1]
+
+	s.mu.RLock()
+	tx, exists := s.transactions[txID]
+	s.mu.RUnlock()
+
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Transaction not found")
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, tx)
+}
+
+// Policy handlers
+func (s *Server) handleCreatePolicy(w http.ResponseWriter, r *http.Request) {
+	var req privy.CreatePolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.policyCounter++
+	policyID := fmt.Sprintf("policy-%d", s.policyCounter)
+
+	policy := &privy.Policy{
+		ID:        policyID,
+		Name:      req.Name,
+		Rules:     req.Rules,
+		CreatedAt: time.Now().UnixMilli(),
+	}
+
+	s.policies[policyID] = policy
+	s.writeJSON(w, http.StatusOK, policy)
+}
+
+func (s *Server) handleGetPolicy(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	policyID := parts[len(parts)-1]
+
+	s.mu.RLock()
+	policy, exists := s.policies[policyID]
+	s.mu.RUnlock()
+
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Policy not found")
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, policy)
+}
+
+func (s *Server) handleUpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	policyID := parts[len(parts)-1]
+
+	var req privy.UpdatePolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policy, exists := s.policies[policyID]
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Policy not found")
+		return
+	}
+
+	if req.Name != "" {
+		policy.Name = req.Name
+	}
+	policy.UpdatedAt = time.Now().UnixMilli()
+
+	s.writeJSON(w, http.StatusOK, policy)
+}
+
+func (s *Server) handleDeletePolicy(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	policyID := parts[len(parts)-1]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.policies[policyID]; !exists {
+		s.writeError(w, http.StatusNotFound, "Policy not found")
+		return
+	}
+
+	delete(s.policies, policyID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleAddPolicyRule(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	policyID := parts[len(parts)-2]
+
+	var req privy.CreateRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policy, exists := s.policies[policyID]
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Policy not found")
+		return
+	}
+
+	rule := privy.PolicyRule{
+		ID:         fmt.Sprintf("rule-%d", len(policy.Rules)+1),
+		Action:     req.Action,
+		Conditions: req.Conditions,
+	}
+
+	policy.Rules = append(policy.Rules, rule)
+	s.writeJSON(w, http.StatusOK, rule)
+}
+
+func (s *Server) handleGetPolicyRule(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	policyID := parts[len(parts)-3]
+	ruleID := parts[len(parts)-1]
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	policy, exists := s.policies[policyID]
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Policy not found")
+		return
+	}
+
+	for _, rule := range policy.Rules {
+		if rule.ID == ruleID {
+			s.writeJSON(w, http.StatusOK, rule)
+			return
+		}
+	}
+
+	s.writeError(w, http.StatusNotFound, "Rule not found")
+}
+
+func (s *Server) handleUpdatePolicyRule(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	policyID := parts[len(parts)-3]
+	ruleID := parts[len(parts)-1]
+
+	var req privy.UpdateRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policy, exists := s.policies[policyID]
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Policy not found")
+		return
+	}
+
+	for i, rule := range policy.Rules {
+		if rule.ID == ruleID {
+			if req.Action != "" {
+				policy.Rules[i].Action = req.Action
+			}
+			if req.Conditions != nil {
+				policy.Rules[i].Conditions = req.Conditions
+			}
+			s.writeJSON(w, http.StatusOK, policy.Rules[i])
+			return
+		}
+	}
+
+	s.writeError(w, http.StatusNotFound, "Rule not found")
+}
+
+func (s *Server) handleDeletePolicyRule(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	policyID := parts[len(parts)-3]
+	ruleID := parts[len(parts)-1]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policy, exists := s.policies[policyID]
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Policy not found")
+		return
+	}
+
+	for i, rule := range policy.Rules {
+		if rule.ID == ruleID {
+			policy.Rules = append(policy.Rules[:i], policy.Rules[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	s.writeError(w, http.StatusNotFound, "Rule not found")
+}
+
+// Condition Set handlers
+func (s *Server) handleCreateConditionSet(w http.ResponseWriter, r *http.Request) {
+	var req privy.CreateConditionSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.csCounter++
+	csID := fmt.Sprintf("cs-%d", s.csCounter)
+
+	cs := &privy.ConditionSet{
+		ID:        csID,
+		Name:      req.Name,
+		OwnerID:   req.OwnerID,
+		CreatedAt: time.Now().UnixMilli(),
+	}
+
+	s.conditionSets[csID] = cs
+	s.csItems[csID] = make(map[string]*privy.ConditionSetItem)
+	s.writeJSON(w, http.StatusOK, cs)
+}
+
+func (s *Server) handleGetConditionSet(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	csID := parts[len(parts)-1]
+
+	s.mu.RLock()
+	cs, exists := s.conditionSets[csID]
+	s.mu.RUnlock()
+
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Condition set not found")
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, cs)
+}
+
+func (s *Server) handleUpdateConditionSet(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	csID := parts[len(parts)-1]
+
+	var req privy.UpdateConditionSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cs, exists := s.conditionSets[csID]
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Condition set not found")
+		return
+	}
+
+	if req.Name != "" {
+		cs.Name = req.Name
+	}
+	cs.UpdatedAt = time.Now().UnixMilli()
+
+	s.writeJSON(w, http.StatusOK, cs)
+}
+
+func (s *Server) handleDeleteConditionSet(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	csID := parts[len(parts)-1]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.conditionSets[csID]; !exists {
+		s.writeError(w, http.StatusNotFound, "Condition set not found")
+		return
+	}
+
+	delete(s.conditionSets, csID)
+	delete(s.csItems, csID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleAddConditionSetItems(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	csID := parts[len(parts)-2]
+
+	var req privy.AddConditionSetItemsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.conditionSets[csID]; !exists {
+		s.writeError(w, http.StatusNotFound, "Condition set not found")
+		return
+	}
+
+	items := make([]privy.ConditionSetItem, len(req.Items))
+	for i, item := range req.Items {
+		s.itemCounter++
+		itemID := fmt.Sprintf("item-%d", s.itemCounter)
+		csItem := &privy.ConditionSetItem{
+			ID:    itemID,
+			Value: item.Value,
+		}
+		s.csItems[csID][itemID] = csItem
+		items[i] = *csItem
+	}
+
+	s.writeJSON(w, http.StatusOK, items)
+}
+
+func (s *Server) handleListConditionSetItems(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	csID := parts[len(parts)-2]
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.conditionSets[csID]; !exists {
+		s.writeError(w, http.StatusNotFound, "Condition set not found")
+		return
+	}
+
+	items := make([]privy.ConditionSetItem, 0)
+	for _, item := range s.csItems[csID] {
+		items = append(items, *item)
+	}
+
+	resp := privy.PaginatedResponse[privy.ConditionSetItem]{
+		Data: items,
+	}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleGetConditionSetItem(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	csID := parts[len(parts)-3]
+	itemID := parts[len(parts)-1]
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.conditionSets[csID]; !exists {
+		s.writeError(w, http.StatusNotFound, "Condition set not found")
+		return
+	}
+
+	item, exists := s.csItems[csID][itemID]
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Item not found")
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, item)
+}
+
+func (s *Server) handleReplaceConditionSetItems(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	csID := parts[len(parts)-2]
+
+	var req privy.ReplaceConditionSetItemsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.conditionSets[csID]; !exists {
+		s.writeError(w, http.StatusNotFound, "Condition set not found")
+		return
+	}
+
+	// Clear existing items
+	s.csItems[csID] = make(map[string]*privy.ConditionSetItem)
+
+	items := make([]privy.ConditionSetItem, len(req.Items))
+	for i, item := range req.Items {
+		s.itemCounter++
+		itemID := fmt.Sprintf("item-%d", s.itemCounter)
+		csItem := &privy.ConditionSetItem{
+			ID:    itemID,
+			Value: item.Value,
+		}
+		s.csItems[csID][itemID] = csItem
+		items[i] = *csItem
+	}
+
+	s.writeJSON(w, http.StatusOK, items)
+}
+
+func (s *Server) handleDeleteConditionSetItem(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	csID := parts[len(parts)-3]
+	itemID := parts[len(parts)-1]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.conditionSets[csID]; !exists {
+		s.writeError(w, http.StatusNotFound, "Condition set not found")
+		return
+	}
+
+	if _, exists := s.csItems[csID][itemID]; !exists {
+		s.writeError(w, http.StatusNotFound, "Item not found")
+		return
+	}
+
+	delete(s.csItems[csID], itemID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Key Quorum handlers
+func (s *Server) handleCreateKeyQuorum(w http.ResponseWriter, r *http.Request) {
+	var req privy.CreateKeyQuorumRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.kqCounter++
+	kqID := fmt.Sprintf("kq-%d", s.kqCounter)
+
+	kq := &privy.KeyQuorum{
+		ID:                     kqID,
+		PublicKey:              req.PublicKey,
+		DisplayName:            req.DisplayName,
+		AuthorizationThreshold: req.AuthorizationThreshold,
+		CreatedAt:              time.Now().UnixMilli(),
+	}
+	for _, pk := range req.PublicKeys {
+		kq.AuthorizationKeys = append(kq.AuthorizationKeys, privy.KeyQuorumAuthorizationKey{PublicKey: pk})
+	}
+
+	s.keyQuorums[kqID] = kq
+	s.writeJSON(w, http.StatusOK, kq)
+}
+
+func (s *Server) handleGetKeyQuorum(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	kqID := parts[len(parts)-1]
+
+	s.mu.RLock()
+	kq, exists := s.keyQuorums[kqID]
+	s.mu.RUnlock()
+
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Key quorum not found")
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, kq)
+}
+
+func (s *Server) handleUpdateKeyQuorum(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	kqID := parts[len(parts)-1]
+
+	var req privy.UpdateKeyQuorumRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kq, exists := s.keyQuorums[kqID]
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "Key quorum not found")
+		return
+	}
+
+	if req.PublicKey != "" {
+		kq.PublicKey = req.PublicKey
+	}
+
+	s.writeJSON(w, http.StatusOK, kq)
+}
+
+func (s *Server) handleDeleteKeyQuorum(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	kqID := parts[len(parts)-1]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.keyQuorums[kqID]; !exists {
+		s.writeError(w, http.StatusNotFound, "Key quorum not found")
+		return
+	}
+
+	delete(s.keyQuorums, kqID)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,43 @@
+package privytest
+
+import (
+	"crypto/sha256"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ripemd160"
+	"golang.org/x/crypto/sha3"
+)
+
+// keccak256 returns the legacy Keccak-256 digest used by Ethereum and Tron.
+func keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// sha3Sum256 returns the FIPS 202 SHA3-256 digest Aptos derives addresses
+// with.
+func sha3Sum256(data ...[]byte) []byte {
+	h := sha3.New256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// blake2b256 returns the unkeyed BLAKE2b-256 digest Sui derives addresses
+// with.
+func blake2b256(data []byte) []byte {
+	sum := blake2b.Sum256(data)
+	return sum[:]
+}
+
+// hash160 is RIPEMD-160(SHA-256(data)), for Bitcoin and Cosmos addresses.
+func hash160(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	h := ripemd160.New()
+	h.Write(sum[:])
+	return h.Sum(nil)
+}
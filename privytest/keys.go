@@ -0,0 +1,191 @@
+package privytest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	starkecdsa "github.com/consensys/gnark-crypto/ecc/stark-curve/ecdsa"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+// keyScheme is the signature scheme a chain type's wallets use.
+type keyScheme int
+
+const (
+	schemeSecp256k1 keyScheme = iota
+	schemeEd25519
+	schemeStark
+)
+
+func schemeFor(chainType privy.ChainType) keyScheme {
+	switch chainType {
+	case privy.ChainTypeSolana, privy.ChainTypeStellar, privy.ChainTypeSui, privy.ChainTypeNear,
+		privy.ChainTypeTon, privy.ChainTypeAptos, privy.ChainTypeMovement:
+		return schemeEd25519
+	case privy.ChainTypeStarknet:
+		return schemeStark
+	default:
+		return schemeSecp256k1
+	}
+}
+
+// walletKey is the private key the server holds for a wallet. Exactly one
+// of the keys is set, depending on scheme.
+type walletKey struct {
+	scheme keyScheme
+	secp   *secp256k1.PrivateKey
+	stark  *starkecdsa.PrivateKey
+	ed     ed25519.PrivateKey
+}
+
+func newWalletKey(chainType privy.ChainType) *walletKey {
+	k := &walletKey{scheme: schemeFor(chainType)}
+	var err error
+	switch k.scheme {
+	case schemeEd25519:
+		_, k.ed, err = ed25519.GenerateKey(rand.Reader)
+	case schemeStark:
+		k.stark, err = starkecdsa.GenerateKey(rand.Reader)
+	default:
+		k.secp, err = secp256k1.GeneratePrivateKey()
+	}
+	if err != nil {
+		panic("privytest: generate key: " + err.Error())
+	}
+	return k
+}
+
+// publicKey returns the public key as Privy reports it: compressed SEC1 for
+// secp256k1, the raw 32 bytes for Ed25519 and the x coordinate for Stark.
+func (k *walletKey) publicKey() []byte {
+	switch k.scheme {
+	case schemeEd25519:
+		return append([]byte(nil), k.ed.Public().(ed25519.PublicKey)...)
+	case schemeStark:
+		x := k.stark.PublicKey.A.X.Bytes()
+		return x[:]
+	default:
+		return k.secp.PubKey().SerializeCompressed()
+	}
+}
+
+func (k *walletKey) privateKeyHex() string {
+	switch k.scheme {
+	case schemeEd25519:
+		return "0x" + hex.EncodeToString(k.ed.Seed())
+	case schemeStark:
+		b := k.stark.Bytes() // public key ‖ scalar
+		return "0x" + hex.EncodeToString(b[len(b)-fr.Bytes:])
+	default:
+		return "0x" + hex.EncodeToString(k.secp.Serialize())
+	}
+}
+
+// address derives the wallet address the chain itself would. TON wallets
+// are v4r2 wallet contracts. StarkNet addresses depend on the deployed
+// account contract, so for them the address is derived from the public key
+// alone.
+func (k *walletKey) address(chainType privy.ChainType) string {
+	pub := k.publicKey()
+	switch chainType {
+	case privy.ChainTypeEthereum:
+		return ethereumAddress(k.secp.PubKey())
+	case privy.ChainTypeTron:
+		hash := keccak256(k.secp.PubKey().SerializeUncompressed()[1:])
+		return base58Check(append([]byte{0x41}, hash[12:]...))
+	case privy.ChainTypeBitcoinSegwit:
+		return segwitV0Address("bc", hash160(pub))
+	case privy.ChainTypeCosmos:
+		return bech32Encode("cosmos", convertBits(hash160(pub)))
+	case privy.ChainTypeSolana:
+		return base58Encode(pub)
+	case privy.ChainTypeStellar:
+		return stellarAddress(pub)
+	case privy.ChainTypeSui:
+		return "0x" + hex.EncodeToString(blake2b256(append([]byte{0x00}, pub...)))
+	case privy.ChainTypeNear:
+		return hex.EncodeToString(pub)
+	case privy.ChainTypeAptos, privy.ChainTypeMovement:
+		return "0x" + hex.EncodeToString(sha3Sum256(pub, []byte{0x00}))
+	case privy.ChainTypeTon:
+		return tonWalletAddress(pub)
+	case privy.ChainTypeStarknet:
+		return "0x" + hex.EncodeToString(pub)
+	default:
+		return "0x" + hex.EncodeToString(pub)
+	}
+}
+
+// ethereumAddress returns the EIP-55 checksummed address of a public key.
+func ethereumAddress(pub *secp256k1.PublicKey) string {
+	addr := hex.EncodeToString(keccak256(pub.SerializeUncompressed()[1:])[12:])
+	hash := hex.EncodeToString(keccak256([]byte(addr)))
+	out := []byte(addr)
+	for i, c := range out {
+		if c >= 'a' && hash[i] >= '8' {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+// signHash signs a digest the way raw_sign does: r‖s‖v with v = 27 or 28 for
+// secp256k1, r‖s for Stark, and a plain Ed25519 signature over the bytes
+// for Ed25519.
+func (k *walletKey) signHash(hash []byte) ([]byte, error) {
+	switch k.scheme {
+	case schemeEd25519:
+		return ed25519.Sign(k.ed, hash), nil
+	case schemeStark:
+		return starkSign(k.stark, hash)
+	default:
+		if len(hash) != 32 {
+			return nil, fmt.Errorf("hash must be 32 bytes, got %d", len(hash))
+		}
+		r, s, recID := secpSign(k.secp, hash)
+		sig := make([]byte, 65)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:64])
+		sig[64] = 27 + recID
+		return sig, nil
+	}
+}
+
+// secpSign signs a 32-byte hash with a low-S ECDSA signature, as Ethereum
+// and Bitcoin require, and returns the recovery ID alongside it.
+func secpSign(key *secp256k1.PrivateKey, hash []byte) (r, s *big.Int, recID byte) {
+	sig := ecdsa.SignCompact(key, hash, false) // 27+recID ‖ r ‖ s
+	return new(big.Int).SetBytes(sig[1:33]), new(big.Int).SetBytes(sig[33:]), sig[0] - 27
+}
+
+// starkSign signs hash as r‖s. StarkNet accounts also require r and s⁻¹ to
+// be below 2^251, so it signs again until they are.
+func starkSign(key *starkecdsa.PrivateKey, hash []byte) ([]byte, error) {
+	for {
+		sig, err := key.Sign(hash, nil)
+		if err != nil {
+			return nil, err
+		}
+		r, s := new(big.Int).SetBytes(sig[:fr.Bytes]), new(big.Int).SetBytes(sig[fr.Bytes:])
+		if w := new(big.Int).ModInverse(s, fr.Modulus()); r.BitLen() <= 251 && w.BitLen() <= 251 {
+			return sig, nil
+		}
+	}
+}
+
+// decodeHex decodes a hex string with or without 0x prefix, allowing an odd
+// number of digits.
+func decodeHex(s string) ([]byte, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	return hex.DecodeString(s)
+}
@@ -0,0 +1,151 @@
+package privytest
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+func TestHashFunctions(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []byte
+		want string
+	}{
+		{"keccak256", keccak256(nil), "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"keccak256 long", keccak256(bytes.Repeat([]byte("a"), 200)), hex.EncodeToString(keccak256(bytes.Repeat([]byte("a"), 100), bytes.Repeat([]byte("a"), 100)))},
+		{"sha3-256", sha3Sum256([]byte("abc")), "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532"},
+		{"hash160", hash160([]byte("abc")), "bb1be98c142444d7a56aa3981c3942a978e4dc33"},
+		{"blake2b-256", blake2b256([]byte("abc")), "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319"},
+		{"blake2b-256 empty", blake2b256(nil), "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8"},
+	}
+	for _, tt := range tests {
+		if got := hex.EncodeToString(tt.got); got != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAddresses(t *testing.T) {
+	one := &walletKey{scheme: schemeSecp256k1, secp: secp256k1.PrivKeyFromBytes([]byte{1})}
+	if got := one.address(privy.ChainTypeEthereum); got != "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf" {
+		t.Errorf("ethereum address = %s", got)
+	}
+	if got := one.address(privy.ChainTypeBitcoinSegwit); got != "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4" {
+		t.Errorf("bitcoin address = %s", got)
+	}
+	if got := one.address(privy.ChainTypeTron); got != "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC" {
+		t.Errorf("tron address = %s", got)
+	}
+}
+
+// recoverSecp256k1 recovers the public key from an r‖s‖v signature.
+func recoverSecp256k1(t *testing.T, hash, sig []byte) *secp256k1.PublicKey {
+	t.Helper()
+	compact := append([]byte{sig[64]}, sig[:64]...)
+	pub, _, err := ecdsa.RecoverCompact(compact, hash)
+	if err != nil {
+		t.Fatalf("recover public key: %v", err)
+	}
+	return pub
+}
+
+func TestSignHash(t *testing.T) {
+	hash := keccak256([]byte("privytest"))
+
+	secp := newWalletKey(privy.ChainTypeEthereum)
+	sig, err := secp.signHash(hash)
+	if err != nil {
+		t.Fatalf("secp256k1 sign failed: %v", err)
+	}
+	if s := new(big.Int).SetBytes(sig[32:64]); s.Cmp(new(big.Int).Rsh(secp256k1.Params().N, 1)) > 0 {
+		t.Error("expected low-S signature")
+	}
+	if !recoverSecp256k1(t, hash, sig).IsEqual(secp.secp.PubKey()) {
+		t.Error("recovery ID does not recover the wallet key")
+	}
+
+	stark := newWalletKey(privy.ChainTypeStarknet)
+	starkHash := new(big.Int).Rsh(new(big.Int).SetBytes(hash), 6).Bytes()
+	sig, err = stark.signHash(starkHash)
+	if err != nil {
+		t.Fatalf("stark sign failed: %v", err)
+	}
+	if ok, err := stark.stark.PublicKey.Verify(sig, starkHash, nil); !ok || err != nil {
+		t.Errorf("stark signature does not verify: %v", err)
+	}
+	if r := new(big.Int).SetBytes(sig[:32]); r.BitLen() > 251 {
+		t.Error("expected r below 2^251")
+	}
+
+	ed := newWalletKey(privy.ChainTypeNear)
+	sig, _ = ed.signHash(hash)
+	if !ed25519.Verify(ed.publicKey(), hash, sig) {
+		t.Error("ed25519 signature does not verify")
+	}
+}
+
+func TestSignEthereumTransaction_EIP155(t *testing.T) {
+	// Example from EIP-155.
+	d, _ := hex.DecodeString(strings.Repeat("46", 32))
+	key := &walletKey{scheme: schemeSecp256k1, secp: secp256k1.PrivKeyFromBytes(d)}
+
+	raw, err := signEthereumTransaction(key, &privy.EthereumTransaction{
+		To:       "0x" + strings.Repeat("35", 20),
+		Value:    "1000000000000000000",
+		GasLimit: "21000",
+		GasPrice: "20000000000",
+		Nonce:    9,
+	}, 1)
+	if err != nil {
+		t.Fatalf("sign failed: %v", err)
+	}
+
+	signingData, _ := hex.DecodeString("ec098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a764000080018080")
+	sigHash := keccak256(signingData)
+	if hex.EncodeToString(sigHash) != "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53" {
+		t.Fatalf("unexpected signing hash %x", sigHash)
+	}
+
+	fields := raw[2+len(signingData)-4:] // skip list header and unsigned fields
+	v := fields[0]
+	if v != 0x25 && v != 0x26 {
+		t.Fatalf("expected EIP-155 v of 37 or 38, got %d", v)
+	}
+	r, rest := rlpString(fields[1:])
+	s, _ := rlpString(rest)
+	sig := append(append(make([]byte, 32-len(r), 65), r...), append(make([]byte, 32-len(s)), s...)...)
+	sig = append(sig, v-37+27)
+	if !recoverSecp256k1(t, sigHash, sig).IsEqual(key.secp.PubKey()) {
+		t.Error("signed transaction does not recover the signing key")
+	}
+}
+
+func rlpString(b []byte) ([]byte, []byte) {
+	n := int(b[0] - 0x80)
+	return b[1 : 1+n], b[1+n:]
+}
+
+func TestExportReturnsSigningKey(t *testing.T) {
+	srv, client := NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	wallet, _ := client.Wallets().Create(ctx, &privy.CreateWalletRequest{ChainType: privy.ChainTypeEthereum})
+	exported, err := client.Wallets().Export(ctx, wallet.ID, "")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	d, _ := hex.DecodeString(strings.TrimPrefix(exported.PrivateKey, "0x"))
+	if ethereumAddress(secp256k1.PrivKeyFromBytes(d).PubKey()) != wallet.Address {
+		t.Errorf("exported key does not match wallet address %s", wallet.Address)
+	}
+}
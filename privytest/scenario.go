@@ -0,0 +1,135 @@
+package privytest
+
+import (
+	"net/http"
+	"path"
+	"time"
+)
+
+// Matcher selects requests for scenario hooks and request inspection.
+type Matcher struct {
+	method  string
+	pattern string
+}
+
+// AnyRequest matches every request.
+var AnyRequest = Matcher{}
+
+// Route matches requests by HTTP method and path pattern. An empty method
+// matches any method. The pattern uses path.Match syntax, so "*" matches a
+// single segment:
+//
+//	privytest.Route("POST", "/v1/wallets/*/raw_sign")
+func Route(method, pattern string) Matcher {
+	return Matcher{method: method, pattern: pattern}
+}
+
+func (m Matcher) matches(method, urlPath string) bool {
+	if m.method != "" && m.method != method {
+		return false
+	}
+	if m.pattern == "" {
+		return true
+	}
+	ok, _ := path.Match(m.pattern, urlPath)
+	return ok
+}
+
+// Injection is a scenario hook registered with InjectError or InjectLatency.
+// It applies to every matching request until limited with Times.
+type Injection struct {
+	server    *Server
+	match     Matcher
+	status    int
+	message   string
+	header    http.Header
+	delay     time.Duration
+	remaining int
+}
+
+// InjectError makes matching requests fail with status and message instead
+// of reaching the fake API.
+func (s *Server) InjectError(match Matcher, status int, message string) *Injection {
+	return s.inject(&Injection{match: match, status: status, message: message})
+}
+
+// InjectLatency delays matching requests by d before they are handled.
+func (s *Server) InjectLatency(match Matcher, d time.Duration) *Injection {
+	return s.inject(&Injection{match: match, delay: d})
+}
+
+// ClearInjections removes all scenario hooks.
+func (s *Server) ClearInjections() {
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	s.injections = nil
+}
+
+func (s *Server) inject(inj *Injection) *Injection {
+	inj.server = s
+	inj.remaining = -1
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	s.injections = append(s.injections, inj)
+	return inj
+}
+
+// Times limits the hook to the next n matching requests.
+func (i *Injection) Times(n int) *Injection {
+	i.server.hooksMu.Lock()
+	defer i.server.hooksMu.Unlock()
+	i.remaining = n
+	return i
+}
+
+// WithHeader sets a response header on injected errors, e.g. Retry-After.
+func (i *Injection) WithHeader(key, value string) *Injection {
+	i.server.hooksMu.Lock()
+	defer i.server.hooksMu.Unlock()
+	if i.header == nil {
+		i.header = http.Header{}
+	}
+	i.header.Set(key, value)
+	return i
+}
+
+// takeInjections returns the hooks that apply to a request and consumes one
+// use of each. The caller must hold hooksMu.
+func (s *Server) takeInjections(method, urlPath string) []*Injection {
+	var matched []*Injection
+	active := s.injections[:0]
+	for _, inj := range s.injections {
+		if inj.remaining != 0 && inj.match.matches(method, urlPath) {
+			matched = append(matched, inj)
+			if inj.remaining > 0 {
+				inj.remaining--
+			}
+		}
+		if inj.remaining != 0 {
+			active = append(active, inj)
+		}
+	}
+	s.injections = active
+	return matched
+}
+
+// apply runs the hook and reports whether the request should continue.
+func (i *Injection) apply(w http.ResponseWriter, r *http.Request) bool {
+	if i.delay > 0 {
+		timer := time.NewTimer(i.delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			return false
+		}
+	}
+	if i.status == 0 {
+		return true
+	}
+	for key, values := range i.header {
+		w.Header()[key] = values
+	}
+	i.server.writeError(w, i.status, i.message)
+	return false
+}
@@ -0,0 +1,361 @@
+// Package privytest provides an in-memory fake of the Privy API for tests.
+//
+// NewServer starts the fake and returns a client configured to use it:
+//
+//	srv, client := privytest.NewServer()
+//	defer srv.Close()
+//
+//	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{
+//		ChainType: privy.ChainTypeEthereum,
+//	})
+//
+// The fake keeps users, wallets, policies, condition sets, key quorums and
+// transactions in memory. Scenario hooks inject errors and latency, and every
+// request is recorded for inspection.
+//
+// Each wallet holds a real secp256k1, Ed25519 or Stark key, chosen by chain
+// type, and its address and public key are derived from it. raw_sign,
+// personal_sign, secp256k1_sign, eth_signTransaction, eth_sendTransaction and
+// the Solana signMessage, signTransaction and signAndSendTransaction methods
+// return signatures that verify against that key, so chain helpers can be
+// tested end to end. Typed data, user operation and Spark responses remain
+// placeholders.
+package privytest
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+// Credentials used by the client returned from NewServer.
+const (
+	AppID     = "test-app-id"
+	AppSecret = "test-app-secret"
+)
+
+// Server is a running fake Privy API.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.RWMutex
+	users         map[string]*privy.User
+	wallets       map[string]*privy.Wallet
+	policies      map[string]*privy.Policy
+	conditionSets map[string]*privy.ConditionSet
+	keyQuorums    map[string]*privy.KeyQuorum
+	transactions  map[string]*privy.Transaction
+	csItems       map[string]map[string]*privy.ConditionSetItem // conditionSetID -> itemID -> item
+	keys          map[string]*walletKey                         // walletID -> signing key
+
+	userCounter   int
+	walletCounter int
+	policyCounter int
+	csCounter     int
+	kqCounter     int
+	txCounter     int
+	itemCounter   int
+
+	hooksMu    sync.Mutex
+	injections []*Injection
+	requests   []*RecordedRequest
+}
+
+// Option configures a Server.
+type Option func(*config)
+
+type config struct {
+	clientOptions []privy.ClientOption
+}
+
+// WithClientOptions adds options to the client returned by NewServer. They
+// are applied after the base and auth URLs, so they can override them.
+func WithClientOptions(opts ...privy.ClientOption) Option {
+	return func(c *config) {
+		c.clientOptions = append(c.clientOptions, opts...)
+	}
+}
+
+// NewServer starts a fake Privy API and returns it with a client pointed at
+// it. Call Close on the server when done.
+func NewServer(opts ...Option) (*Server, *privy.Client) {
+	cfg := config{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	s := &Server{
+		users:         make(map[string]*privy.User),
+		wallets:       make(map[string]*privy.Wallet),
+		policies:      make(map[string]*privy.Policy),
+		conditionSets: make(map[string]*privy.ConditionSet),
+		keyQuorums:    make(map[string]*privy.KeyQuorum),
+		transactions:  make(map[string]*privy.Transaction),
+		csItems:       make(map[string]map[string]*privy.ConditionSetItem),
+		keys:          make(map[string]*walletKey),
+	}
+	s.Server = httptest.NewServer(s)
+
+	clientOpts := append([]privy.ClientOption{
+		privy.WithBaseURL(s.URL + "/v1"),
+		privy.WithAuthURL(s.URL + "/api/v1"),
+	}, cfg.clientOptions...)
+	return s, privy.NewClient(AppID, AppSecret, clientOpts...)
+}
+
+// RecordedRequest is a request received by the server.
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+	Time   time.Time
+}
+
+// Requests returns the requests received so far, oldest first.
+func (s *Server) Requests() []*RecordedRequest {
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	return append([]*RecordedRequest(nil), s.requests...)
+}
+
+// RequestsMatching returns the received requests selected by match.
+func (s *Server) RequestsMatching(match Matcher) []*RecordedRequest {
+	var matched []*RecordedRequest
+	for _, req := range s.Requests() {
+		if match.matches(req.Method, req.Path) {
+			matched = append(matched, req)
+		}
+	}
+	return matched
+}
+
+// LastRequest returns the most recent request, or nil if there was none.
+func (s *Server) LastRequest() *RecordedRequest {
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	if len(s.requests) == 0 {
+		return nil
+	}
+	return s.requests[len(s.requests)-1]
+}
+
+// ClearRequests discards the recorded requests.
+func (s *Server) ClearRequests() {
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	s.requests = nil
+}
+
+// AddUser stores a user, replacing any with the same ID.
+func (s *Server) AddUser(user *privy.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[user.ID] = user
+}
+
+// AddWallet stores a wallet, replacing any with the same ID, and generates
+// a signing key for it. An empty address or public key is filled in from
+// the key.
+func (s *Server) AddWallet(wallet *privy.Wallet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := newWalletKey(wallet.ChainType)
+	if wallet.Address == "" {
+		wallet.Address = key.address(wallet.ChainType)
+	}
+	if wallet.PublicKey == "" {
+		wallet.PublicKey = "0x" + hex.EncodeToString(key.publicKey())
+	}
+	s.wallets[wallet.ID] = wallet
+	s.keys[wallet.ID] = key
+}
+
+// newWallet creates and stores a wallet with a fresh key. The caller must
+// hold mu.
+func (s *Server) newWallet(chainType privy.ChainType) *privy.Wallet {
+	s.walletCounter++
+	key := newWalletKey(chainType)
+	wallet := &privy.Wallet{
+		ID:        fmt.Sprintf("wallet-%d", s.walletCounter),
+		Address:   key.address(chainType),
+		PublicKey: "0x" + hex.EncodeToString(key.publicKey()),
+		ChainType: chainType,
+		CreatedAt: time.Now().UnixMilli(),
+	}
+	s.wallets[wallet.ID] = wallet
+	s.keys[wallet.ID] = key
+	return wallet
+}
+
+// AddTransaction stores a transaction, replacing any with the same ID.
+func (s *Server) AddTransaction(tx *privy.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transactions[tx.ID] = tx
+}
+
+// Transactions returns the stored transactions, including those created by
+// eth_sendTransaction and signAndSendTransaction.
+func (s *Server) Transactions() []*privy.Transaction {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	txs := make([]*privy.Transaction, 0, len(s.transactions))
+	for _, tx := range s.transactions {
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+// ServeHTTP records the request, applies scenario hooks and then handles it
+// like the Privy API would.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	s.hooksMu.Lock()
+	s.requests = append(s.requests, &RecordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+		Time:   time.Now(),
+	})
+	injections := s.takeInjections(r.Method, r.URL.Path)
+	s.hooksMu.Unlock()
+
+	for _, inj := range injections {
+		if !inj.apply(w, r) {
+			return
+		}
+	}
+
+	// Validate authentication headers
+	if r.Header.Get("Authorization") == "" {
+		s.writeError(w, http.StatusUnauthorized, "Missing Authorization header")
+		return
+	}
+	if r.Header.Get("privy-app-id") == "" {
+		s.writeError(w, http.StatusUnauthorized, "Missing privy-app-id header")
+		return
+	}
+
+	s.route(w, r)
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+
+	path := r.URL.Path
+	method := r.Method
+
+	// Route requests
+	switch {
+	// Users endpoints
+	case path == "/v1/users" && method == "POST":
+		s.handleCreateUser(w, r)
+	case path == "/api/v1/users" && method == "GET":
+		s.handleListUsers(w, r)
+	case path == "/api/v1/users/email/address" && method == "POST":
+		s.handleGetUserByEmail(w, r)
+	case path == "/api/v1/users/phone/number" && method == "POST":
+		s.handleGetUserByPhone(w, r)
+	case path == "/api/v1/users/wallet/address" && method == "POST":
+		s.handleGetUserByWallet(w, r)
+	case strings.HasPrefix(path, "/api/v1/users/") && strings.HasSuffix(path, "/custom_metadata") && method == "POST":
+		s.handleUpdateUserMetadata(w, r)
+	case strings.HasPrefix(path, "/api/v1/users/") && method == "DELETE":
+		s.handleDeleteUser(w, r)
+	case strings.HasPrefix(path, "/api/v1/users/") && method == "GET":
+		s.handleGetUser(w, r)
+
+	// Wallets endpoints
+	case path == "/v1/wallets" && method == "POST":
+		s.handleCreateWallet(w, r)
+	case path == "/v1/wallets" && method == "GET":
+		s.handleListWallets(w, r)
+	case strings.HasPrefix(path, "/v1/wallets/") && strings.HasSuffix(path, "/raw_sign") && method == "POST":
+		s.handleWalletRawSign(w, r)
+	case strings.HasPrefix(path, "/v1/wallets/") && strings.HasSuffix(path, "/rpc") && method == "POST":
+		s.handleWalletRPC(w, r)
+	case strings.HasPrefix(path, "/v1/wallets/") && strings.HasSuffix(path, "/balance") && method == "GET":
+		s.handleGetWalletBalance(w, r)
+	case strings.HasPrefix(path, "/v1/wallets/") && strings.HasSuffix(path, "/transactions") && method == "GET":
+		s.handleGetWalletTransactions(w, r)
+	case strings.HasPrefix(path, "/v1/wallets/") && strings.HasSuffix(path, "/export") && method == "POST":
+		s.handleExportWallet(w, r)
+	case strings.HasPrefix(path, "/v1/wallets/") && method == "GET":
+		s.handleGetWallet(w, r)
+	case strings.HasPrefix(path, "/v1/wallets/") && method == "PATCH":
+		s.handleUpdateWallet(w, r)
+	case path == "/v1/wallets/import/initialize" && method == "POST":
+		s.handleInitializeImport(w, r)
+	case path == "/v1/wallets/import/submit" && method == "POST":
+		s.handleSubmitImport(w, r)
+
+	// Transactions endpoints
+	case strings.HasPrefix(path, "/v1/transactions/") && method == "GET":
+		s.handleGetTransaction(w, r)
+
+	// Policies endpoints
+	case path == "/v1/policies" && method == "POST":
+		s.handleCreatePolicy(w, r)
+	case strings.HasPrefix(path, "/v1/policies/") && strings.Contains(path, "/rules/") && method == "GET":
+		s.handleGetPolicyRule(w, r)
+	case strings.HasPrefix(path, "/v1/policies/") && strings.Contains(path, "/rules/") && method == "PATCH":
+		s.handleUpdatePolicyRule(w, r)
+	case strings.HasPrefix(path, "/v1/policies/") && strings.Contains(path, "/rules/") && method == "DELETE":
+		s.handleDeletePolicyRule(w, r)
+	case strings.HasPrefix(path, "/v1/policies/") && strings.HasSuffix(path, "/rules") && method == "POST":
+		s.handleAddPolicyRule(w, r)
+	case strings.HasPrefix(path, "/v1/policies/") && method == "GET":
+		s.handleGetPolicy(w, r)
+	case strings.HasPrefix(path, "/v1/policies/") && method == "PATCH":
+		s.handleUpdatePolicy(w, r)
+	case strings.HasPrefix(path, "/v1/policies/") && method == "DELETE":
+		s.handleDeletePolicy(w, r)
+
+	// Condition Sets endpoints
+	case path == "/v1/condition-sets" && method == "POST":
+		s.handleCreateConditionSet(w, r)
+	case strings.HasPrefix(path, "/v1/condition-sets/") && strings.Contains(path, "/items/") && method == "GET":
+		s.handleGetConditionSetItem(w, r)
+	case strings.HasPrefix(path, "/v1/condition-sets/") && strings.Contains(path, "/items/") && method == "DELETE":
+		s.handleDeleteConditionSetItem(w, r)
+	case strings.HasPrefix(path, "/v1/condition-sets/") && strings.HasSuffix(path, "/items") && method == "POST":
+		s.handleAddConditionSetItems(w, r)
+	case strings.HasPrefix(path, "/v1/condition-sets/") && strings.HasSuffix(path, "/items") && method == "GET":
+		s.handleListConditionSetItems(w, r)
+	case strings.HasPrefix(path, "/v1/condition-sets/") && strings.HasSuffix(path, "/items") && method == "PATCH":
+		s.handleReplaceConditionSetItems(w, r)
+	case strings.HasPrefix(path, "/v1/condition-sets/") && method == "GET":
+		s.handleGetConditionSet(w, r)
+	case strings.HasPrefix(path, "/v1/condition-sets/") && method == "PATCH":
+		s.handleUpdateConditionSet(w, r)
+	case strings.HasPrefix(path, "/v1/condition-sets/") && method == "DELETE":
+		s.handleDeleteConditionSet(w, r)
+
+	// Key Quorums endpoints
+	case path == "/v1/key-quorums" && method == "POST":
+		s.handleCreateKeyQuorum(w, r)
+	case strings.HasPrefix(path, "/v1/key-quorums/") && method == "GET":
+		s.handleGetKeyQuorum(w, r)
+	case strings.HasPrefix(path, "/v1/key-quorums/") && method == "PATCH":
+		s.handleUpdateKeyQuorum(w, r)
+	case strings.HasPrefix(path, "/v1/key-quorums/") && method == "DELETE":
+		s.handleDeleteKeyQuorum(w, r)
+
+	default:
+		s.writeError(w, http.StatusNotFound, fmt.Sprintf("Unknown endpoint: %s %s", method, path))
+	}
+}
@@ -0,0 +1,95 @@
+package privytest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+func TestNewServer(t *testing.T) {
+	srv, client := NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{ChainType: privy.ChainTypeEthereum})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	got, err := client.Wallets().Get(ctx, wallet.ID)
+	if err != nil || got.Address != wallet.Address {
+		t.Fatalf("expected to get the created wallet, got %+v / %v", got, err)
+	}
+
+	reqs := srv.Requests()
+	if len(reqs) != 2 {
+		t.Fatalf("expected 2 recorded requests, got %d", len(reqs))
+	}
+	var body privy.CreateWalletRequest
+	if err := json.Unmarshal(reqs[0].Body, &body); err != nil || body.ChainType != privy.ChainTypeEthereum {
+		t.Errorf("expected recorded create body, got %s", reqs[0].Body)
+	}
+	if reqs[0].Header.Get("privy-app-id") != AppID {
+		t.Errorf("expected app ID header, got %q", reqs[0].Header.Get("privy-app-id"))
+	}
+	if last := srv.LastRequest(); last.Method != "GET" || last.Path != "/v1/wallets/"+wallet.ID {
+		t.Errorf("unexpected last request %s %s", last.Method, last.Path)
+	}
+
+	srv.ClearRequests()
+	if srv.LastRequest() != nil {
+		t.Error("expected no requests after ClearRequests")
+	}
+}
+
+func TestInjectError(t *testing.T) {
+	srv, client := NewServer(WithClientOptions(
+		privy.WithRetryPolicy(privy.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}),
+	))
+	defer srv.Close()
+
+	ctx := context.Background()
+	wallet, err := client.Wallets().Create(ctx, &privy.CreateWalletRequest{ChainType: privy.ChainTypeSolana})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	rawSign := Route("POST", "/v1/wallets/*/raw_sign")
+	srv.InjectError(rawSign, http.StatusServiceUnavailable, "unavailable").Times(2)
+	if _, err := client.RawSign(privy.ContextWithIdempotencyKey(ctx, "sign-1"), wallet.ID, "0x1234"); err != nil {
+		t.Fatalf("expected RawSign to succeed after retries, got %v", err)
+	}
+	if n := len(srv.RequestsMatching(rawSign)); n != 3 {
+		t.Errorf("expected 3 raw_sign attempts, got %d", n)
+	}
+
+	srv.InjectError(Route("GET", ""), http.StatusTooManyRequests, "slow down").WithHeader("Retry-After", "0").Times(4)
+	_, err = client.Wallets().Get(ctx, wallet.ID)
+	if !privy.IsRateLimited(err) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+
+	srv.InjectError(AnyRequest, http.StatusForbidden, "blocked")
+	srv.ClearInjections()
+	if _, err := client.Wallets().Get(ctx, wallet.ID); err != nil {
+		t.Errorf("expected no injected errors after ClearInjections, got %v", err)
+	}
+}
+
+func TestInjectLatency(t *testing.T) {
+	srv, client := NewServer()
+	defer srv.Close()
+
+	srv.InjectLatency(AnyRequest, time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.Wallets().Get(ctx, "wallet-1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package privytest
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	privy "github.com/vadimzhukck/privy-sdk-go"
+)
+
+// rawSign handles POST /wallets/{id}/raw_sign for either a precomputed hash
+// or bytes plus a hash function.
+func rawSign(key *walletKey, hashParams privy.RawSignHashParams, bytesParams privy.RawSignBytesParams) ([]byte, error) {
+	if hashParams.Hash != "" {
+		hash, err := decodeHex(hashParams.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hash: %w", err)
+		}
+		return key.signHash(hash)
+	}
+
+	data, err := decodeMessage(bytesParams.Bytes, bytesParams.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	var hash []byte
+	switch bytesParams.HashFunction {
+	case "keccak256":
+		hash = keccak256(data)
+	case "sha256":
+		sum := sha256.Sum256(data)
+		hash = sum[:]
+	case "blake2b256":
+		hash = blake2b256(data)
+	default:
+		return nil, fmt.Errorf("unsupported hash_function %q", bytesParams.HashFunction)
+	}
+	return key.signHash(hash)
+}
+
+// signRPC handles the wallet RPC methods that sign without broadcasting.
+func signRPC(key *walletKey, method string, params json.RawMessage, resp *privy.SignatureResponse) error {
+	switch method {
+	case "personal_sign":
+		var p privy.SignMessageRequest
+		if err := json.Unmarshal(params, &p); err != nil {
+			return fmt.Errorf("invalid params: %w", err)
+		}
+		if key.scheme != schemeSecp256k1 {
+			return fmt.Errorf("personal_sign requires an Ethereum wallet")
+		}
+		message, err := decodeMessage(p.Message, p.Encoding)
+		if err != nil {
+			return err
+		}
+		sig, err := personalSign(key, message)
+		if err != nil {
+			return err
+		}
+		resp.Data.Signature = "0x" + hex.EncodeToString(sig)
+		resp.Data.Encoding = "hex"
+
+	case "signMessage":
+		var p privy.SolanaSignMessageRequest
+		if err := json.Unmarshal(params, &p); err != nil {
+			return fmt.Errorf("invalid params: %w", err)
+		}
+		if key.scheme != schemeEd25519 {
+			return fmt.Errorf("signMessage requires a Solana wallet")
+		}
+		message, err := decodeMessage(p.Message, p.Encoding)
+		if err != nil {
+			return err
+		}
+		resp.Data.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key.ed, message))
+		resp.Data.Encoding = "base64"
+
+	case "eth_signTransaction":
+		var p privy.SignTransactionRequest
+		if err := json.Unmarshal(params, &p); err != nil || p.Transaction == nil {
+			return fmt.Errorf("invalid params: missing transaction")
+		}
+		raw, err := signEthereumTransaction(key, p.Transaction, 0)
+		if err != nil {
+			return err
+		}
+		resp.Data.SignedTransaction = "0x" + hex.EncodeToString(raw)
+		resp.Data.Encoding = "rlp"
+
+	case "signTransaction":
+		var p privy.SolanaSignTransactionRequest
+		if err := json.Unmarshal(params, &p); err != nil {
+			return fmt.Errorf("invalid params: %w", err)
+		}
+		tx, err := base64.StdEncoding.DecodeString(p.Transaction)
+		if err != nil {
+			return fmt.Errorf("invalid transaction encoding: %w", err)
+		}
+		signed, err := signSolanaTransaction(key, tx)
+		if err != nil {
+			return err
+		}
+		resp.Data.SignedTransaction = base64.StdEncoding.EncodeToString(signed)
+		resp.Data.Encoding = "base64"
+
+	case "secp256k1_sign", "raw_sign":
+		var p privy.SignHashRequest
+		if err := json.Unmarshal(params, &p); err != nil {
+			return fmt.Errorf("invalid params: %w", err)
+		}
+		hash, err := decodeHex(p.Hash)
+		if err != nil {
+			return fmt.Errorf("invalid hash: %w", err)
+		}
+		sig, err := key.signHash(hash)
+		if err != nil {
+			return err
+		}
+		resp.Data.Signature = "0x" + hex.EncodeToString(sig)
+		resp.Data.Encoding = "hex"
+	}
+	return nil
+}
+
+// sendRPC signs a transaction for eth_sendTransaction or
+// signAndSendTransaction and returns the hash the network would assign it.
+func sendRPC(key *walletKey, method, caip2 string, params json.RawMessage) (string, error) {
+	if method == "eth_sendTransaction" {
+		var p privy.SendTransactionRequest
+		if err := json.Unmarshal(params, &p); err != nil || p.Transaction == nil {
+			return "", fmt.Errorf("invalid params: missing transaction")
+		}
+		chainID, _ := strconv.ParseInt(strings.TrimPrefix(caip2, "eip155:"), 10, 64)
+		raw, err := signEthereumTransaction(key, p.Transaction, chainID)
+		if err != nil {
+			return "", err
+		}
+		return "0x" + hex.EncodeToString(keccak256(raw)), nil
+	}
+
+	var p privy.SolanaSignAndSendTransactionRequest
+	if err := json.Unmarshal(params, &p); err != nil {
+		return "", fmt.Errorf("invalid params: %w", err)
+	}
+	tx, err := base64.StdEncoding.DecodeString(p.Transaction)
+	if err != nil {
+		return "", fmt.Errorf("invalid transaction encoding: %w", err)
+	}
+	signed, err := signSolanaTransaction(key, tx)
+	if err != nil {
+		return "", err
+	}
+	// The transaction ID is the first signature.
+	_, size, _ := readCompactU16(signed)
+	return base58Encode(signed[size : size+ed25519.SignatureSize]), nil
+}
+
+// decodeMessage decodes a message in the encodings Privy accepts.
+func decodeMessage(message, encoding string) ([]byte, error) {
+	switch encoding {
+	case "", "utf-8", "utf8":
+		return []byte(message), nil
+	case "hex":
+		b, err := decodeHex(message)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex message: %w", err)
+		}
+		return b, nil
+	case "base64":
+		b, err := base64.StdEncoding.DecodeString(message)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 message: %w", err)
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q", encoding)
+	}
+}
@@ -0,0 +1,68 @@
+package privytest
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"fmt"
+)
+
+// readCompactU16 decodes Solana's compact-u16 length prefix.
+func readCompactU16(b []byte) (n int, size int, err error) {
+	for size < 3 {
+		if size >= len(b) {
+			return 0, 0, fmt.Errorf("truncated length prefix")
+		}
+		v := b[size]
+		n |= int(v&0x7f) << (7 * size)
+		size++
+		if v&0x80 == 0 {
+			return n, size, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("invalid length prefix")
+}
+
+// signSolanaTransaction fills in the wallet's signature on a serialized
+// legacy or versioned transaction.
+func signSolanaTransaction(key *walletKey, tx []byte) ([]byte, error) {
+	if key.scheme != schemeEd25519 {
+		return nil, fmt.Errorf("wallet cannot sign Solana transactions")
+	}
+	numSigs, size, err := readCompactU16(tx)
+	if err != nil {
+		return nil, fmt.Errorf("signatures: %w", err)
+	}
+	sigStart := size
+	msgStart := sigStart + numSigs*ed25519.SignatureSize
+	if msgStart > len(tx) {
+		return nil, fmt.Errorf("truncated signatures")
+	}
+	message := tx[msgStart:]
+
+	header := message
+	if len(header) > 0 && header[0]&0x80 != 0 {
+		header = header[1:] // versioned message prefix
+	}
+	if len(header) < 3 {
+		return nil, fmt.Errorf("truncated message header")
+	}
+	required := int(header[0])
+	numKeys, size, err := readCompactU16(header[3:])
+	if err != nil {
+		return nil, fmt.Errorf("account keys: %w", err)
+	}
+	keys := header[3+size:]
+	if numKeys < required || len(keys) < numKeys*ed25519.PublicKeySize || numSigs != required {
+		return nil, fmt.Errorf("malformed message")
+	}
+
+	pub := key.publicKey()
+	for i := 0; i < required; i++ {
+		if bytes.Equal(keys[i*ed25519.PublicKeySize:(i+1)*ed25519.PublicKeySize], pub) {
+			signed := append([]byte(nil), tx...)
+			copy(signed[sigStart+i*ed25519.SignatureSize:], ed25519.Sign(key.ed, message))
+			return signed, nil
+		}
+	}
+	return nil, fmt.Errorf("wallet is not a required signer")
+}
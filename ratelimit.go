@@ -0,0 +1,162 @@
+package privy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointClass groups API endpoints that share a rate limit bucket.
+type EndpointClass string
+
+const (
+	// EndpointClassRead covers GET requests and user lookups.
+	EndpointClassRead EndpointClass = "read"
+
+	// EndpointClassSigning covers wallet RPC, raw_sign and key export.
+	EndpointClassSigning EndpointClass = "signing"
+
+	// EndpointClassAdmin covers every other write: creating users and wallets,
+	// managing policies, condition sets and key quorums.
+	EndpointClassAdmin EndpointClass = "admin"
+)
+
+// RateLimit configures a single token bucket.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate. Zero disables the limit.
+	RequestsPerSecond float64
+
+	// Burst is the bucket size. Defaults to RequestsPerSecond rounded up.
+	Burst int
+}
+
+// RateLimits configures client-side throttling per endpoint class.
+type RateLimits struct {
+	Read    RateLimit
+	Signing RateLimit
+	Admin   RateLimit
+}
+
+// WithRateLimits enables client-side token bucket rate limiting. Each endpoint
+// class has its own bucket, shared by every service and chain helper created
+// from the client. Requests wait for a token and fail only if ctx is done first.
+func WithRateLimits(limits RateLimits) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = &rateLimiter{buckets: map[EndpointClass]*tokenBucket{}}
+		for class, limit := range map[EndpointClass]RateLimit{
+			EndpointClassRead:    limits.Read,
+			EndpointClassSigning: limits.Signing,
+			EndpointClassAdmin:   limits.Admin,
+		} {
+			if b := newTokenBucket(limit); b != nil {
+				c.rateLimiter.buckets[class] = b
+			}
+		}
+	}
+}
+
+// rateLimiter dispatches requests to the bucket for their endpoint class.
+type rateLimiter struct {
+	buckets map[EndpointClass]*tokenBucket
+}
+
+// wait blocks until a request of the given method and URL may be sent.
+func (l *rateLimiter) wait(ctx context.Context, method, rawURL string) error {
+	if l == nil {
+		return nil
+	}
+	class := classifyEndpoint(method, rawURL)
+	b, ok := l.buckets[class]
+	if !ok {
+		return nil
+	}
+	if err := b.wait(ctx); err != nil {
+		return fmt.Errorf("privy: waiting for %s rate limit: %w", class, err)
+	}
+	return nil
+}
+
+// tokenBucket is a minimal token bucket that reserves tokens ahead of time,
+// so concurrent waiters are served in arrival order.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full bucket for limit, or nil if limit is disabled.
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = math.Max(1, math.Ceil(limit.RequestsPerSecond))
+	}
+	return &tokenBucket{
+		rate:   limit.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// wait takes a token, sleeping until one is available.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+
+	if err := sleepContext(ctx, delay); err != nil {
+		// Give back the reservation we will not use
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// classifyEndpoint maps a request to its rate limit bucket.
+func classifyEndpoint(method, rawURL string) EndpointClass {
+	path := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		path = u.Path
+	}
+	path = strings.TrimSuffix(path, "/")
+
+	switch {
+	case strings.HasSuffix(path, "/rpc"), strings.HasSuffix(path, "/raw_sign"), strings.HasSuffix(path, "/export"):
+		return EndpointClassSigning
+	case method == http.MethodGet || method == http.MethodHead:
+		return EndpointClassRead
+	case isUserLookup(path):
+		return EndpointClassRead
+	}
+	return EndpointClassAdmin
+}
+
+// isUserLookup reports whether path is a POST /users/{provider}/{field} lookup,
+// such as /users/email/address.
+func isUserLookup(path string) bool {
+	i := strings.LastIndex(path, "/users/")
+	if i < 0 {
+		return false
+	}
+	parts := strings.Split(path[i+len("/users/"):], "/")
+	return len(parts) == 2 && parts[1] != "custom_metadata"
+}
@@ -0,0 +1,90 @@
+package privy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClassifyEndpoint(t *testing.T) {
+	tests := []struct {
+		method string
+		url    string
+		want   EndpointClass
+	}{
+		{"GET", "https://api.privy.io/v1/wallets/w1", EndpointClassRead},
+		{"GET", "https://api.privy.io/v1/users?limit=10", EndpointClassRead},
+		{"POST", "https://auth.privy.io/api/v1/users/email/address", EndpointClassRead},
+		{"POST", "https://auth.privy.io/api/v1/users/telegram/telegram_user_id", EndpointClassRead},
+		{"POST", "https://api.privy.io/v1/wallets/w1/rpc", EndpointClassSigning},
+		{"POST", "https://api.privy.io/v1/wallets/w1/raw_sign", EndpointClassSigning},
+		{"POST", "https://api.privy.io/v1/wallets/w1/export", EndpointClassSigning},
+		{"POST", "https://api.privy.io/v1/wallets", EndpointClassAdmin},
+		{"POST", "https://auth.privy.io/api/v1/users/u1/custom_metadata", EndpointClassAdmin},
+		{"PATCH", "https://api.privy.io/v1/policies/p1", EndpointClassAdmin},
+		{"DELETE", "https://api.privy.io/v1/key-quorums/k1", EndpointClassAdmin},
+	}
+	for _, tt := range tests {
+		if got := classifyEndpoint(tt.method, tt.url); got != tt.want {
+			t.Errorf("classifyEndpoint(%s %s) = %s, want %s", tt.method, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestTokenBucket_Throttles(t *testing.T) {
+	b := newTokenBucket(RateLimit{RequestsPerSecond: 20, Burst: 2})
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := b.wait(context.Background()); err != nil {
+			t.Fatalf("wait failed: %v", err)
+		}
+	}
+	// Two requests fit in the burst, the other two need 50ms each
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected throttling to take ~100ms, took %v", elapsed)
+	}
+}
+
+func TestTokenBucket_Disabled(t *testing.T) {
+	if b := newTokenBucket(RateLimit{}); b != nil {
+		t.Error("expected zero rate to disable the bucket")
+	}
+}
+
+func TestWithRateLimits_SeparateBuckets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"id": "wallet-1", "method": "raw_sign"})
+	}))
+	defer server.Close()
+
+	client := NewClient("app-id", "app-secret",
+		WithBaseURL(server.URL+"/v1"),
+		WithRateLimits(RateLimits{
+			Signing: RateLimit{RequestsPerSecond: 0.1, Burst: 1},
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.RawSign(ctx, "wallet-1", "0x01"); err != nil {
+		t.Fatalf("first RawSign failed: %v", err)
+	}
+
+	// Reads are not limited, so they are unaffected by the empty signing bucket
+	for i := 0; i < 5; i++ {
+		if _, err := client.Wallets().Get(ctx, "wallet-1"); err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+	}
+
+	_, err := client.RawSign(ctx, "wallet-1", "0x02")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected second RawSign to wait for the signing bucket, got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package privy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// idempotencyKeyHeader is the header Privy uses to deduplicate retried writes.
+const idempotencyKeyHeader = "privy-idempotency-key"
+
+// RetryPolicy controls how the client retries failed requests.
+//
+// Requests are retried on 429 and 5xx responses and on transient network
+// errors. Idempotent methods (GET, HEAD, OPTIONS, PUT, DELETE) are always
+// eligible; POST and PATCH are only retried when the request carries an
+// idempotency key, since the first attempt may already have been applied.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	// Zero disables retries.
+	MaxRetries int
+
+	// InitialBackoff is the base delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the computed backoff. It does not cap Retry-After.
+	MaxBackoff time.Duration
+
+	// Multiplier grows the backoff between attempts. Values below 1 are treated as 2.
+	Multiplier float64
+}
+
+// DefaultRetryPolicy is a reasonable policy for most applications:
+// three retries starting at 500ms and capped at 10s.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Multiplier:     2,
+}
+
+// WithRetryPolicy enables automatic retries using the given policy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+type idempotencyKeyContextKey struct{}
+
+// ContextWithIdempotencyKey attaches an idempotency key to ctx. Requests made
+// with the returned context send it in the privy-idempotency-key header, which
+// also makes POST and PATCH requests eligible for retries.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// idempotencyKeyFromContext returns the idempotency key attached to ctx, if any.
+func idempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// backoff returns the jittered delay before the given retry (0-based).
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	delay := float64(p.InitialBackoff)
+	for i := 0; i < retry; i++ {
+		delay *= multiplier
+		if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// Full jitter: spread retries from many clients across the whole window
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// retryable reports whether a request should be retried after err.
+func (p RetryPolicy) retryable(ctx context.Context, method string, retry int, err error) bool {
+	if retry >= p.MaxRetries || ctx.Err() != nil {
+		return false
+	}
+	if !isIdempotentMethod(method) && idempotencyKeyFromContext(ctx) == "" {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return isTransientNetworkError(err)
+}
+
+// isIdempotentMethod reports whether repeating method has no additional effect.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isTransientNetworkError reports whether err is a network failure worth retrying.
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// retryAfterFromError returns the Retry-After delay carried by an APIError.
+func retryAfterFromError(err error) (time.Duration, bool) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Header == nil {
+		return 0, false
+	}
+	return parseRetryAfter(apiErr.Header.Get("Retry-After"), time.Now())
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,221 @@
+package privy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+}
+
+func TestRetry_GetRetriesServerErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "wallet-1"})
+	}))
+	defer server.Close()
+
+	client := NewClient("app-id", "app-secret",
+		WithBaseURL(server.URL+"/v1"),
+		WithRetryPolicy(testRetryPolicy),
+	)
+
+	wallet, err := client.Wallets().Get(context.Background(), "wallet-1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if wallet.ID != "wallet-1" {
+		t.Errorf("expected wallet-1, got %s", wallet.ID)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetry_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient("app-id", "app-secret",
+		WithBaseURL(server.URL+"/v1"),
+		WithRetryPolicy(testRetryPolicy),
+	)
+
+	_, err := client.Wallets().Get(context.Background(), "wallet-1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 APIError, got %v", err)
+	}
+	if calls != 4 {
+		t.Errorf("expected 4 attempts, got %d", calls)
+	}
+}
+
+func TestRetry_DoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient("app-id", "app-secret",
+		WithBaseURL(server.URL+"/v1"),
+		WithRetryPolicy(testRetryPolicy),
+	)
+
+	if _, err := client.Wallets().Get(context.Background(), "wallet-1"); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestRetry_PostRequiresIdempotencyKey(t *testing.T) {
+	var calls int32
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		keys = append(keys, r.Header.Get("privy-idempotency-key"))
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient("app-id", "app-secret",
+		WithBaseURL(server.URL+"/v1"),
+		WithRetryPolicy(testRetryPolicy),
+	)
+
+	if _, err := client.RawSign(context.Background(), "wallet-1", "0x1234"); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("expected POST without idempotency key to be attempted once, got %d", calls)
+	}
+
+	calls, keys = 0, nil
+	_, err := client.Wallets().Create(context.Background(), &CreateWalletRequest{
+		ChainType:      ChainTypeEthereum,
+		IdempotencyKey: "create-1",
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 4 {
+		t.Errorf("expected POST with idempotency key to be retried, got %d attempts", calls)
+	}
+	for _, key := range keys {
+		if key != "create-1" {
+			t.Errorf("expected idempotency key header create-1, got %q", key)
+		}
+	}
+
+	calls = 0
+	ctx := ContextWithIdempotencyKey(context.Background(), "sign-1")
+	client.RawSign(ctx, "wallet-1", "0x1234")
+	if calls != 4 {
+		t.Errorf("expected RawSign with context idempotency key to be retried, got %d attempts", calls)
+	}
+}
+
+func TestRetry_HonoursRetryAfter(t *testing.T) {
+	var calls int32
+	var first time.Time
+	var elapsed time.Duration
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		elapsed = time.Since(first)
+		json.NewEncoder(w).Encode(map[string]any{"id": "wallet-1"})
+	}))
+	defer server.Close()
+
+	client := NewClient("app-id", "app-secret",
+		WithBaseURL(server.URL+"/v1"),
+		WithRetryPolicy(testRetryPolicy),
+	)
+
+	if _, err := client.Wallets().Get(context.Background(), "wallet-1"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if elapsed < time.Second {
+		t.Errorf("expected retry to wait for Retry-After, waited %v", elapsed)
+	}
+}
+
+func TestRetry_StopsOnContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient("app-id", "app-secret",
+		WithBaseURL(server.URL+"/v1"),
+		WithRetryPolicy(testRetryPolicy),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Wallets().Get(ctx, "wallet-1")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("expected retry wait to be interrupted by context cancellation")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error to wrap context.DeadlineExceeded, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected error to wrap the last API error, got %v", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if d, ok := parseRetryAfter("3", now); !ok || d != 3*time.Second {
+		t.Errorf("expected 3s, got %v (%v)", d, ok)
+	}
+	if d, ok := parseRetryAfter(now.Add(10*time.Second).Format(http.TimeFormat), now); !ok || d != 10*time.Second {
+		t.Errorf("expected 10s, got %v (%v)", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon", now); ok {
+		t.Error("expected invalid Retry-After to be rejected")
+	}
+}
+
+func TestRetryPolicy_BackoffIsCapped(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+	for retry := 0; retry < 50; retry++ {
+		if d := policy.backoff(retry); d < 0 || d > time.Second {
+			t.Errorf("retry %d: backoff %v outside [0, 1s]", retry, d)
+		}
+	}
+}
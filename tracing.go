@@ -0,0 +1,104 @@
+package privy
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// Attribute keys set on API call spans.
+const (
+	AttrOperation  = "privy.operation"
+	AttrWalletID   = "privy.wallet_id"
+	AttrChainType  = "privy.chain_type"
+	AttrCAIP2      = "privy.caip2"
+	AttrRPCMethod  = "privy.rpc_method"
+	AttrRetryCount = "privy.retry_count"
+	AttrHTTPMethod = "http.request.method"
+	AttrStatusCode = "http.response.status_code"
+)
+
+// Attribute is a key/value pair recorded on a span.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Attr returns an Attribute.
+func Attr(key string, value any) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Tracer starts spans for API calls and chain helper phases. The otelprivy
+// module provides an OpenTelemetry implementation; the core SDK has no
+// tracing dependency.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a traced unit of work started by a Tracer.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+
+	// End finishes the span, marking it failed if err is non-nil.
+	End(err error)
+}
+
+// WithTracer traces every API call, named after its operation
+// (wallets.raw_sign, users.get, ...), and the phases of chain helper calls.
+func WithTracer(tracer Tracer) ClientOption {
+	return func(c *Client) {
+		c.tracer = tracer
+	}
+}
+
+// StartSpan starts a span with the client's tracer. Without a tracer it
+// returns ctx unchanged and a no-op span, so callers can always End it.
+func (c *Client) StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	if c == nil || c.tracer == nil {
+		return ctx, noopSpan{}
+	}
+	return c.tracer.Start(ctx, name, attrs...)
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+func (noopSpan) End(error)                  {}
+
+// requestAttributes describes an API call for its span: the wallet it
+// targets and, for wallet RPC, the chain and method.
+func requestAttributes(operation, method, rawURL string, body []byte) []Attribute {
+	attrs := []Attribute{
+		Attr(AttrOperation, operation),
+		Attr(AttrHTTPMethod, method),
+	}
+
+	if i := strings.Index(rawURL, "/wallets/"); i >= 0 {
+		id := rawURL[i+len("/wallets/"):]
+		if j := strings.IndexAny(id, "/?"); j >= 0 {
+			id = id[:j]
+		}
+		if id != "" && id != "import" {
+			attrs = append(attrs, Attr(AttrWalletID, id))
+		}
+	}
+
+	var rpc struct {
+		Method    string `json:"method"`
+		ChainType string `json:"chain_type"`
+		CAIP2     string `json:"caip2"`
+	}
+	if len(body) > 0 && json.Unmarshal(body, &rpc) == nil {
+		if rpc.ChainType != "" {
+			attrs = append(attrs, Attr(AttrChainType, rpc.ChainType))
+		}
+		if rpc.CAIP2 != "" {
+			attrs = append(attrs, Attr(AttrCAIP2, rpc.CAIP2))
+		}
+		if rpc.Method != "" && strings.HasSuffix(operation, "."+rpc.Method) {
+			attrs = append(attrs, Attr(AttrRPCMethod, rpc.Method))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,119 @@
+package privy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type recordedSpan struct {
+	name  string
+	attrs map[string]any
+	err   error
+	ended bool
+}
+
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	span := &recordedSpan{name: name, attrs: map[string]any{}}
+	span.SetAttributes(attrs...)
+	r.spans = append(r.spans, span)
+	return ctx, span
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...Attribute) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *recordedSpan) End(err error) {
+	s.err = err
+	s.ended = true
+}
+
+func TestWithTracer_SpanPerCall(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"method": "eth_sendTransaction"})
+	}))
+	defer server.Close()
+
+	tracer := &recordingTracer{}
+	client := NewClient("app-id", "app-secret",
+		WithBaseURL(server.URL+"/v1"),
+		WithTracer(tracer),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond}),
+	)
+
+	ctx := ContextWithIdempotencyKey(context.Background(), "tx-1")
+	_, err := client.Wallets().Ethereum().SendTransaction(ctx, "wallet-1", &EthereumTransaction{To: "0xabc"}, 8453, false, "")
+	if err != nil {
+		t.Fatalf("SendTransaction failed: %v", err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected 1 span for the call including retries, got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "wallets.rpc.eth_sendTransaction" {
+		t.Errorf("unexpected span name %q", span.name)
+	}
+	want := map[string]any{
+		AttrWalletID:   "wallet-1",
+		AttrCAIP2:      "eip155:8453",
+		AttrRPCMethod:  "eth_sendTransaction",
+		AttrStatusCode: http.StatusOK,
+		AttrRetryCount: 1,
+	}
+	for k, v := range want {
+		if span.attrs[k] != v {
+			t.Errorf("attribute %s = %v, want %v", k, span.attrs[k], v)
+		}
+	}
+	if !span.ended || span.err != nil {
+		t.Errorf("expected span to end without error, got ended=%v err=%v", span.ended, span.err)
+	}
+}
+
+func TestWithTracer_RecordsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	tracer := &recordingTracer{}
+	client := NewClient("app-id", "app-secret", WithBaseURL(server.URL+"/v1"), WithTracer(tracer))
+
+	client.Wallets().Get(context.Background(), "wallet-1")
+
+	span := tracer.spans[0]
+	if span.name != "wallets.get" || !IsNotFound(span.err) {
+		t.Errorf("expected wallets.get span ending with ErrNotFound, got %s / %v", span.name, span.err)
+	}
+	if span.attrs[AttrStatusCode] != http.StatusNotFound {
+		t.Errorf("expected status code 404, got %v", span.attrs[AttrStatusCode])
+	}
+}
+
+func TestStartSpan_NoTracer(t *testing.T) {
+	var client *Client
+	ctx := context.Background()
+	gotCtx, span := client.StartSpan(ctx, "bitcoin.transfer")
+	if gotCtx != ctx {
+		t.Error("expected context to be returned unchanged")
+	}
+	span.SetAttributes(Attr("k", "v"))
+	span.End(nil)
+}
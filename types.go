@@ -405,9 +405,18 @@ type ConditionSetItem struct {
 
 // KeyQuorum represents a key quorum for wallet authorization.
 type KeyQuorum struct {
-	ID        string `json:"id"`
-	PublicKey string `json:"public_key"`
-	CreatedAt int64  `json:"created_at"`
+	ID                     string                      `json:"id"`
+	PublicKey              string                      `json:"public_key"`
+	DisplayName            string                      `json:"display_name,omitempty"`
+	AuthorizationKeys      []KeyQuorumAuthorizationKey `json:"authorization_keys,omitempty"`
+	AuthorizationThreshold int                         `json:"authorization_threshold,omitempty"`
+	CreatedAt              int64                       `json:"created_at"`
+}
+
+// KeyQuorumAuthorizationKey represents one member key of a key quorum.
+type KeyQuorumAuthorizationKey struct {
+	PublicKey   string `json:"public_key"`
+	DisplayName string `json:"display_name,omitempty"`
 }
 
 // PaginatedResponse represents a paginated API response.
@@ -356,6 +356,24 @@ func (s *UsersService) List(ctx context.Context, opts *ListOptions) (*PaginatedR
 	return &resp, nil
 }
 
+// Iter returns an Iterator over all users, starting at opts.Cursor.
+func (s *UsersService) Iter(ctx context.Context, opts *ListOptions, iterOpts *IteratorOptions) *Iterator[User] {
+	var base ListOptions
+	if opts != nil {
+		base = *opts
+	}
+	return NewIterator(ctx, base.Cursor, func(ctx context.Context, cursor string) (*PaginatedResponse[User], error) {
+		page := base
+		page.Cursor = cursor
+		return s.List(ctx, &page)
+	}, iterOpts)
+}
+
+// All iterates over all users, fetching pages lazily.
+func (s *UsersService) All(ctx context.Context, opts *ListOptions, iterOpts *IteratorOptions) Seq2[User, error] {
+	return s.Iter(ctx, opts, iterOpts).All()
+}
+
 // GetByEmail retrieves a user by their email address.
 func (s *UsersService) GetByEmail(ctx context.Context, email string) (*User, error) {
 	if s == nil || s.client == nil {
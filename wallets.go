@@ -119,6 +119,11 @@ func (s *WalletsService) Create(ctx context.Context, req *CreateWalletRequest) (
 	}
 	u := fmt.Sprintf("%s/wallets", s.client.baseURL)
 
+	// Send the key as a header too so retries of this POST are allowed
+	if req != nil && req.IdempotencyKey != "" && idempotencyKeyFromContext(ctx) == "" {
+		ctx = ContextWithIdempotencyKey(ctx, req.IdempotencyKey)
+	}
+
 	var wallet Wallet
 	if err := s.client.doRequest(ctx, "POST", u, req, &wallet); err != nil {
 		return nil, err
@@ -184,6 +189,24 @@ func (s *WalletsService) List(ctx context.Context, opts *WalletListOptions) (*Pa
 	return &resp, nil
 }
 
+// Iter returns an Iterator over all wallets matching opts, starting at opts.Cursor.
+func (s *WalletsService) Iter(ctx context.Context, opts *WalletListOptions, iterOpts *IteratorOptions) *Iterator[Wallet] {
+	var base WalletListOptions
+	if opts != nil {
+		base = *opts
+	}
+	return NewIterator(ctx, base.Cursor, func(ctx context.Context, cursor string) (*PaginatedResponse[Wallet], error) {
+		page := base
+		page.Cursor = cursor
+		return s.List(ctx, &page)
+	}, iterOpts)
+}
+
+// All iterates over all wallets matching opts, fetching pages lazily.
+func (s *WalletsService) All(ctx context.Context, opts *WalletListOptions, iterOpts *IteratorOptions) Seq2[Wallet, error] {
+	return s.Iter(ctx, opts, iterOpts).All()
+}
+
 // Update updates a wallet's policies or additional signers.
 func (s *WalletsService) Update(ctx context.Context, walletID string, req *UpdateWalletRequest) (*Wallet, error) {
 	if s == nil || s.client == nil {
@@ -192,7 +215,7 @@ func (s *WalletsService) Update(ctx context.Context, walletID string, req *Updat
 	u := fmt.Sprintf("%s/wallets/%s", s.client.baseURL, walletID)
 
 	var wallet Wallet
-	if err := s.client.doRequest(ctx, "PATCH", u, req, &wallet); err != nil {
+	if err := s.client.doRequestWithSignature(ctx, "PATCH", u, req, &wallet, ""); err != nil {
 		return nil, err
 	}
 
@@ -312,6 +335,28 @@ func (s *WalletsService) GetTransactions(ctx context.Context, walletID string, o
 	return &resp, nil
 }
 
+// IterTransactions returns an Iterator over a wallet's transaction history.
+// opts is required, as for GetTransactions.
+func (s *WalletsService) IterTransactions(ctx context.Context, walletID string, opts *GetTransactionsOptions, iterOpts *IteratorOptions) *Iterator[Transaction] {
+	var cursor string
+	if opts != nil {
+		cursor = opts.Cursor
+	}
+	return NewIterator(ctx, cursor, func(ctx context.Context, cursor string) (*PaginatedResponse[Transaction], error) {
+		if opts == nil {
+			return s.GetTransactions(ctx, walletID, nil)
+		}
+		page := *opts
+		page.Cursor = cursor
+		return s.GetTransactions(ctx, walletID, &page)
+	}, iterOpts)
+}
+
+// AllTransactions iterates over a wallet's transaction history, fetching pages lazily.
+func (s *WalletsService) AllTransactions(ctx context.Context, walletID string, opts *GetTransactionsOptions, iterOpts *IteratorOptions) Seq2[Transaction, error] {
+	return s.IterTransactions(ctx, walletID, opts, iterOpts).All()
+}
+
 // InitializeImport initializes the wallet import process.
 func (s *WalletsService) InitializeImport(ctx context.Context, req *ImportWalletInitRequest) (*ImportWalletInitResponse, error) {
 	if s == nil || s.client == nil {